@@ -0,0 +1,110 @@
+// Copyright 2019 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bulldozer
+
+import (
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// codeOwnersRule is a line of a CODEOWNERS file: a pattern and the owners of
+// the files it matches.
+type codeOwnersRule struct {
+	pattern *regexp.Regexp
+	owners  []string
+}
+
+// parseCodeOwners parses the contents of a CODEOWNERS file. Like GitHub, it
+// skips comments, blank lines, and lines with patterns it cannot parse.
+func parseCodeOwners(contents string) []codeOwnersRule {
+	var rules []codeOwnersRule
+	for _, line := range strings.Split(contents, "\n") {
+		if i := strings.Index(line, " #"); i >= 0 {
+			line = line[:i]
+		}
+		fields := strings.Fields(line)
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+
+		pattern, err := codeOwnersPattern(strings.TrimPrefix(fields[0], `\`))
+		if err != nil {
+			continue
+		}
+		rules = append(rules, codeOwnersRule{pattern: pattern, owners: fields[1:]})
+	}
+	return rules
+}
+
+// ownersOf returns the owners of a file. The last rule that matches the file
+// takes precedence, so a matching rule without owners means the file has no
+// owners even if an earlier rule assigns some.
+func ownersOf(rules []codeOwnersRule, filename string) []string {
+	for i := len(rules) - 1; i >= 0; i-- {
+		if rules[i].pattern.MatchString(filename) {
+			return rules[i].owners
+		}
+	}
+	return nil
+}
+
+// codeOwnersPattern converts a CODEOWNERS pattern, which mostly follows the
+// rules of gitignore files, to a regular expression matching the paths of
+// the files it applies to. Patterns containing a slash other than at the end
+// are relative to the root of the repository; other patterns match at any
+// depth. A pattern matching a directory matches every file in it, except
+// that a pattern ending in "/*" only matches the files directly inside.
+func codeOwnersPattern(pattern string) (*regexp.Regexp, error) {
+	anchored := strings.Contains(strings.TrimSuffix(pattern, "/"), "/")
+	directChildren := strings.HasSuffix(pattern, "/*") && !strings.HasSuffix(pattern, "/**")
+	directory := strings.HasSuffix(pattern, "/")
+	pattern = strings.Trim(pattern, "/")
+
+	var b strings.Builder
+	b.WriteString("^")
+	if !anchored {
+		b.WriteString("(?:.*/)?")
+	}
+	for rest := pattern; rest != ""; {
+		switch {
+		case strings.HasPrefix(rest, "**/"):
+			b.WriteString("(?:.*/)?")
+			rest = rest[3:]
+		case strings.HasPrefix(rest, "**"):
+			b.WriteString(".*")
+			rest = rest[2:]
+		case rest[0] == '*':
+			b.WriteString("[^/]*")
+			rest = rest[1:]
+		case rest[0] == '?':
+			b.WriteString("[^/]")
+			rest = rest[1:]
+		default:
+			r, size := utf8.DecodeRuneInString(rest)
+			b.WriteString(regexp.QuoteMeta(string(r)))
+			rest = rest[size:]
+		}
+	}
+	switch {
+	case directChildren:
+		b.WriteString("$")
+	case directory:
+		b.WriteString("/.*$")
+	default:
+		b.WriteString("(?:/.*)?$")
+	}
+	return regexp.Compile(b.String())
+}
@@ -0,0 +1,56 @@
+// Copyright 2019 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bulldozer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOwnersOf(t *testing.T) {
+	rules := parseCodeOwners(`
+# default owners
+*                   @palantir/everyone
+
+*.go                @gopher # inline comment
+/build/             @palantir/build
+docs/*              @writer
+apps/**/config      @palantir/config
+logs/               @logger
+/vendor/
+`)
+
+	tests := map[string][]string{
+		"README.md":                       {"@palantir/everyone"},
+		"main.go":                         {"@gopher"},
+		"bulldozer/signals.go":            {"@gopher"},
+		"build/release.sh":                {"@palantir/build"},
+		"tools/build/release.sh":          {"@palantir/everyone"},
+		"docs/index.md":                   {"@writer"},
+		"docs/guides/setup.md":            {"@palantir/everyone"},
+		"apps/config":                     {"@palantir/config"},
+		"apps/server/prod/config":         {"@palantir/config"},
+		"apps/server/prod/config/app.yml": {"@palantir/config"},
+		"server/logs/out.txt":             {"@logger"},
+		"vendor/github.com/pkg/errors.go": {},
+	}
+
+	for filename, owners := range tests {
+		t.Run(filename, func(t *testing.T) {
+			assert.Equal(t, owners, ownersOf(rules, filename))
+		})
+	}
+}
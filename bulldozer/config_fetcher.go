@@ -53,6 +53,7 @@ type ConfigFetcher struct {
 	configurationV1Path     string
 	configurationV0Paths    []string
 	defaultRepositoryConfig *Config
+	lookup                  LookupFunc
 }
 
 func NewConfigFetcher(configurationV1Path string, configurationV0Paths []string, defaultRepositoryConfig *Config) ConfigFetcher {
@@ -63,6 +64,14 @@ func NewConfigFetcher(configurationV1Path string, configurationV0Paths []string,
 	}
 }
 
+// WithLookup returns a copy of the fetcher that interpolates variables in the
+// signals of each fetched configuration using lookup. See
+// Signals.Interpolate for the supported syntax.
+func (cf ConfigFetcher) WithLookup(lookup LookupFunc) ConfigFetcher {
+	cf.lookup = lookup
+	return cf
+}
+
 // ConfigForPR fetches the configuration for a PR. It returns an error
 // only if the existence of the configuration file could not be determined. If the file
 // does not exist or is invalid, the returned error is nil and the appropriate
@@ -148,6 +157,22 @@ func (cf *ConfigFetcher) unmarshalConfig(bytes []byte) (*Config, error) {
 		return nil, errors.Wrapf(err, "failed to unmarshal configuration")
 	}
 
+	for _, signals := range []*Signals{
+		&config.Merge.Trigger, &config.Merge.Ignore, &config.Merge.Blacklist, &config.Merge.Whitelist,
+		&config.Update.Trigger, &config.Update.Ignore, &config.Update.Blacklist, &config.Update.Whitelist,
+	} {
+		resolved, err := signals.ResolveExtends(config.SignalFragments)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to resolve signal fragments")
+		}
+		if cf.lookup != nil {
+			if resolved, err = resolved.Interpolate(cf.lookup); err != nil {
+				return nil, errors.Wrap(err, "failed to interpolate signal values")
+			}
+		}
+		*signals = resolved
+	}
+
 	// Merge old signals configurations if they exist when the new values aren't present
 	if config.Merge.Blacklist.Enabled() && !config.Merge.Ignore.Enabled() {
 		config.Merge.Ignore = config.Merge.Blacklist
@@ -182,12 +207,12 @@ func (cf *ConfigFetcher) unmarshalConfigV0(bytes []byte) (*Config, error) {
 			Version: 1,
 			Update: UpdateConfig{
 				Trigger: Signals{
-					Labels: []string{"update me", "update-me", "update_me"},
+					Labels: SubSignal{Values: []string{"update me", "update-me", "update_me"}},
 				},
 			},
 			Merge: MergeConfig{
 				Trigger: Signals{
-					Labels: []string{"merge when ready", "merge-when-ready", "merge_when_ready"},
+					Labels: SubSignal{Values: []string{"merge when ready", "merge-when-ready", "merge_when_ready"}},
 				},
 				DeleteAfterMerge: configv0.DeleteAfterMerge,
 				Method:           configv0.Strategy,
@@ -203,12 +228,12 @@ func (cf *ConfigFetcher) unmarshalConfigV0(bytes []byte) (*Config, error) {
 			Version: 1,
 			Update: UpdateConfig{
 				Trigger: Signals{
-					Labels: []string{"update me", "update-me", "update_me"},
+					Labels: SubSignal{Values: []string{"update me", "update-me", "update_me"}},
 				},
 			},
 			Merge: MergeConfig{
 				Ignore: Signals{
-					Labels: []string{"wip", "do not merge", "do-not-merge", "do_not_merge"},
+					Labels: SubSignal{Values: []string{"wip", "do not merge", "do-not-merge", "do_not_merge"}},
 				},
 				DeleteAfterMerge: configv0.DeleteAfterMerge,
 				Method:           configv0.Strategy,
@@ -224,12 +249,12 @@ func (cf *ConfigFetcher) unmarshalConfigV0(bytes []byte) (*Config, error) {
 			Version: 1,
 			Update: UpdateConfig{
 				Trigger: Signals{
-					Labels: []string{"update me", "update-me", "update_me"},
+					Labels: SubSignal{Values: []string{"update me", "update-me", "update_me"}},
 				},
 			},
 			Merge: MergeConfig{
 				Trigger: Signals{
-					CommentSubstrings: []string{"==MERGE_WHEN_READY=="},
+					CommentSubstrings: SubSignal{Values: []string{"==MERGE_WHEN_READY=="}},
 				},
 				DeleteAfterMerge: configv0.DeleteAfterMerge,
 				Method:           configv0.Strategy,
@@ -51,12 +51,12 @@ update:
 		actual, err := cf.unmarshalConfig([]byte(config))
 		require.Nil(t, err)
 		assert.Equal(t, Signals{
-			Labels:            []string{"merge when ready"},
-			CommentSubstrings: []string{"==MERGE_WHEN_READY=="},
+			Labels:            SubSignal{Values: []string{"merge when ready"}},
+			CommentSubstrings: SubSignal{Values: []string{"==MERGE_WHEN_READY=="}},
 		}, actual.Merge.Trigger)
 		assert.Equal(t, Signals{
-			Labels:            []string{"do not merge"},
-			CommentSubstrings: []string{"==DO_NOT_MERGE=="},
+			Labels:            SubSignal{Values: []string{"do not merge"}},
+			CommentSubstrings: SubSignal{Values: []string{"==DO_NOT_MERGE=="}},
 		}, actual.Merge.Ignore)
 	})
 
@@ -90,19 +90,19 @@ update:
 		require.Nil(t, err)
 
 		assert.Equal(t, Signals{
-			Labels:            []string{"merge when ready"},
-			CommentSubstrings: []string{"==OLD_MERGE_WHEN_READY=="},
+			Labels:            SubSignal{Values: []string{"merge when ready"}},
+			CommentSubstrings: SubSignal{Values: []string{"==OLD_MERGE_WHEN_READY=="}},
 		}, actual.Merge.Trigger)
 		assert.Equal(t, Signals{
-			Labels:            []string{"do not merge"},
-			CommentSubstrings: []string{"==OLD_DO_NOT_MERGE=="},
+			Labels:            SubSignal{Values: []string{"do not merge"}},
+			CommentSubstrings: SubSignal{Values: []string{"==OLD_DO_NOT_MERGE=="}},
 		}, actual.Merge.Ignore)
 
 		assert.Equal(t, Signals{
-			Labels: []string{"wip", "update me"},
+			Labels: SubSignal{Values: []string{"wip", "update me"}},
 		}, actual.Update.Trigger)
 		assert.Equal(t, Signals{
-			Labels: []string{"do not update"},
+			Labels: SubSignal{Values: []string{"do not update"}},
 		}, actual.Update.Ignore)
 	})
 
@@ -144,17 +144,166 @@ update:
 		require.Nil(t, err)
 
 		assert.Equal(t, Signals{
-			Labels: []string{"mwr"},
+			Labels: SubSignal{Values: []string{"mwr"}},
 		}, actual.Merge.Trigger)
 		assert.Equal(t, Signals{
-			Labels: []string{"new dnm"},
+			Labels: SubSignal{Values: []string{"new dnm"}},
 		}, actual.Merge.Ignore)
 
 		assert.Equal(t, Signals{
-			Labels: []string{"new wip"},
+			Labels: SubSignal{Values: []string{"new wip"}},
 		}, actual.Update.Trigger)
 		assert.Equal(t, Signals{
-			Labels: []string{"new dnu"},
+			Labels: SubSignal{Values: []string{"new dnu"}},
 		}, actual.Update.Ignore)
 	})
+	t.Run("parseMatchTypes", func(t *testing.T) {
+		cf := NewConfigFetcher("", []string{""}, nil)
+
+		config := `
+version: 1
+
+merge:
+  trigger:
+    match: all
+    labels:
+      values: ["merge when ready", "reviewed"]
+      match: one
+    branches: ["develop"]
+`
+
+		actual, err := cf.unmarshalConfig([]byte(config))
+		require.Nil(t, err)
+
+		assert.Equal(t, Signals{
+			Match:    MatchAll,
+			Labels:   SubSignal{Values: []string{"merge when ready", "reviewed"}, Match: MatchOne},
+			Branches: SubSignal{Values: []string{"develop"}},
+		}, actual.Merge.Trigger)
+	})
+
+	t.Run("rejectsUnknownMatchType", func(t *testing.T) {
+		cf := NewConfigFetcher("", []string{""}, nil)
+
+		config := `
+version: 1
+
+merge:
+  trigger:
+    match: onee
+    labels: ["merge when ready"]
+`
+
+		_, err := cf.unmarshalConfig([]byte(config))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `invalid match type "onee": must be one of "one", "all", "score"`)
+	})
+
+	t.Run("parseDisabledSubSignal", func(t *testing.T) {
+		cf := NewConfigFetcher("", []string{""}, nil)
+
+		config := `
+version: 1
+
+merge:
+  trigger:
+    labels:
+      values: ["merge when ready"]
+      enabled: false
+`
+
+		actual, err := cf.unmarshalConfig([]byte(config))
+		require.Nil(t, err)
+
+		disabled := false
+		assert.Equal(t, SubSignal{Values: []string{"merge when ready"}, Enabled: &disabled}, actual.Merge.Trigger.Labels)
+		assert.False(t, actual.Merge.Trigger.Enabled())
+	})
+
+	t.Run("parseRequiredStatuses", func(t *testing.T) {
+		cf := NewConfigFetcher("", []string{""}, nil)
+
+		config := `
+version: 1
+
+merge:
+  required_statuses:
+    - "ci/circleci: ete-tests"
+  required_app_statuses:
+    - context: build
+      app: github-actions
+`
+
+		actual, err := cf.unmarshalConfig([]byte(config))
+		require.Nil(t, err)
+
+		assert.Equal(t, []string{"ci/circleci: ete-tests"}, actual.Merge.RequiredStatuses)
+		assert.Equal(t, []RequiredStatus{
+			{Context: "build", App: "github-actions"},
+		}, actual.Merge.RequiredAppStatuses)
+	})
+
+	t.Run("rejectsUnknownSubSignalKeys", func(t *testing.T) {
+		cf := NewConfigFetcher("", []string{""}, nil)
+
+		config := `
+version: 1
+
+merge:
+  trigger:
+    labels:
+      value: ["merge when ready"]
+`
+
+		_, err := cf.unmarshalConfig([]byte(config))
+		assert.Error(t, err)
+	})
+	t.Run("parseSignalFragments", func(t *testing.T) {
+		cf := NewConfigFetcher("", []string{""}, nil)
+
+		config := `
+version: 1
+
+signal_fragments:
+  do_not_merge:
+    labels: ["do not merge"]
+    comment_substrings: ["==DO_NOT_MERGE=="]
+
+merge:
+  ignore:
+    extends: ["do_not_merge"]
+    labels: ["wip"]
+
+update:
+  ignore:
+    extends: ["do_not_merge"]
+`
+
+		actual, err := cf.unmarshalConfig([]byte(config))
+		require.Nil(t, err)
+
+		assert.Equal(t, Signals{
+			Labels:            SubSignal{Values: []string{"do not merge", "wip"}},
+			CommentSubstrings: SubSignal{Values: []string{"==DO_NOT_MERGE=="}},
+		}, actual.Merge.Ignore)
+		assert.Equal(t, Signals{
+			Labels:            SubSignal{Values: []string{"do not merge"}},
+			CommentSubstrings: SubSignal{Values: []string{"==DO_NOT_MERGE=="}},
+		}, actual.Update.Ignore)
+	})
+
+	t.Run("rejectsUnknownSignalFragments", func(t *testing.T) {
+		cf := NewConfigFetcher("", []string{""}, nil)
+
+		config := `
+version: 1
+
+merge:
+  ignore:
+    extends: ["do_not_merge"]
+`
+
+		_, err := cf.unmarshalConfig([]byte(config))
+		assert.Error(t, err)
+	})
 }
@@ -14,6 +14,10 @@
 
 package bulldozer
 
+import (
+	"fmt"
+)
+
 type MessageStrategy string
 type TitleStrategy string
 type MergeMethod string
@@ -51,6 +55,33 @@ type MergeConfig struct {
 	// Additional status checks that bulldozer should require
 	// (even if the branch protection settings doesn't require it)
 	RequiredStatuses []string `yaml:"required_statuses"`
+
+	// RequiredAppStatuses are additional status checks that must be reported
+	// by a specific GitHub App, so that checks with the same name from
+	// different apps can be told apart.
+	RequiredAppStatuses []RequiredStatus `yaml:"required_app_statuses"`
+}
+
+// RequiredStatus is a status check that must succeed before bulldozer merges
+// a pull request. If App is set, only a check run created by the GitHub App
+// with that slug satisfies the requirement; otherwise, it is like an entry
+// of RequiredStatuses.
+type RequiredStatus struct {
+	Context string `yaml:"context"`
+	App     string `yaml:"app"`
+}
+
+func (rs RequiredStatus) String() string {
+	if rs.App == "" {
+		return rs.Context
+	}
+	return fmt.Sprintf("%s (app:%s)", rs.Context, rs.App)
+}
+
+// tracksMergeAttempts returns true if the trigger or ignore signals limit the
+// number of failed merge attempts, in which case failed attempts are recorded.
+func (mc MergeConfig) tracksMergeAttempts() bool {
+	return mc.Trigger.MaxMergeAttempts > 0 || mc.Ignore.MaxMergeAttempts > 0
 }
 
 type MergeOptions struct {
@@ -75,6 +106,10 @@ type UpdateConfig struct {
 type Config struct {
 	Version int `yaml:"version"`
 
+	// SignalFragments are named sets of signals that trigger and ignore
+	// sections can include using "extends".
+	SignalFragments map[string]Signals `yaml:"signal_fragments"`
+
 	Merge  MergeConfig  `yaml:"merge"`
 	Update UpdateConfig `yaml:"update"`
 }
@@ -0,0 +1,190 @@
+// Copyright 2019 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bulldozer
+
+import (
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// diffLine is an added or removed line in a unified diff.
+type diffLine struct {
+	File    string
+	Added   bool
+	Content string
+
+	// Line is the line number of an added line in the new version of the
+	// file, or of a removed line in the old version.
+	Line int
+}
+
+var hunkHeaderPattern = regexp.MustCompile(`^@@ -(\d+)(?:,\d+)? \+(\d+)(?:,\d+)? @@`)
+
+// parseDiff returns the added and removed lines in a unified diff, in the
+// order they appear. Context lines and file headers are not included.
+func parseDiff(diff string) []diffLine {
+	var lines []diffLine
+	var file string
+	var oldLine, newLine int
+	inHunk := false
+
+	for _, line := range strings.Split(diff, "\n") {
+		switch {
+		case strings.HasPrefix(line, "diff --git "):
+			inHunk = false
+		case strings.HasPrefix(line, "@@"):
+			inHunk = true
+			if m := hunkHeaderPattern.FindStringSubmatch(line); m != nil {
+				oldLine, _ = strconv.Atoi(m[1])
+				newLine, _ = strconv.Atoi(m[2])
+			}
+		case !inHunk:
+			// file headers use "/dev/null" for the missing side of
+			// created and deleted files, so prefer whichever is present
+			if strings.HasPrefix(line, "--- a/") {
+				file = strings.TrimPrefix(line, "--- a/")
+			}
+			if strings.HasPrefix(line, "+++ b/") {
+				file = strings.TrimPrefix(line, "+++ b/")
+			}
+		case strings.HasPrefix(line, "+"):
+			lines = append(lines, diffLine{File: file, Added: true, Content: line[1:], Line: newLine})
+			newLine++
+		case strings.HasPrefix(line, "-"):
+			lines = append(lines, diffLine{File: file, Added: false, Content: line[1:], Line: oldLine})
+			oldLine++
+		case strings.HasPrefix(line, "\\"):
+			// "\ No newline at end of file" applies to the previous line
+		default:
+			oldLine++
+			newLine++
+		}
+	}
+	return lines
+}
+
+// conflictMarkerFiles returns the files, in order, where the added lines
+// contain a complete set of merge conflict markers: a line starting with
+// "<<<<<<<", followed by a line that is "=======", followed by a line
+// starting with ">>>>>>>". Each marker must be exactly seven characters at
+// the start of the line, so longer runs, like Markdown underlines, and
+// markers that are quoted or indented do not match.
+func conflictMarkerFiles(lines []diffLine) []string {
+	var files []string
+	state := make(map[string]int)
+	for _, line := range lines {
+		if !line.Added {
+			continue
+		}
+
+		content := strings.TrimRight(line.Content, "\r")
+		switch step := state[line.File]; {
+		case step == 0 && isConflictMarker(content, '<'):
+			state[line.File] = 1
+		case step == 1 && content == "=======":
+			state[line.File] = 2
+		case step == 2 && isConflictMarker(content, '>'):
+			state[line.File] = 3
+			files = append(files, line.File)
+		}
+	}
+	return files
+}
+
+// isConflictMarker returns true if line starts with exactly seven of c,
+// followed by the end of the line or a space.
+func isConflictMarker(line string, c byte) bool {
+	marker := strings.Repeat(string(c), 7)
+	if !strings.HasPrefix(line, marker) {
+		return false
+	}
+	return len(line) == len(marker) || line[len(marker)] == ' '
+}
+
+// dependency is a dependency declared in a manifest file.
+type dependency struct {
+	Manifest string
+	Name     string
+}
+
+var (
+	goModDependency        = regexp.MustCompile(`^\s*(?:require\s+)?([^\s()]+)\s+v\S+`)
+	packageJSONDependency  = regexp.MustCompile(`^\s*"([^"]+)"\s*:\s*"((?:[~^<>=v]*\d|\*|latest|(?:file|git|github|link|npm|workspace):|https?://)[^"]*)"`)
+	requirementsDependency = regexp.MustCompile(`^\s*([A-Za-z0-9][A-Za-z0-9._-]*)`)
+)
+
+// goModDirectives are go.mod directives that can be followed by a version
+// but do not declare a dependency.
+var goModDirectives = map[string]bool{"exclude": true, "retract": true, "replace": true, "toolchain": true}
+
+// packageJSONFields are package.json fields with version-like values that do
+// not declare a dependency.
+var packageJSONFields = map[string]bool{"version": true, "node": true, "npm": true}
+
+// dependencyName returns the name of the dependency declared by a line of a
+// manifest, or false if the line does not declare one. The format of the
+// line depends on the name of the manifest: "go.mod", "package.json", and
+// pip requirements files ("requirements*.txt") are supported. Lines of other
+// manifests never declare a dependency.
+func dependencyName(manifest, line string) (string, bool) {
+	switch base := path.Base(manifest); {
+	case base == "go.mod":
+		if m := goModDependency.FindStringSubmatch(line); m != nil && !goModDirectives[m[1]] {
+			return m[1], true
+		}
+	case base == "package.json":
+		if m := packageJSONDependency.FindStringSubmatch(line); m != nil && !packageJSONFields[m[1]] {
+			return m[1], true
+		}
+	case strings.HasPrefix(base, "requirements") && strings.HasSuffix(base, ".txt"):
+		if m := requirementsDependency.FindStringSubmatch(line); m != nil {
+			return m[1], true
+		}
+	}
+	return "", false
+}
+
+// addedDependencies returns the dependencies declared by added lines of the
+// manifests, in order. A dependency that is also declared by a removed line
+// of the same manifest, like one whose version changed, is not added.
+func addedDependencies(lines []diffLine, isManifest func(file string) bool) []dependency {
+	removed := make(map[dependency]bool)
+	for _, line := range lines {
+		if !line.Added && isManifest(line.File) {
+			if name, ok := dependencyName(line.File, line.Content); ok {
+				removed[dependency{line.File, name}] = true
+			}
+		}
+	}
+
+	var added []dependency
+	seen := make(map[dependency]bool)
+	for _, line := range lines {
+		if !line.Added || !isManifest(line.File) {
+			continue
+		}
+		name, ok := dependencyName(line.File, line.Content)
+		if !ok {
+			continue
+		}
+		if dep := (dependency{line.File, name}); !removed[dep] && !seen[dep] {
+			seen[dep] = true
+			added = append(added, dep)
+		}
+	}
+	return added
+}
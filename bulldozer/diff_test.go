@@ -0,0 +1,140 @@
+// Copyright 2019 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bulldozer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testDiff = `diff --git a/main.go b/main.go
+index 3f4c2a1..8d2e9b0 100644
+--- a/main.go
++++ b/main.go
+@@ -1,4 +1,4 @@
+ package main
+ 
+--- removed sql comment
++// TODO: remove this
+diff --git a/old.txt b/old.txt
+deleted file mode 100644
+index 3f4c2a1..0000000
+--- a/old.txt
++++ /dev/null
+@@ -1 +0,0 @@
+-secret=hunter2
+diff --git a/new.txt b/new.txt
+new file mode 100644
+index 0000000..3f4c2a1
+--- /dev/null
++++ b/new.txt
+@@ -0,0 +1 @@
++hello
+`
+
+func TestParseDiff(t *testing.T) {
+	lines := parseDiff(testDiff)
+
+	assert.Equal(t, []diffLine{
+		{File: "main.go", Added: false, Content: "-- removed sql comment", Line: 3},
+		{File: "main.go", Added: true, Content: "// TODO: remove this", Line: 3},
+		{File: "old.txt", Added: false, Content: "secret=hunter2", Line: 1},
+		{File: "new.txt", Added: true, Content: "hello", Line: 1},
+	}, lines)
+}
+
+func TestConflictMarkerFiles(t *testing.T) {
+	tests := map[string]struct {
+		Lines []diffLine
+		Files []string
+	}{
+		"conflict": {
+			Lines: []diffLine{
+				{File: "main.go", Added: true, Content: "<<<<<<< HEAD"},
+				{File: "main.go", Added: true, Content: "x := 1"},
+				{File: "main.go", Added: true, Content: "======="},
+				{File: "main.go", Added: true, Content: "x := 2"},
+				{File: "main.go", Added: true, Content: ">>>>>>> feature"},
+			},
+			Files: []string{"main.go"},
+		},
+		"removedConflict": {
+			Lines: []diffLine{
+				{File: "main.go", Added: false, Content: "<<<<<<< HEAD"},
+				{File: "main.go", Added: false, Content: "======="},
+				{File: "main.go", Added: false, Content: ">>>>>>> feature"},
+			},
+		},
+		"documentation": {
+			Lines: []diffLine{
+				{File: "README.md", Added: true, Content: "Resolve lines like `<<<<<<< HEAD` before merging."},
+				{File: "README.md", Added: true, Content: "Conflicts"},
+				{File: "README.md", Added: true, Content: "========="},
+				{File: "README.md", Added: true, Content: "  >>>>>>> feature"},
+			},
+		},
+		"underline": {
+			Lines: []diffLine{
+				{File: "README.rst", Added: true, Content: "Title"},
+				{File: "README.rst", Added: true, Content: "======="},
+			},
+		},
+		"markersInDifferentFiles": {
+			Lines: []diffLine{
+				{File: "a.go", Added: true, Content: "<<<<<<< HEAD"},
+				{File: "b.go", Added: true, Content: "======="},
+				{File: "a.go", Added: true, Content: ">>>>>>> feature"},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.Files, conflictMarkerFiles(test.Lines))
+		})
+	}
+}
+
+func TestAddedDependencies(t *testing.T) {
+	lines := []diffLine{
+		{File: "go.mod", Added: false, Content: "\tgithub.com/pkg/errors v0.8.1"},
+		{File: "go.mod", Added: true, Content: "\tgithub.com/pkg/errors v0.9.1"},
+		{File: "go.mod", Added: true, Content: "\tgithub.com/rs/zerolog v1.18.0 // indirect"},
+		{File: "go.mod", Added: true, Content: "require gopkg.in/yaml.v2 v2.3.0"},
+		{File: "go.mod", Added: true, Content: "go 1.13"},
+		{File: "go.mod", Added: true, Content: "replace github.com/a/b => github.com/c/b v1.0.0"},
+		{File: "go.mod", Added: true, Content: "retract v1.0.1"},
+		{File: "go.mod", Added: false, Content: "\tgithub.com/google/uuid v1.1.1"},
+		{File: "web/package.json", Added: true, Content: `    "version": "1.2.0",`},
+		{File: "web/package.json", Added: true, Content: `    "react": "^16.13.1",`},
+		{File: "web/package.json", Added: true, Content: `    "build": "webpack --mode production",`},
+		{File: "requirements-dev.txt", Added: true, Content: "# test dependencies"},
+		{File: "requirements-dev.txt", Added: true, Content: "pytest>=6.0"},
+		{File: "requirements-dev.txt", Added: true, Content: "-r requirements.txt"},
+		{File: "docs/deps.md", Added: true, Content: "\tgithub.com/pkg/errors v0.9.1"},
+	}
+
+	isManifest := func(file string) bool {
+		return file != "docs/deps.md"
+	}
+
+	assert.Equal(t, []dependency{
+		{Manifest: "go.mod", Name: "github.com/rs/zerolog"},
+		{Manifest: "go.mod", Name: "gopkg.in/yaml.v2"},
+		{Manifest: "web/package.json", Name: "react"},
+		{Manifest: "requirements-dev.txt", Name: "pytest"},
+	}, addedDependencies(lines, isManifest))
+}
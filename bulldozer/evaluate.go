@@ -16,6 +16,7 @@ package bulldozer
 
 import (
 	"context"
+	"fmt"
 	"strings"
 
 	"github.com/pkg/errors"
@@ -27,7 +28,7 @@ import (
 // IsPRIgnored returns true if the PR is identified as ignored,
 // false otherwise. Additionally, a description of the reason will be returned.
 func IsPRIgnored(ctx context.Context, pullCtx pull.Context, config Signals) (bool, string, error) {
-	matches, reason, err := config.Matches(ctx, pullCtx, "ignored")
+	matches, reason, err := matchSignals(ctx, pullCtx, config, "ignored")
 	if err != nil {
 		// ignore must always fail closed (matches on error)
 		matches = true
@@ -38,7 +39,7 @@ func IsPRIgnored(ctx context.Context, pullCtx pull.Context, config Signals) (boo
 // IsPRTriggered returns true if the PR is identified as triggered,
 // false otherwise. Additionally, a description of the reason will be returned.
 func IsPRTriggered(ctx context.Context, pullCtx pull.Context, config Signals) (bool, string, error) {
-	matches, reason, err := config.Matches(ctx, pullCtx, "triggered")
+	matches, reason, err := matchSignals(ctx, pullCtx, config, "triggered")
 	if err != nil {
 		// trigger must always fail closed (no match on error)
 		return false, reason, err
@@ -46,6 +47,49 @@ func IsPRTriggered(ctx context.Context, pullCtx pull.Context, config Signals) (b
 	return matches, reason, err
 }
 
+// matchSignals is like Signals.Matches, but logs the outcome of each signal
+// if the signals are exhaustive.
+func matchSignals(ctx context.Context, pullCtx pull.Context, config Signals, tag string) (bool, string, error) {
+	result, err := config.evaluate(ctx, pullCtx, tag)
+
+	logger := zerolog.Ctx(ctx)
+	for _, detail := range result.Details {
+		logger.Debug().Msgf("%s signal %s for %s: matched=%t: %s", tag, detail.Signal, pullCtx.Locator(), detail.Matches, detail.Reason)
+	}
+	return result.Matches, result.Reason, err
+}
+
+// NamedSignals is a set of signals identified by name.
+type NamedSignals struct {
+	Name    string
+	Signals *Signals
+}
+
+// FirstMatch evaluates the signal sets in order and returns the name of the
+// first set that matches the pull request, along with a description of why
+// it matches. The name of each set is used as the tag in descriptions. If no
+// set matches, the returned name is empty. Sets that are nil are skipped.
+//
+// All sets are evaluated against the same pull request context, so data
+// loaded for one set, like comments or reviews, is reused by later sets when
+// the context caches it, as the GitHub context does.
+func FirstMatch(ctx context.Context, pullCtx pull.Context, sets []NamedSignals) (string, string, error) {
+	for _, set := range sets {
+		if set.Signals == nil {
+			continue
+		}
+
+		matches, reason, err := set.Signals.Matches(ctx, pullCtx, set.Name)
+		if err != nil {
+			return "", reason, errors.Wrapf(err, "failed to evaluate signal set %q", set.Name)
+		}
+		if matches {
+			return set.Name, reason, nil
+		}
+	}
+	return "", fmt.Sprintf("pull request does not match any of %d signal sets", len(sets)), nil
+}
+
 // statusSetDifference returns all statuses in required that are not in actual,
 // accouting for special behavior in GitHub.
 func statusSetDifference(required, actual []string) []string {
@@ -85,10 +129,48 @@ func statusSetDifference(required, actual []string) []string {
 	return result
 }
 
+// unsatisfiedAppStatuses returns the required statuses that do not have a
+// successful check run created by the required app, described with the slug
+// of the app.
+func unsatisfiedAppStatuses(required []RequiredStatus, statuses []*pull.Status) []string {
+	var result []string
+	for _, r := range required {
+		satisfied := false
+		for _, s := range statuses {
+			if s.Context == r.Context && s.App == r.App && s.State == "success" {
+				satisfied = true
+				break
+			}
+		}
+		if !satisfied {
+			result = append(result, r.String())
+		}
+	}
+	return result
+}
+
+type mergeMethodKey struct{}
+
+// withMergeMethod returns a context recording the method that will be used to
+// merge the pull request being evaluated, for signals that depend on it.
+func withMergeMethod(ctx context.Context, method MergeMethod) context.Context {
+	return context.WithValue(ctx, mergeMethodKey{}, method)
+}
+
+// mergeMethodFrom returns the merge method recorded by withMergeMethod, or an
+// empty string if signals are evaluated without a merge configuration.
+func mergeMethodFrom(ctx context.Context) MergeMethod {
+	method, _ := ctx.Value(mergeMethodKey{}).(MergeMethod)
+	return method
+}
+
 // ShouldMergePR TODO: may want to return a richer type than bool
 func ShouldMergePR(ctx context.Context, pullCtx pull.Context, mergeConfig MergeConfig) (bool, error) {
 	logger := zerolog.Ctx(ctx)
 
+	base, _ := pullCtx.Branches()
+	ctx = withMergeMethod(ctx, mergeConfig.MethodFor(base))
+
 	if mergeConfig.Ignore.Enabled() {
 		ignored, reason, err := IsPRIgnored(ctx, pullCtx, mergeConfig.Ignore)
 		if err != nil {
@@ -123,12 +205,28 @@ func ShouldMergePR(ctx context.Context, pullCtx pull.Context, mergeConfig MergeC
 	}
 	requiredStatuses = append(requiredStatuses, mergeConfig.RequiredStatuses...)
 
+	var appStatuses []RequiredStatus
+	for _, s := range mergeConfig.RequiredAppStatuses {
+		if s.App == "" {
+			requiredStatuses = append(requiredStatuses, s.Context)
+		} else {
+			appStatuses = append(appStatuses, s)
+		}
+	}
+
 	successStatuses, err := pullCtx.CurrentSuccessStatuses(ctx)
 	if err != nil {
 		return false, errors.Wrap(err, "failed to determine currently successful status checks")
 	}
 
 	unsatisfiedStatuses := statusSetDifference(requiredStatuses, successStatuses)
+	if len(appStatuses) > 0 {
+		statuses, err := pullCtx.Statuses(ctx)
+		if err != nil {
+			return false, errors.Wrap(err, "failed to determine status checks")
+		}
+		unsatisfiedStatuses = append(unsatisfiedStatuses, unsatisfiedAppStatuses(appStatuses, statuses)...)
+	}
 	if len(unsatisfiedStatuses) > 0 {
 		logger.Debug().Msgf("%s is deemed not mergeable because of unfulfilled status checks: [%s]", pullCtx.Locator(), strings.Join(unsatisfiedStatuses, ","))
 		return false, nil
@@ -22,24 +22,25 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/palantir/bulldozer/pull"
 	"github.com/palantir/bulldozer/pull/pulltest"
 )
 
 func TestSimpleXListed(t *testing.T) {
 	mergeConfig := MergeConfig{
 		Trigger: Signals{
-			Labels:            []string{"LABEL_MERGE"},
-			Comments:          []string{"FULL_COMMENT_PLZ_MERGE"},
-			CommentSubstrings: []string{":+1:"},
-			PRBodySubstrings:  []string{"BODY_MERGE_PLZ"},
-			Branches:          []string{"develop"},
+			Labels:            SubSignal{Values: []string{"LABEL_MERGE"}},
+			Comments:          SubSignal{Values: []string{"FULL_COMMENT_PLZ_MERGE"}},
+			CommentSubstrings: SubSignal{Values: []string{":+1:"}},
+			PRBodySubstrings:  SubSignal{Values: []string{"BODY_MERGE_PLZ"}},
+			Branches:          SubSignal{Values: []string{"develop"}},
 		},
 		Ignore: Signals{
-			Labels:            []string{"LABEL_NOMERGE"},
-			Comments:          []string{"NO_WAY"},
-			CommentSubstrings: []string{":-1:"},
-			PRBodySubstrings:  []string{"BODY_NOMERGE"},
-			Branches:          []string{"master"},
+			Labels:            SubSignal{Values: []string{"LABEL_NOMERGE"}},
+			Comments:          SubSignal{Values: []string{"NO_WAY"}},
+			CommentSubstrings: SubSignal{Values: []string{":-1:"}},
+			PRBodySubstrings:  SubSignal{Values: []string{"BODY_NOMERGE"}},
+			Branches:          SubSignal{Values: []string{"master"}},
 		},
 	}
 
@@ -89,14 +90,14 @@ func TestSimpleXListed(t *testing.T) {
 func TestShouldMerge(t *testing.T) {
 	mergeConfig := MergeConfig{
 		Trigger: Signals{
-			Labels:            []string{"LABEL_MERGE", "LABEL2_MERGE"},
-			Comments:          []string{"FULL_COMMENT_PLZ_MERGE"},
-			CommentSubstrings: []string{":+1:", ":y:"},
+			Labels:            SubSignal{Values: []string{"LABEL_MERGE", "LABEL2_MERGE"}},
+			Comments:          SubSignal{Values: []string{"FULL_COMMENT_PLZ_MERGE"}},
+			CommentSubstrings: SubSignal{Values: []string{":+1:", ":y:"}},
 		},
 		Ignore: Signals{
-			Labels:            []string{"LABEL_NOMERGE"},
-			Comments:          []string{"NO_WAY"},
-			CommentSubstrings: []string{":-1:"},
+			Labels:            SubSignal{Values: []string{"LABEL_NOMERGE"}},
+			Comments:          SubSignal{Values: []string{"NO_WAY"}},
+			CommentSubstrings: SubSignal{Values: []string{":-1:"}},
 		},
 	}
 
@@ -325,4 +326,170 @@ func TestShouldMerge(t *testing.T) {
 		require.Nil(t, err)
 		assert.True(t, actualShouldMerge)
 	})
+
+	t.Run("appStatusChecks", func(t *testing.T) {
+		appConfig := mergeConfig
+		appConfig.RequiredAppStatuses = []RequiredStatus{{Context: "build", App: "ci-bot"}}
+
+		tests := map[string]struct {
+			Statuses    []*pull.Status
+			ShouldMerge bool
+		}{
+			"requiredAppSucceeded": {
+				Statuses: []*pull.Status{
+					{Context: "build", State: "failure", App: "other-bot"},
+					{Context: "build", State: "success", App: "ci-bot"},
+				},
+				ShouldMerge: true,
+			},
+			"otherAppSucceeded": {
+				Statuses: []*pull.Status{
+					{Context: "build", State: "success", App: "other-bot"},
+					{Context: "build", State: "failure", App: "ci-bot"},
+				},
+				ShouldMerge: false,
+			},
+			"commitStatusSucceeded": {
+				Statuses: []*pull.Status{
+					{Context: "build", State: "success"},
+				},
+				ShouldMerge: false,
+			},
+		}
+
+		for name, test := range tests {
+			t.Run(name, func(t *testing.T) {
+				pc := &pulltest.MockPullContext{
+					LabelValue:    []string{"LABEL_MERGE"},
+					StatusesValue: test.Statuses,
+				}
+
+				actualShouldMerge, err := ShouldMergePR(ctx, pc, appConfig)
+
+				require.Nil(t, err)
+				assert.Equal(t, test.ShouldMerge, actualShouldMerge)
+			})
+		}
+	})
+}
+
+func TestUnsatisfiedAppStatuses(t *testing.T) {
+	required := []RequiredStatus{
+		{Context: "build", App: "ci-bot"},
+		{Context: "lint", App: "ci-bot"},
+	}
+	statuses := []*pull.Status{
+		{Context: "build", State: "success", App: "ci-bot"},
+		{Context: "lint", State: "success", App: "other-bot"},
+		{Context: "lint", State: "failure", App: "ci-bot"},
+	}
+
+	assert.Equal(t, []string{"lint (app:ci-bot)"}, unsatisfiedAppStatuses(required, statuses))
+}
+
+// cachingContext caches the comments of the embedded context, like
+// GithubContext, and counts how many times they are loaded.
+type cachingContext struct {
+	*pulltest.MockPullContext
+
+	comments []*pull.Comment
+	loads    int
+}
+
+func (c *cachingContext) AuthoredComments(ctx context.Context) ([]*pull.Comment, error) {
+	if c.comments == nil {
+		comments, err := c.MockPullContext.AuthoredComments(ctx)
+		if err != nil {
+			return nil, err
+		}
+		c.comments = comments
+		c.loads++
+	}
+	return c.comments, nil
+}
+
+func TestFirstMatch(t *testing.T) {
+	ctx := context.Background()
+
+	release := &Signals{
+		BranchPrefixes: SubSignal{Values: []string{"release/"}},
+	}
+	develop := &Signals{
+		Branches: SubSignal{Values: []string{"develop"}},
+	}
+	comment := &Signals{
+		Comments: SubSignal{Values: []string{"merge please"}},
+	}
+	sets := []NamedSignals{
+		{Name: "release", Signals: release},
+		{Name: "empty"},
+		{Name: "develop", Signals: develop},
+		{Name: "comment", Signals: comment},
+	}
+
+	t.Run("firstSetWins", func(t *testing.T) {
+		pc := &pulltest.MockPullContext{
+			BranchBase:   "develop",
+			CommentValue: []string{"merge please"},
+		}
+
+		name, reason, err := FirstMatch(ctx, pc, sets)
+		require.NoError(t, err)
+		assert.Equal(t, "develop", name)
+		assert.Equal(t, `pull request target is a develop branch: "develop"`, reason)
+	})
+
+	t.Run("declaredOrder", func(t *testing.T) {
+		pc := &pulltest.MockPullContext{
+			BranchBase:   "develop",
+			CommentValue: []string{"merge please"},
+		}
+
+		reordered := []NamedSignals{sets[3], sets[2]}
+		name, _, err := FirstMatch(ctx, pc, reordered)
+		require.NoError(t, err)
+		assert.Equal(t, "comment", name)
+	})
+
+	t.Run("noMatch", func(t *testing.T) {
+		pc := &pulltest.MockPullContext{
+			BranchBase: "master",
+		}
+
+		name, reason, err := FirstMatch(ctx, pc, sets)
+		require.NoError(t, err)
+		assert.Equal(t, "", name)
+		assert.Equal(t, "pull request does not match any of 4 signal sets", reason)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		pc := &pulltest.MockPullContext{
+			BranchBase:      "master",
+			CommentErrValue: errors.New("failure"),
+		}
+
+		name, _, err := FirstMatch(ctx, pc, sets)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `failed to evaluate signal set "comment"`)
+		assert.Equal(t, "", name)
+	})
+
+	t.Run("sharedContext", func(t *testing.T) {
+		pc := &cachingContext{
+			MockPullContext: &pulltest.MockPullContext{
+				BranchBase:   "master",
+				CommentValue: []string{"ship it"},
+			},
+		}
+
+		commentSets := []NamedSignals{
+			{Name: "first", Signals: &Signals{Comments: SubSignal{Values: []string{"merge please"}}}},
+			{Name: "second", Signals: &Signals{CommentSubstrings: SubSignal{Values: []string{"ship"}}}},
+		}
+
+		name, _, err := FirstMatch(ctx, pc, commentSets)
+		require.NoError(t, err)
+		assert.Equal(t, "second", name)
+		assert.Equal(t, 1, pc.loads, "comments were loaded more than once")
+	})
 }
@@ -0,0 +1,105 @@
+// Copyright 2019 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bulldozer
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// ResolveExtends returns a copy of the signals with every fragment named in
+// Extends merged in. Fragments are looked up in the provided map and may
+// themselves extend other fragments.
+//
+// Fragments are merged in the order they are listed, followed by the signals
+// themselves. Lists of values are concatenated in that order. Other values,
+// like "match", are overridden by each later source that sets a non-zero
+// value, so a value set directly on the signals always takes precedence over
+// the same value in a fragment. The Extends field of the result is empty.
+// Groups are resolved the same way, using the same fragments.
+func (s Signals) ResolveExtends(fragments map[string]Signals) (Signals, error) {
+	return s.resolveExtends(fragments, nil)
+}
+
+func (s Signals) resolveExtends(fragments map[string]Signals, path []string) (Signals, error) {
+	var resolved Signals
+	for _, name := range s.Extends {
+		for _, seen := range path {
+			if seen == name {
+				return Signals{}, errors.Errorf("signal fragment %q extends itself: %s", name, strings.Join(append(path, name), " -> "))
+			}
+		}
+
+		fragment, ok := fragments[name]
+		if !ok {
+			return Signals{}, errors.Errorf("unknown signal fragment %q", name)
+		}
+
+		fragment, err := fragment.resolveExtends(fragments, append(path, name))
+		if err != nil {
+			return Signals{}, err
+		}
+		mergeValues(reflect.ValueOf(&resolved).Elem(), reflect.ValueOf(fragment))
+	}
+
+	mergeValues(reflect.ValueOf(&resolved).Elem(), reflect.ValueOf(s))
+	resolved.Extends = nil
+
+	// groups may extend fragments too; mergeValues copied the map, so the
+	// resolved groups can replace the entries in place
+	for name, group := range resolved.Groups {
+		group, err := group.resolveExtends(fragments, path)
+		if err != nil {
+			return Signals{}, errors.Wrapf(err, "failed to resolve group %q", name)
+		}
+		resolved.Groups[name] = group
+	}
+	return resolved, nil
+}
+
+// mergeValues merges src into dst, which must be settable values of the same
+// type. Slices are concatenated, maps are merged key by key, structs are
+// merged field by field, and other non-zero values in src replace the value
+// in dst.
+func mergeValues(dst, src reflect.Value) {
+	switch src.Kind() {
+	case reflect.Struct:
+		for i := 0; i < src.NumField(); i++ {
+			if dst.Field(i).CanSet() {
+				mergeValues(dst.Field(i), src.Field(i))
+			}
+		}
+	case reflect.Slice:
+		if src.Len() > 0 {
+			merged := reflect.MakeSlice(src.Type(), 0, dst.Len()+src.Len())
+			dst.Set(reflect.AppendSlice(reflect.AppendSlice(merged, dst), src))
+		}
+	case reflect.Map:
+		if src.Len() > 0 {
+			if dst.IsNil() {
+				dst.Set(reflect.MakeMapWithSize(src.Type(), src.Len()))
+			}
+			for _, key := range src.MapKeys() {
+				dst.SetMapIndex(key, src.MapIndex(key))
+			}
+		}
+	default:
+		if !src.IsZero() {
+			dst.Set(src)
+		}
+	}
+}
@@ -0,0 +1,150 @@
+// Copyright 2019 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bulldozer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveExtends(t *testing.T) {
+	fragments := map[string]Signals{
+		"release": {
+			Match:          MatchAll,
+			BranchPrefixes: SubSignal{Values: []string{"release/"}},
+			Labels:         SubSignal{Values: []string{"release"}},
+		},
+		"reviewed": {
+			Match:  MatchOne,
+			Labels: SubSignal{Values: []string{"reviewed"}, Match: MatchAll},
+		},
+		"nested": {
+			Extends:  []string{"release"},
+			Branches: SubSignal{Values: []string{"develop"}},
+		},
+		"cycleA": {
+			Extends: []string{"cycleB"},
+		},
+		"cycleB": {
+			Extends: []string{"cycleA"},
+		},
+	}
+
+	t.Run("listsConcatenate", func(t *testing.T) {
+		signals := Signals{
+			Extends: []string{"release"},
+			Labels:  SubSignal{Values: []string{"merge when ready"}},
+		}
+
+		resolved, err := signals.ResolveExtends(fragments)
+		require.NoError(t, err)
+		assert.Equal(t, Signals{
+			Match:          MatchAll,
+			BranchPrefixes: SubSignal{Values: []string{"release/"}},
+			Labels:         SubSignal{Values: []string{"release", "merge when ready"}},
+		}, resolved)
+	})
+
+	t.Run("laterFragmentOverridesScalars", func(t *testing.T) {
+		signals := Signals{
+			Extends: []string{"release", "reviewed"},
+		}
+
+		resolved, err := signals.ResolveExtends(fragments)
+		require.NoError(t, err)
+		assert.Equal(t, MatchOne, resolved.Match)
+		assert.Equal(t, SubSignal{Values: []string{"release", "reviewed"}, Match: MatchAll}, resolved.Labels)
+	})
+
+	t.Run("signalsOverrideFragmentScalars", func(t *testing.T) {
+		signals := Signals{
+			Match:   MatchOne,
+			Extends: []string{"release"},
+		}
+
+		resolved, err := signals.ResolveExtends(fragments)
+		require.NoError(t, err)
+		assert.Equal(t, MatchOne, resolved.Match)
+	})
+
+	t.Run("nestedFragments", func(t *testing.T) {
+		signals := Signals{
+			Extends: []string{"nested"},
+		}
+
+		resolved, err := signals.ResolveExtends(fragments)
+		require.NoError(t, err)
+		assert.Equal(t, Signals{
+			Match:          MatchAll,
+			BranchPrefixes: SubSignal{Values: []string{"release/"}},
+			Labels:         SubSignal{Values: []string{"release"}},
+			Branches:       SubSignal{Values: []string{"develop"}},
+		}, resolved)
+	})
+
+	t.Run("groups", func(t *testing.T) {
+		signals := Signals{
+			Groups: map[string]Signals{
+				"release_develop": {
+					Extends:  []string{"release"},
+					Branches: SubSignal{Values: []string{"develop"}},
+				},
+			},
+		}
+
+		resolved, err := signals.ResolveExtends(fragments)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]Signals{
+			"release_develop": {
+				Match:          MatchAll,
+				BranchPrefixes: SubSignal{Values: []string{"release/"}},
+				Labels:         SubSignal{Values: []string{"release"}},
+				Branches:       SubSignal{Values: []string{"develop"}},
+			},
+		}, resolved.Groups)
+		assert.Equal(t, []string{"release"}, signals.Groups["release_develop"].Extends)
+	})
+
+	t.Run("doesNotModifyFragments", func(t *testing.T) {
+		signals := Signals{
+			Extends: []string{"release"},
+			Labels:  SubSignal{Values: []string{"merge when ready"}},
+		}
+
+		_, err := signals.ResolveExtends(fragments)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"release"}, fragments["release"].Labels.Values)
+	})
+
+	t.Run("unknownFragment", func(t *testing.T) {
+		signals := Signals{
+			Extends: []string{"missing"},
+		}
+
+		_, err := signals.ResolveExtends(fragments)
+		assert.EqualError(t, err, `unknown signal fragment "missing"`)
+	})
+
+	t.Run("cycle", func(t *testing.T) {
+		signals := Signals{
+			Extends: []string{"cycleA"},
+		}
+
+		_, err := signals.ResolveExtends(fragments)
+		assert.EqualError(t, err, `signal fragment "cycleA" extends itself: cycleA -> cycleB -> cycleA`)
+	})
+}
@@ -0,0 +1,160 @@
+// Copyright 2019 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bulldozer
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// LookupFunc returns the value of a variable and true, or false if the
+// variable is not defined. os.LookupEnv is a LookupFunc.
+type LookupFunc func(name string) (string, bool)
+
+// Interpolate returns a copy of the signals with variables in every list of
+// string values, including those of sub-configurations and groups, replaced
+// using lookup. Variables are written as $NAME or
+// ${NAME}, where NAME contains only letters, digits, and underscores, and
+// "$$" is replaced by a literal "$". A "$" that does not start a variable is
+// left unchanged, so patterns ending in "$" keep their meaning. It is an
+// error to reference a variable that is not defined.
+func (s Signals) Interpolate(lookup LookupFunc) (Signals, error) {
+	v := reflect.New(reflect.TypeOf(s)).Elem()
+	v.Set(reflect.ValueOf(s))
+	if err := interpolateValues(v, lookup); err != nil {
+		return Signals{}, err
+	}
+	return v.Interface().(Signals), nil
+}
+
+// interpolateValues interpolates every string in the string slices contained
+// in v, which must be settable, following pointers to structs and the values
+// of maps of structs, like Groups. Slices, structs behind pointers, and maps
+// are copied before they are modified.
+func interpolateValues(v reflect.Value, lookup LookupFunc) error {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() || v.Type().Elem().Kind() != reflect.Struct {
+			return nil
+		}
+		elem := reflect.New(v.Type().Elem())
+		elem.Elem().Set(v.Elem())
+		if err := interpolateValues(elem.Elem(), lookup); err != nil {
+			return err
+		}
+		v.Set(elem)
+	case reflect.Map:
+		if v.Type().Elem().Kind() != reflect.Struct || v.Len() == 0 {
+			return nil
+		}
+		values := reflect.MakeMapWithSize(v.Type(), v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			elem := reflect.New(v.Type().Elem()).Elem()
+			elem.Set(iter.Value())
+			if err := interpolateValues(elem, lookup); err != nil {
+				return errors.Wrapf(err, "failed to interpolate %v", iter.Key())
+			}
+			values.SetMapIndex(iter.Key(), elem)
+		}
+		v.Set(values)
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if v.Field(i).CanSet() {
+				if err := interpolateValues(v.Field(i), lookup); err != nil {
+					return err
+				}
+			}
+		}
+	case reflect.Slice:
+		if v.Type().Elem().Kind() != reflect.String || v.Len() == 0 {
+			return nil
+		}
+		values := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			value, err := interpolate(v.Index(i).String(), lookup)
+			if err != nil {
+				return err
+			}
+			values.Index(i).SetString(value)
+		}
+		v.Set(values)
+	}
+	return nil
+}
+
+func interpolate(s string, lookup LookupFunc) (string, error) {
+	if !strings.Contains(s, "$") {
+		return s, nil
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '$' || i+1 == len(s) {
+			b.WriteByte(s[i])
+			continue
+		}
+
+		var name string
+		switch next := s[i+1]; {
+		case next == '$':
+			b.WriteByte('$')
+			i++
+			continue
+		case next == '{':
+			end := strings.IndexByte(s[i+2:], '}')
+			if end < 0 {
+				return "", errors.Errorf("unterminated variable reference in %q", s)
+			}
+			name = s[i+2 : i+2+end]
+			if name == "" || variableNameLength(name) != len(name) {
+				return "", errors.Errorf("invalid variable name %q in %q", name, s)
+			}
+			i += end + 2
+		default:
+			n := variableNameLength(s[i+1:])
+			if n == 0 {
+				b.WriteByte('$')
+				continue
+			}
+			name = s[i+1 : i+1+n]
+			i += n
+		}
+
+		value, ok := lookup(name)
+		if !ok {
+			return "", errors.Errorf("undefined variable %q in %q", name, s)
+		}
+		b.WriteString(value)
+	}
+	return b.String(), nil
+}
+
+// variableNameLength returns the length of the variable name at the start of
+// s, or 0 if s does not start with a variable name.
+func variableNameLength(s string) int {
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == '_', 'a' <= c && c <= 'z', 'A' <= c && c <= 'Z':
+		case '0' <= c && c <= '9' && i > 0:
+		default:
+			return i
+		}
+	}
+	return len(s)
+}
@@ -0,0 +1,132 @@
+// Copyright 2019 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bulldozer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInterpolate(t *testing.T) {
+	lookup := func(name string) (string, bool) {
+		v, ok := map[string]string{
+			"DEFAULT_BRANCH": "develop",
+			"LABEL":          "merge when ready",
+			"BOT":            "bot",
+			"EMPTY":          "",
+		}[name]
+		return v, ok
+	}
+
+	t.Run("definedVariables", func(t *testing.T) {
+		signals := Signals{
+			Match:          MatchAll,
+			Branches:       SubSignal{Values: []string{"$DEFAULT_BRANCH", "main"}},
+			Labels:         SubSignal{Values: []string{"${LABEL}"}, Match: MatchAll},
+			Comments:       SubSignal{Values: []string{"${BOT}: merge", "$BOT-merge", "x${EMPTY}y"}},
+			BranchPatterns: SubSignal{Values: []string{"^release/.*$", "a$|b"}},
+			CommitAuthors:  []string{"$BOT"},
+		}
+
+		interpolated, err := signals.Interpolate(lookup)
+		require.NoError(t, err)
+		assert.Equal(t, Signals{
+			Match:          MatchAll,
+			Branches:       SubSignal{Values: []string{"develop", "main"}},
+			Labels:         SubSignal{Values: []string{"merge when ready"}, Match: MatchAll},
+			Comments:       SubSignal{Values: []string{"bot: merge", "bot-merge", "xy"}},
+			BranchPatterns: SubSignal{Values: []string{"^release/.*$", "a$|b"}},
+			CommitAuthors:  []string{"bot"},
+		}, interpolated)
+
+		assert.Equal(t, []string{"$DEFAULT_BRANCH", "main"}, signals.Branches.Values, "original signals were modified")
+	})
+
+	t.Run("subConfigurations", func(t *testing.T) {
+		signals := Signals{
+			RequireTestChanges:  &TestChanges{TestPaths: []string{"$BOT/"}},
+			RequireRFCReference: &RFCReference{Pattern: "RFC-\\d+"},
+		}
+
+		interpolated, err := signals.Interpolate(lookup)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"bot/"}, interpolated.RequireTestChanges.TestPaths)
+		assert.Equal(t, []string{"$BOT/"}, signals.RequireTestChanges.TestPaths, "original signals were modified")
+	})
+
+	t.Run("groups", func(t *testing.T) {
+		signals := Signals{
+			Groups: map[string]Signals{
+				"bots": {
+					Creators: SubSignal{Values: []string{"$BOT"}},
+					Branches: SubSignal{Values: []string{"${DEFAULT_BRANCH}"}},
+				},
+			},
+		}
+
+		interpolated, err := signals.Interpolate(lookup)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"bot"}, interpolated.Groups["bots"].Creators.Values)
+		assert.Equal(t, []string{"develop"}, interpolated.Groups["bots"].Branches.Values)
+		assert.Equal(t, []string{"$BOT"}, signals.Groups["bots"].Creators.Values, "original signals were modified")
+
+		signals.Groups["bots"] = Signals{Labels: SubSignal{Values: []string{"$MISSING"}}}
+		_, err = signals.Interpolate(lookup)
+		assert.Error(t, err)
+	})
+
+	t.Run("escapedDollar", func(t *testing.T) {
+		signals := Signals{
+			Comments: SubSignal{Values: []string{"costs $$5", "$${LABEL}", "$$$BOT"}},
+		}
+
+		interpolated, err := signals.Interpolate(lookup)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"costs $5", "${LABEL}", "$bot"}, interpolated.Comments.Values)
+	})
+
+	t.Run("undefinedVariable", func(t *testing.T) {
+		signals := Signals{
+			Branches: SubSignal{Values: []string{"$MISSING"}},
+		}
+
+		_, err := signals.Interpolate(lookup)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `undefined variable "MISSING"`)
+	})
+
+	t.Run("undefinedBracedVariable", func(t *testing.T) {
+		signals := Signals{
+			Labels: SubSignal{Values: []string{"prefix-${MISSING}"}},
+		}
+
+		_, err := signals.Interpolate(lookup)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `undefined variable "MISSING"`)
+	})
+
+	t.Run("invalidReference", func(t *testing.T) {
+		for _, value := range []string{"${LABEL", "${}", "${NOT-A-NAME}"} {
+			signals := Signals{
+				Labels: SubSignal{Values: []string{value}},
+			}
+
+			_, err := signals.Interpolate(lookup)
+			assert.Error(t, err, "expected an error for %q", value)
+		}
+	})
+}
@@ -0,0 +1,118 @@
+// Copyright 2019 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bulldozer
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// DefaultMaxEvaluatedValues is the largest number of values signals may
+// configure if SetMaxEvaluatedValues is not called.
+const DefaultMaxEvaluatedValues = 1000
+
+var maxEvaluatedValues = DefaultMaxEvaluatedValues
+
+// SetMaxEvaluatedValues limits the total number of values, like labels,
+// patterns, and paths, that a set of signals may configure, including the
+// values of groups. Matching signals that exceed the limit fails with an
+// error instead of evaluating them, which keeps a single oversized
+// configuration from slowing down every pull request. A limit less than or
+// equal to zero disables the check. Like Register, SetMaxEvaluatedValues
+// should be called during program initialization.
+func SetMaxEvaluatedValues(n int) {
+	maxEvaluatedValues = n
+}
+
+// checkEvaluatedValues returns an error naming the signal with the most
+// values if the signals configure more values than the limit.
+func (s *Signals) checkEvaluatedValues(tag string) (string, error) {
+	if maxEvaluatedValues <= 0 {
+		return "", nil
+	}
+
+	counts := make(map[string]int)
+	s.countValues("", counts)
+
+	total, largest := 0, ""
+	for name, n := range counts {
+		total += n
+		if largest == "" || n > counts[largest] || (n == counts[largest] && name < largest) {
+			largest = name
+		}
+	}
+	if total <= maxEvaluatedValues {
+		return "", nil
+	}
+	return fmt.Sprintf("%s signals configure %d values, exceeding the limit of %d", tag, total, maxEvaluatedValues),
+		errors.Errorf("signals configure %d values, exceeding the limit of %d; the largest signal is %q with %d values", total, maxEvaluatedValues, largest, counts[largest])
+}
+
+// countValues adds the number of values of each configured signal to counts,
+// keyed by the name of the signal in the configuration. The signals of
+// groups are named by their path, like "groups.docs.labels".
+func (s *Signals) countValues(prefix string, counts map[string]int) {
+	v := reflect.ValueOf(*s)
+	for i := 0; i < v.NumField(); i++ {
+		name := strings.Split(v.Type().Field(i).Tag.Get("yaml"), ",")[0]
+		if name == "" || name == "-" {
+			continue
+		}
+
+		if groups, ok := v.Field(i).Interface().(map[string]Signals); ok {
+			names := make([]string, 0, len(groups))
+			for groupName := range groups {
+				names = append(names, groupName)
+			}
+			sort.Strings(names)
+			for _, groupName := range names {
+				group := groups[groupName]
+				group.countValues(prefix+name+"."+groupName+".", counts)
+			}
+			continue
+		}
+
+		if n := countValues(v.Field(i)); n > 0 {
+			counts[prefix+name] += n
+		}
+	}
+}
+
+// countValues returns the number of elements of the lists and maps in v,
+// including those of nested structures like sub-signals.
+func countValues(v reflect.Value) int {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			return 0
+		}
+		return countValues(v.Elem())
+	case reflect.Struct:
+		n := 0
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).PkgPath == "" {
+				n += countValues(v.Field(i))
+			}
+		}
+		return n
+	case reflect.Slice, reflect.Map:
+		return v.Len()
+	}
+	return 0
+}
@@ -0,0 +1,95 @@
+// Copyright 2019 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bulldozer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/palantir/bulldozer/pull/pulltest"
+)
+
+func TestMaxEvaluatedValues(t *testing.T) {
+	defer SetMaxEvaluatedValues(DefaultMaxEvaluatedValues)
+	SetMaxEvaluatedValues(4)
+
+	ctx := context.Background()
+	pc := &pulltest.MockPullContext{LabelValue: []string{"merge"}}
+
+	tests := map[string]struct {
+		Signals Signals
+		Error   string
+	}{
+		"atLimit": {
+			Signals: Signals{
+				Labels:   SubSignal{Values: []string{"merge", "ship", "go", "land"}},
+				Branches: SubSignal{Values: []string{}},
+			},
+		},
+		"overLimit": {
+			Signals: Signals{
+				Labels: SubSignal{Values: []string{"merge", "ship", "go", "land", "yolo"}},
+			},
+			Error: `signals configure 5 values, exceeding the limit of 4; the largest signal is "labels" with 5 values`,
+		},
+		"acrossSignals": {
+			Signals: Signals{
+				Labels:         SubSignal{Values: []string{"merge", "ship"}},
+				Comments:       SubSignal{Values: []string{"/merge", "/ship", "/go"}},
+				OverrideLabels: []string{"force"},
+			},
+			Error: `signals configure 6 values, exceeding the limit of 4; the largest signal is "comments" with 3 values`,
+		},
+		"inGroups": {
+			Signals: Signals{
+				Labels: SubSignal{Values: []string{"merge"}},
+				Groups: map[string]Signals{
+					"docs": {Labels: SubSignal{Values: []string{"docs", "readme"}}},
+					"deps": {Branches: SubSignal{Values: []string{"main", "develop", "release"}}},
+				},
+			},
+			Error: `signals configure 6 values, exceeding the limit of 4; the largest signal is "groups.deps.branches" with 3 values`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			matches, reason, err := test.Signals.Matches(ctx, pc, "testlist")
+			if test.Error == "" {
+				require.NoError(t, err)
+				assert.True(t, matches)
+				return
+			}
+
+			require.Error(t, err)
+			assert.EqualError(t, err, test.Error)
+			assert.False(t, matches)
+			assert.Contains(t, reason, "exceeding the limit of 4")
+		})
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		defer SetMaxEvaluatedValues(4)
+		SetMaxEvaluatedValues(0)
+
+		signals := Signals{Labels: SubSignal{Values: []string{"merge", "ship", "go", "land", "yolo"}}}
+		matches, _, err := signals.Matches(ctx, pc, "testlist")
+		require.NoError(t, err)
+		assert.True(t, matches)
+	})
+}
@@ -150,20 +150,27 @@ func (m *PushRestrictionMerger) DeleteHead(ctx context.Context, pullCtx pull.Con
 	return m.Normal.DeleteHead(ctx, pullCtx)
 }
 
+// MethodFor returns the method used to merge pull requests targeting the base
+// branch: the method for the branch in BranchMethod, if any, or Method. It
+// returns MergeCommit if the configured method is not valid.
+func (mc MergeConfig) MethodFor(base string) MergeMethod {
+	method := mc.Method
+	if branchMethod, ok := mc.BranchMethod[base]; ok {
+		method = branchMethod
+	}
+	if !isValidMergeMethod(method) {
+		return MergeCommit
+	}
+	return method
+}
+
 // MergePR merges a pull request if all conditions are met. It logs any errors
 // that it encounters.
 func MergePR(ctx context.Context, pullCtx pull.Context, merger Merger, mergeConfig MergeConfig) {
 	logger := zerolog.Ctx(ctx)
 
 	base, head := pullCtx.Branches()
-	mergeMethod := mergeConfig.Method
-
-	if branchMergeMethod, ok := mergeConfig.BranchMethod[base]; ok {
-		mergeMethod = branchMergeMethod
-	}
-	if !isValidMergeMethod(mergeMethod) {
-		mergeMethod = MergeCommit
-	}
+	mergeMethod := mergeConfig.MethodFor(base)
 
 	commitMsg := CommitMessage{}
 	if mergeMethod == SquashAndMerge {
@@ -196,9 +203,11 @@ func MergePR(ctx context.Context, pullCtx pull.Context, merger Merger, mergeConf
 	}
 
 	var attempts int
-	var merged, retry bool
+	var merged, failed bool
 	for {
-		merged, retry = attemptMerge(ctx, pullCtx, merger, mergeMethod, commitMsg)
+		var attemptFailed, retry bool
+		merged, attemptFailed, retry = attemptMerge(ctx, pullCtx, merger, mergeMethod, commitMsg)
+		failed = failed || attemptFailed
 		if merged || !retry {
 			break
 		}
@@ -206,9 +215,15 @@ func MergePR(ctx context.Context, pullCtx pull.Context, merger Merger, mergeConf
 		attempts++
 		if attempts >= MaxPullRequestPollCount {
 			logger.Error().Msgf("Failed to merge pull request after %d attempts", attempts)
-			return
+			break
+		}
+		if err := sleep(ctx, 4*time.Second); err != nil {
+			break
 		}
-		time.Sleep(4 * time.Second)
+	}
+
+	if !merged && failed && mergeConfig.tracksMergeAttempts() {
+		recordFailedMergeAttempt(ctx, pullCtx)
 	}
 
 	if merged {
@@ -221,29 +236,32 @@ func MergePR(ctx context.Context, pullCtx pull.Context, merger Merger, mergeConf
 }
 
 // attemptMerge attempts to merge a pull request, logging any errors and
-// returing flags to show if the merge suceeded and if a retry is needed.
-func attemptMerge(ctx context.Context, pullCtx pull.Context, merger Merger, method MergeMethod, msg CommitMessage) (merged, retry bool) {
+// returing flags to show if the merge suceeded, if a merge was requested and
+// failed unexpectedly, and if a retry is needed. Rejections because a
+// condition like a required review is not yet satisfied, or because the merge
+// is invalid, are expected and do not count as failures.
+func attemptMerge(ctx context.Context, pullCtx pull.Context, merger Merger, method MergeMethod, msg CommitMessage) (merged, failed, retry bool) {
 	logger := zerolog.Ctx(ctx)
 
 	mergeState, err := pullCtx.MergeState(ctx)
 	if err != nil {
 		logger.Error().Err(err).Msgf("Failed to get merge state for %q", pullCtx.Locator())
-		return false, false
+		return false, false, false
 	}
 
 	if mergeState.Closed {
 		logger.Debug().Msg("Pull request already closed")
-		return false, false
+		return false, false, false
 	}
 
 	if mergeState.Mergeable == nil {
 		logger.Debug().Msg("Pull request mergeability not yet known")
-		return false, true
+		return false, false, true
 	}
 
 	if !*mergeState.Mergeable {
 		logger.Debug().Msg("Pull request is not mergeable")
-		return false, false
+		return false, false, false
 	}
 
 	logger.Info().Msgf("Attempting to merge pull request with method %s", method)
@@ -252,24 +270,42 @@ func attemptMerge(ctx context.Context, pullCtx pull.Context, merger Merger, meth
 		gerr, ok := errors.Cause(err).(*github.ErrorResponse)
 		if !ok {
 			logger.Error().Err(err).Msg("Failed to merge pull request")
-			return false, true
+			return false, true, true
 		}
 
 		switch gerr.Response.StatusCode {
 		case http.StatusMethodNotAllowed:
 			logger.Info().Msgf("Merge rejected due to unsatisfied condition: %q", gerr.Message)
-			return false, false
+			return false, false, false
 		case http.StatusConflict:
 			logger.Info().Msgf("Merge rejected due to being invalid: %q", gerr.Message)
-			return false, false
+			return false, false, false
 		default:
 			logger.Error().Msgf("Merge failed with unexpected status: %d: %q", gerr.Response.StatusCode, gerr.Message)
-			return false, true
+			return false, true, true
 		}
 	}
 
 	logger.Info().Msgf("Successfully merged pull request as SHA %s", sha)
-	return true, false
+	return true, false, false
+}
+
+// recordFailedMergeAttempt increments the number of failed merge attempts
+// recorded for a pull request, logging any errors.
+func recordFailedMergeAttempt(ctx context.Context, pullCtx pull.Context) {
+	logger := zerolog.Ctx(ctx)
+
+	attempts, err := pullCtx.MergeAttempts(ctx)
+	if err != nil {
+		logger.Error().Err(err).Msg("Failed to read failed merge attempts")
+		return
+	}
+
+	if err := pullCtx.SetMergeAttempts(ctx, attempts+1); err != nil {
+		logger.Error().Err(err).Msg("Failed to record failed merge attempt")
+		return
+	}
+	logger.Info().Msgf("Recorded %d failed merge attempts for %q", attempts+1, pullCtx.Locator())
 }
 
 // attemptDelete attempts to delete a pull request branch, logging any errors
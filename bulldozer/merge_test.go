@@ -16,8 +16,12 @@ package bulldozer
 
 import (
 	"context"
+	"net/http"
 	"testing"
+	"time"
 
+	"github.com/google/go-github/v32/github"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -43,6 +47,21 @@ func (m *MockMerger) DeleteHead(ctx context.Context, pullCtx pull.Context) error
 	return m.DeleteError
 }
 
+func TestMergeConfigMethodFor(t *testing.T) {
+	config := MergeConfig{
+		Method: SquashAndMerge,
+		BranchMethod: map[string]MergeMethod{
+			"release": RebaseAndMerge,
+			"legacy":  "octopus",
+		},
+	}
+
+	assert.Equal(t, SquashAndMerge, config.MethodFor("develop"))
+	assert.Equal(t, RebaseAndMerge, config.MethodFor("release"))
+	assert.Equal(t, MergeCommit, config.MethodFor("legacy"))
+	assert.Equal(t, MergeCommit, MergeConfig{}.MethodFor("develop"))
+}
+
 func TestCalculateCommitTitle(t *testing.T) {
 	defaultPullContext := &pulltest.MockPullContext{
 		NumberValue: 12,
@@ -182,3 +201,99 @@ func TestPushRestrictionMerger(t *testing.T) {
 	assert.Equal(t, 1, normal.DeleteCount, "normal delete was incorrectly called")
 	assert.Equal(t, 1, restricted.DeleteCount, "restricted delete was not called")
 }
+
+func TestMergePRRecordsFailedAttempts(t *testing.T) {
+	defer func(s func(context.Context, time.Duration) error) { sleep = s }(sleep)
+	sleep = func(ctx context.Context, d time.Duration) error { return nil }
+
+	ctx := context.Background()
+	mergeable := true
+
+	mergeConfig := MergeConfig{
+		Trigger: Signals{
+			Match:            MatchAll,
+			Labels:           SubSignal{Values: []string{"merge when ready"}},
+			MaxMergeAttempts: 2,
+		},
+		Method: MergeCommit,
+	}
+
+	merger := &MockMerger{
+		MergeError: &github.ErrorResponse{
+			Response: &http.Response{StatusCode: http.StatusBadGateway},
+			Message:  "Server Error",
+		},
+	}
+	pullCtx := &pulltest.MockPullContext{
+		LabelValue:      []string{"merge when ready"},
+		MergeStateValue: &pull.MergeState{Mergeable: &mergeable},
+	}
+
+	for attempt := 1; attempt <= 2; attempt++ {
+		shouldMerge, err := ShouldMergePR(ctx, pullCtx, mergeConfig)
+		require.NoError(t, err)
+		require.True(t, shouldMerge, "pull request should be mergeable on attempt %d", attempt)
+
+		MergePR(ctx, pullCtx, merger, mergeConfig)
+		assert.Equal(t, attempt, pullCtx.MergeAttemptsValue, "failed attempt was not recorded")
+	}
+
+	shouldMerge, err := ShouldMergePR(ctx, pullCtx, mergeConfig)
+	require.NoError(t, err)
+	assert.False(t, shouldMerge, "pull request should not be mergeable after reaching the attempt limit")
+	assert.Equal(t, 2*MaxPullRequestPollCount, merger.MergeCount)
+}
+
+func TestMergePRDoesNotRecordRejectedAttempts(t *testing.T) {
+	ctx := context.Background()
+	mergeable := true
+
+	mergeConfig := MergeConfig{
+		Trigger: Signals{
+			Match:            MatchAll,
+			Labels:           SubSignal{Values: []string{"merge when ready"}},
+			MaxMergeAttempts: 2,
+		},
+		Method: MergeCommit,
+	}
+
+	pullCtx := &pulltest.MockPullContext{
+		LabelValue:      []string{"merge when ready"},
+		MergeStateValue: &pull.MergeState{Mergeable: &mergeable},
+	}
+
+	for _, status := range []int{http.StatusMethodNotAllowed, http.StatusMethodNotAllowed, http.StatusConflict} {
+		merger := &MockMerger{
+			MergeError: &github.ErrorResponse{
+				Response: &http.Response{StatusCode: status},
+				Message:  "At least 1 approving review is required by reviewers with write access.",
+			},
+		}
+
+		shouldMerge, err := ShouldMergePR(ctx, pullCtx, mergeConfig)
+		require.NoError(t, err)
+		require.True(t, shouldMerge, "pull request waiting for review should stay mergeable")
+
+		MergePR(ctx, pullCtx, merger, mergeConfig)
+		assert.Equal(t, 1, merger.MergeCount)
+		assert.Equal(t, 0, pullCtx.MergeAttemptsValue, "rejected attempt was recorded")
+	}
+}
+
+func TestMergePRDoesNotRecordAttemptsWithoutLimit(t *testing.T) {
+	ctx := context.Background()
+	mergeable := true
+
+	merger := &MockMerger{
+		MergeError: &github.ErrorResponse{
+			Response: &http.Response{StatusCode: http.StatusMethodNotAllowed},
+		},
+	}
+	pullCtx := &pulltest.MockPullContext{
+		MergeStateValue:          &pull.MergeState{Mergeable: &mergeable},
+		SetMergeAttemptsErrValue: errors.New("merge attempts should not be recorded"),
+	}
+
+	MergePR(ctx, pullCtx, merger, MergeConfig{Method: MergeCommit})
+	assert.Equal(t, 0, pullCtx.MergeAttemptsValue)
+}
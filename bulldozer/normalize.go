@@ -0,0 +1,156 @@
+// Copyright 2019 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bulldozer
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+	"golang.org/x/text/width"
+)
+
+// Comparator compares text in the body, comments, and labels of a pull
+// request with the values configured for a signal. Text is normalized, if
+// enabled, before it is passed to the comparator.
+type Comparator interface {
+	// Equal reports whether a and b are the same.
+	Equal(a, b string) bool
+
+	// Contains reports whether needle appears in haystack.
+	Contains(haystack, needle string) bool
+}
+
+type exactComparator struct{}
+
+func (exactComparator) Equal(a, b string) bool {
+	return a == b
+}
+
+func (exactComparator) Contains(haystack, needle string) bool {
+	return strings.Contains(haystack, needle)
+}
+
+var comparator Comparator = exactComparator{}
+
+// SetComparator sets the comparator used by the label, comment, and
+// substring signals. By default, text must match exactly, except for labels,
+// which ignore case. Labels are converted to lower case before they are
+// passed to a custom comparator. Substrings matched at word boundaries with
+// WordBoundary do not use the comparator. Passing nil restores the default.
+// Like Register, SetComparator should be called during program
+// initialization.
+func SetComparator(c Comparator) {
+	if c == nil {
+		c = exactComparator{}
+	}
+	comparator = c
+}
+
+// normalizeText returns text in a normalized form for comparison, according
+// to the normalization options of the signals. It applies normalizeUnicode
+// and then, if FoldCase is set, simple Unicode case folding, so that text
+// differing only in case compares equal.
+func (s *Signals) normalizeText(text string) string {
+	text = s.normalizeUnicode(text)
+	if s.FoldCase {
+		text = strings.Map(foldRune, text)
+	}
+	return text
+}
+
+// normalizeUnicode returns text in Unicode Normalization Form C, after
+// folding full-width and half-width characters to their canonical width, if
+// the signals enable normalization. Text composed of different but
+// canonically equivalent sequences of code points then compares equal.
+// Unlike normalizeText, it preserves case, so it also applies to text that is
+// matched against patterns or measured.
+func (s *Signals) normalizeUnicode(text string) string {
+	if !s.Normalize {
+		return text
+	}
+	return norm.NFC.String(width.Fold.String(text))
+}
+
+// foldRune maps r to a canonical case, so that runes in the same simple case
+// folding orbit, like "K", "k", and the Kelvin sign, map to the same rune.
+func foldRune(r rune) rune {
+	return unicode.ToLower(unicode.ToUpper(r))
+}
+
+// textEqual reports whether two strings are equal after normalization,
+// according to the comparator. If fold is true, the comparison ignores case.
+func (s *Signals) textEqual(a, b string, fold bool) bool {
+	a, b = s.normalizeText(a), s.normalizeText(b)
+	if fold {
+		if _, ok := comparator.(exactComparator); ok {
+			return strings.EqualFold(a, b)
+		}
+		a, b = strings.ToLower(a), strings.ToLower(b)
+	}
+	return comparator.Equal(a, b)
+}
+
+// textContains reports whether text contains substr after normalization,
+// according to the comparator.
+func (s *Signals) textContains(text, substr string) bool {
+	return comparator.Contains(s.normalizeText(text), s.normalizeText(substr))
+}
+
+// substringMatches reports whether text contains substr after normalization,
+// as used by the body and comment substring signals. If WordBoundary is set,
+// the substring must also start and end at word boundaries.
+func (s *Signals) substringMatches(text, substr string) bool {
+	if !s.WordBoundary {
+		return s.textContains(text, substr)
+	}
+	return containsWord(s.normalizeText(text), s.normalizeText(substr))
+}
+
+// containsWord reports whether text contains substr at word boundaries. Like
+// \b in a regular expression, a substring that starts or ends with a word
+// character, which is a letter, digit, or underscore, must not be preceded
+// or followed by another word character. Punctuation at either end of the
+// substring matches next to any character.
+func containsWord(text, substr string) bool {
+	if substr == "" {
+		return true
+	}
+
+	first, _ := utf8.DecodeRuneInString(substr)
+	last, _ := utf8.DecodeLastRuneInString(substr)
+	for offset := 0; offset <= len(text)-len(substr); {
+		i := strings.Index(text[offset:], substr)
+		if i < 0 {
+			return false
+		}
+		start, end := offset+i, offset+i+len(substr)
+
+		before, _ := utf8.DecodeLastRuneInString(text[:start])
+		after, _ := utf8.DecodeRuneInString(text[end:])
+		if !(isWordRune(first) && start > 0 && isWordRune(before)) && !(isWordRune(last) && end < len(text) && isWordRune(after)) {
+			return true
+		}
+
+		_, size := utf8.DecodeRuneInString(text[start:])
+		offset = start + size
+	}
+	return false
+}
+
+func isWordRune(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
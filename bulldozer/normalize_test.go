@@ -0,0 +1,229 @@
+// Copyright 2019 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bulldozer
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/palantir/bulldozer/pull/pulltest"
+)
+
+const (
+	composed   = "fusionn\u00e9e \u01d6ber"
+	decomposed = "fusionne\u0301e u\u0308\u0304ber"
+)
+
+func TestNormalizeText(t *testing.T) {
+	normalized := &Signals{Normalize: true}
+	assert.Equal(t, composed, normalized.normalizeText(decomposed))
+	assert.Equal(t, composed, normalized.normalizeText(composed))
+	assert.Equal(t, "/merge now", normalized.normalizeText("\uff0fmerge\u3000now"))
+	assert.Equal(t, "\u1ec7", normalized.normalizeText("e\u0323\u0302"))
+	assert.Equal(t, "\u1ec7", normalized.normalizeText("e\u0302\u0323"), "did not reorder combining marks")
+	assert.Equal(t, "\uac00", normalized.normalizeText("\u1100\u1161"), "did not compose Hangul")
+	assert.Equal(t, "\u30ac", normalized.normalizeText("\uff76\uff9e"), "did not fold half-width katakana")
+
+	folded := &Signals{FoldCase: true}
+	assert.Equal(t, "merge \u00e9t\u00e9 k", folded.normalizeText("MERGE \u00c9T\u00c9 \u212a"))
+	assert.Equal(t, "fusionne\u0301e", folded.normalizeText("FUSIONNE\u0301E"))
+
+	both := &Signals{Normalize: true, FoldCase: true}
+	assert.Equal(t, composed, both.normalizeText("FUSIONNE\u0301E \u01d5BER"))
+
+	plain := &Signals{}
+	assert.Equal(t, decomposed, plain.normalizeText(decomposed))
+}
+
+func TestSignalsMatchesNormalize(t *testing.T) {
+	ctx := context.Background()
+
+	tests := map[string]struct {
+		Signals Signals
+		Context *pulltest.MockPullContext
+		Enable  func(*Signals)
+	}{
+		"comments": {
+			Signals: Signals{Comments: SubSignal{Values: []string{composed}}},
+			Context: &pulltest.MockPullContext{CommentValue: []string{decomposed}},
+		},
+		"commentSubstrings": {
+			Signals: Signals{CommentSubstrings: SubSignal{Values: []string{decomposed}}},
+			Context: &pulltest.MockPullContext{CommentValue: []string{"merge " + composed}},
+		},
+		"bodySubstrings": {
+			Signals: Signals{PRBodySubstrings: SubSignal{Values: []string{composed}}},
+			Context: &pulltest.MockPullContext{BodyValue: "body " + decomposed},
+		},
+		"labels": {
+			Signals: Signals{Labels: SubSignal{Values: []string{"FUSIONNE\u0301E"}}},
+			Context: &pulltest.MockPullContext{LabelValue: []string{"fusionn\u00e9e"}},
+		},
+		"titleMaxLength": {
+			Signals: Signals{TitleMaxLength: len([]rune(composed))},
+			Context: &pulltest.MockPullContext{TitleValue: decomposed},
+		},
+		"titleRequiredPattern": {
+			Signals: Signals{TitleRequiredPattern: "^" + composed + "$"},
+			Context: &pulltest.MockPullContext{TitleValue: decomposed},
+		},
+		"foldedComments": {
+			Signals: Signals{Comments: SubSignal{Values: []string{"/Merge"}}},
+			Context: &pulltest.MockPullContext{CommentValue: []string{"/MERGE"}},
+			Enable:  func(s *Signals) { s.FoldCase = true },
+		},
+		"foldedBodySubstrings": {
+			Signals: Signals{PRBodySubstrings: SubSignal{Values: []string{"\u00c9T\u00c9"}}},
+			Context: &pulltest.MockPullContext{BodyValue: "body \u00e9t\u00e9"},
+			Enable:  func(s *Signals) { s.FoldCase = true },
+		},
+		"foldedAndNormalized": {
+			Signals: Signals{CommentSubstrings: SubSignal{Values: []string{"FUSIONNE\u0301E"}}},
+			Context: &pulltest.MockPullContext{CommentValue: []string{"merge " + composed}},
+			Enable:  func(s *Signals) { s.Normalize, s.FoldCase = true, true },
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			matches, _, err := test.Signals.Matches(ctx, test.Context, "testlist")
+			require.NoError(t, err)
+			assert.False(t, matches, "matched without normalization")
+
+			if test.Enable != nil {
+				test.Enable(&test.Signals)
+			} else {
+				test.Signals.Normalize = true
+			}
+			matches, _, err = test.Signals.Matches(ctx, test.Context, "testlist")
+			require.NoError(t, err)
+			assert.True(t, matches, "did not match with normalization")
+		})
+	}
+}
+
+func TestContainsWord(t *testing.T) {
+	tests := map[string]struct {
+		Text    string
+		Substr  string
+		Matches bool
+	}{
+		"word":             {Text: "please merge now", Substr: "merge", Matches: true},
+		"prefixOfWord":     {Text: "this is mergeable", Substr: "merge", Matches: false},
+		"suffixOfWord":     {Text: "premerge checks", Substr: "merge", Matches: false},
+		"laterOccurrence":  {Text: "mergeable, so merge", Substr: "merge", Matches: true},
+		"punctuation":      {Text: "ok:merge:", Substr: ":merge:", Matches: true},
+		"punctuationInner": {Text: ":mergeable:", Substr: ":merge", Matches: false},
+		"start":            {Text: "merge", Substr: "merge", Matches: true},
+		"unicode":          {Text: "fusionnée", Substr: "fusionn", Matches: false},
+		"underscore":       {Text: "do_merge", Substr: "merge", Matches: false},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.Matches, containsWord(test.Text, test.Substr))
+		})
+	}
+}
+
+func TestSignalsMatchesWordBoundary(t *testing.T) {
+	ctx := context.Background()
+
+	tests := map[string]struct {
+		Signals Signals
+		Context *pulltest.MockPullContext
+		Enable  func(*Signals)
+	}{
+		"commentSubstrings": {
+			Signals: Signals{CommentSubstrings: SubSignal{Values: []string{":merge"}}},
+			Context: &pulltest.MockPullContext{CommentValue: []string{"this is :mergeable: now"}},
+		},
+		"bodySubstrings": {
+			Signals: Signals{PRBodySubstrings: SubSignal{Values: []string{"merge"}}},
+			Context: &pulltest.MockPullContext{BodyValue: "not mergeable yet"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			matches, _, err := test.Signals.Matches(ctx, test.Context, "testlist")
+			require.NoError(t, err)
+			assert.True(t, matches, "did not match without word boundaries")
+
+			test.Signals.WordBoundary = true
+			matches, _, err = test.Signals.Matches(ctx, test.Context, "testlist")
+			require.NoError(t, err)
+			assert.False(t, matches, "matched with word boundaries")
+		})
+	}
+}
+
+// trimFoldComparator compares text ignoring case and surrounding whitespace.
+type trimFoldComparator struct{}
+
+func (trimFoldComparator) Equal(a, b string) bool {
+	return strings.EqualFold(strings.TrimSpace(a), strings.TrimSpace(b))
+}
+
+func (trimFoldComparator) Contains(haystack, needle string) bool {
+	return strings.Contains(strings.ToLower(haystack), strings.ToLower(strings.TrimSpace(needle)))
+}
+
+func TestSetComparator(t *testing.T) {
+	defer SetComparator(nil)
+
+	ctx := context.Background()
+
+	tests := map[string]struct {
+		Signals Signals
+		Context *pulltest.MockPullContext
+		Enable  func(*Signals)
+	}{
+		"comments": {
+			Signals: Signals{Comments: SubSignal{Values: []string{"/merge"}}},
+			Context: &pulltest.MockPullContext{CommentValue: []string{"  /MERGE\n"}},
+		},
+		"commentSubstrings": {
+			Signals: Signals{CommentSubstrings: SubSignal{Values: []string{" ready to merge "}}},
+			Context: &pulltest.MockPullContext{CommentValue: []string{"This is Ready To Merge."}},
+		},
+		"bodySubstrings": {
+			Signals: Signals{PRBodySubstrings: SubSignal{Values: []string{"==MERGE=="}}},
+			Context: &pulltest.MockPullContext{BodyValue: "Summary\n\n==merge=="},
+		},
+		"labels": {
+			Signals: Signals{Labels: SubSignal{Values: []string{"merge when ready "}}},
+			Context: &pulltest.MockPullContext{LabelValue: []string{"Merge When Ready"}},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			SetComparator(nil)
+			matches, _, err := test.Signals.Matches(ctx, test.Context, "testlist")
+			require.NoError(t, err)
+			assert.False(t, matches, "matched with the default comparator")
+
+			SetComparator(trimFoldComparator{})
+			matches, _, err = test.Signals.Matches(ctx, test.Context, "testlist")
+			require.NoError(t, err)
+			assert.True(t, matches, "did not match with the custom comparator")
+		})
+	}
+}
@@ -0,0 +1,120 @@
+// Copyright 2019 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bulldozer
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// signalReason is the description of the result of a single signal type.
+type signalReason struct {
+	signal string
+	text   string
+}
+
+// markdown formats the reasons of the signal types that decided a result as
+// GitHub-flavored markdown, or returns an empty string if the signals do not
+// enable MarkdownReasons. If summary is not empty, it introduces a bullet
+// list of the reasons; otherwise, there must be a single reason.
+func (s *Signals) markdown(summary string, reasons ...signalReason) string {
+	if !s.MarkdownReasons {
+		return ""
+	}
+
+	if summary == "" && len(reasons) == 1 {
+		return markdownSignalReason(reasons[0])
+	}
+
+	var b strings.Builder
+	b.WriteString(markdownText(summary))
+	if len(reasons) > 0 {
+		b.WriteString(":\n")
+		for _, r := range reasons {
+			b.WriteString("\n- ")
+			b.WriteString(markdownSignalReason(r))
+		}
+	}
+	return b.String()
+}
+
+// joinReasons returns the plain text reasons separated by semicolons.
+func joinReasons(reasons []signalReason) string {
+	texts := make([]string, len(reasons))
+	for i, r := range reasons {
+		texts[i] = r.text
+	}
+	return strings.Join(texts, "; ")
+}
+
+func markdownSignalReason(r signalReason) string {
+	if r.signal == "" {
+		return markdownText(r.text)
+	}
+	return fmt.Sprintf("**%s**: %s", r.signal, markdownText(r.text))
+}
+
+var quotedPattern = regexp.MustCompile(`"(?:[^"\\]|\\.)*"`)
+
+// markdownText converts the quoted values in a plain text reason, like
+// labels and branch names, to code spans, and escapes other characters that
+// markdown would interpret.
+func markdownText(text string) string {
+	var b strings.Builder
+	last := 0
+	for _, loc := range quotedPattern.FindAllStringIndex(text, -1) {
+		value, err := strconv.Unquote(text[loc[0]:loc[1]])
+		if err != nil {
+			continue
+		}
+		b.WriteString(escapeMarkdown(text[last:loc[0]]))
+		b.WriteString(codeSpan(value))
+		last = loc[1]
+	}
+	b.WriteString(escapeMarkdown(text[last:]))
+	return b.String()
+}
+
+var markdownEscaper = strings.NewReplacer(
+	`\`, `\\`, "`", "\\`", "*", `\*`, "_", `\_`, "[", `\[`, "]", `\]`, "<", `\<`, ">", `\>`, "#", `\#`, "|", `\|`,
+)
+
+func escapeMarkdown(text string) string {
+	return markdownEscaper.Replace(text)
+}
+
+// codeSpan returns value as a markdown code span, using a fence longer than
+// any run of backticks in the value.
+func codeSpan(value string) string {
+	longest, run := 0, 0
+	for _, r := range value {
+		if r == '`' {
+			run++
+			if run > longest {
+				longest = run
+			}
+		} else {
+			run = 0
+		}
+	}
+
+	fence := strings.Repeat("`", longest+1)
+	if longest > 0 || strings.HasPrefix(value, " ") || strings.HasSuffix(value, " ") {
+		return fence + " " + value + " " + fence
+	}
+	return fence + value + fence
+}
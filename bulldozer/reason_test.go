@@ -0,0 +1,119 @@
+// Copyright 2019 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bulldozer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/palantir/bulldozer/pull/pulltest"
+)
+
+func TestMarkdownText(t *testing.T) {
+	tests := map[string]struct {
+		Text     string
+		Markdown string
+	}{
+		"quotedValues": {
+			Text:     `pull request has a trigger label: "merge when ready"`,
+			Markdown: "pull request has a trigger label: `merge when ready`",
+		},
+		"escapedQuotes": {
+			Text:     `pull request title matches "say \"hi\""`,
+			Markdown: "pull request title matches `say \"hi\"`",
+		},
+		"backticks": {
+			Text:     `pull request body has a trigger substring: "use ` + "`go vet`" + `"`,
+			Markdown: "pull request body has a trigger substring: `` use `go vet` ``",
+		},
+		"markdownCharacters": {
+			Text:     `pull request changes 2 files in "*.lock" (maximum 1) for #12`,
+			Markdown: "pull request changes 2 files in `*.lock` (maximum 1) for \\#12",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.Markdown, markdownText(test.Text))
+		})
+	}
+}
+
+func TestSignalsMarkdownReasons(t *testing.T) {
+	ctx := context.Background()
+
+	pc := &pulltest.MockPullContext{
+		BranchBase: "develop",
+		LabelValue: []string{"merge when ready"},
+	}
+
+	tests := map[string]struct {
+		Signals  Signals
+		Reason   string
+		Markdown string
+	}{
+		"matchAll": {
+			Signals: Signals{
+				Match:           MatchAll,
+				Labels:          SubSignal{Values: []string{"merge when ready"}},
+				Branches:        SubSignal{Values: []string{"develop"}},
+				MarkdownReasons: true,
+			},
+			Reason: `pull request matches all testlist signals: pull request target is a testlist branch: "develop"; pull request has a testlist label: "merge when ready"`,
+			Markdown: "pull request matches all testlist signals:\n" +
+				"\n- **branches**: pull request target is a testlist branch: `develop`" +
+				"\n- **labels**: pull request has a testlist label: `merge when ready`",
+		},
+		"matchOne": {
+			Signals: Signals{
+				Labels:          SubSignal{Values: []string{"merge when ready"}},
+				MarkdownReasons: true,
+			},
+			Reason:   `pull request has a testlist label: "merge when ready"`,
+			Markdown: "**labels**: pull request has a testlist label: `merge when ready`",
+		},
+		"allFailures": {
+			Signals: Signals{
+				Match:            MatchAll,
+				Labels:           SubSignal{Values: []string{"ready"}},
+				Branches:         SubSignal{Values: []string{"main"}},
+				ReportAllReasons: true,
+				MarkdownReasons:  true,
+			},
+			Reason: `pull request does not match 2 testlist signals: pull request target branch ("develop") is not a testlist branch: "main"; pull request does not have a testlist label: "ready"`,
+			Markdown: "pull request does not match 2 testlist signals:\n" +
+				"\n- **branches**: pull request target branch (`develop`) is not a testlist branch: `main`" +
+				"\n- **labels**: pull request does not have a testlist label: `ready`",
+		},
+		"plainOnly": {
+			Signals: Signals{
+				Labels: SubSignal{Values: []string{"merge when ready"}},
+			},
+			Reason: `pull request has a testlist label: "merge when ready"`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			result, err := test.Signals.Evaluate(ctx, pc, "testlist")
+			require.NoError(t, err)
+			assert.Equal(t, test.Reason, result.Reason)
+			assert.Equal(t, test.Markdown, result.MarkdownReason)
+		})
+	}
+}
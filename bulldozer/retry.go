@@ -0,0 +1,507 @@
+// Copyright 2019 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bulldozer
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/google/go-github/v32/github"
+	"github.com/pkg/errors"
+
+	"github.com/palantir/bulldozer/pull"
+)
+
+// RetryPolicy controls how pull request data that fails to load with a
+// transient error is retried while signals are evaluated. Each accessor of
+// the pull request context is called at most Attempts times, waiting Backoff
+// before the first retry and doubling the wait before each subsequent retry.
+type RetryPolicy struct {
+	Attempts int
+	Backoff  time.Duration
+}
+
+// DefaultRetryPolicy is the retry policy used if SetRetryPolicy is not
+// called. It makes a single attempt, so errors are not retried.
+var DefaultRetryPolicy = RetryPolicy{
+	Attempts: 1,
+}
+
+var retryPolicy = DefaultRetryPolicy
+
+// SetRetryPolicy sets the policy for retrying pull request data that fails to
+// load with a transient error, such as a server error or a secondary rate
+// limit response from GitHub. An Attempts value less than or equal to one
+// disables retries. Like Register, SetRetryPolicy should be called during
+// program initialization.
+func SetRetryPolicy(policy RetryPolicy) {
+	retryPolicy = policy
+}
+
+// maxRetryAfter is the longest wait requested by a secondary rate limit
+// response that an evaluation honors before giving up.
+const maxRetryAfter = time.Minute
+
+// sleep waits for the duration or until the context is canceled. It is a
+// variable so tests can avoid waiting.
+var sleep = func(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
+
+// retryDelay returns how long to wait before retrying a request that failed
+// with err and false if the request should not be retried. GitHub server
+// errors and network timeouts are retried after backoff. Secondary rate limit
+// responses are retried after the wait GitHub requests, if it is no longer
+// than maxRetryAfter, or after backoff if GitHub does not specify one. Primary
+// rate limits only reset at the end of the hour, so they are not retried;
+// neither are other client errors, like missing resources or permissions.
+func retryDelay(err error, backoff time.Duration) (time.Duration, bool) {
+	switch cause := errors.Cause(err).(type) {
+	case *github.RateLimitError:
+		return 0, false
+	case *github.AbuseRateLimitError:
+		if cause.RetryAfter == nil {
+			return backoff, true
+		}
+		if *cause.RetryAfter > maxRetryAfter {
+			return 0, false
+		}
+		return *cause.RetryAfter, true
+	case *github.ErrorResponse:
+		return backoff, cause.Response != nil && cause.Response.StatusCode >= http.StatusInternalServerError
+	case net.Error:
+		return backoff, cause.Timeout()
+	}
+	return 0, false
+}
+
+// withRetries returns a context that retries the accessors of pullCtx
+// according to the retry policy, or pullCtx itself if retries are disabled or
+// pullCtx already retries. Only the failed accessor call is repeated, so data
+// loaded by earlier calls is reused.
+func withRetries(pullCtx pull.Context) pull.Context {
+	if _, ok := pullCtx.(*retryingContext); ok || retryPolicy.Attempts <= 1 {
+		return pullCtx
+	}
+	return &retryingContext{Context: pullCtx, policy: retryPolicy}
+}
+
+// retryingContext is a pull.Context that retries accessors that fail with a
+// transient error. Methods that do not make requests or that change the pull
+// request are passed through unchanged.
+type retryingContext struct {
+	pull.Context
+
+	policy RetryPolicy
+}
+
+// retry calls fn until it succeeds, fails with an error that should not be
+// retried, or the attempts of the policy are exhausted.
+func (c *retryingContext) retry(ctx context.Context, fn func() error) error {
+	err := fn()
+
+	backoff := c.policy.Backoff
+	for attempt := 1; attempt < c.policy.Attempts; attempt++ {
+		wait, retry := retryDelay(err, backoff)
+		if !retry {
+			break
+		}
+		if serr := sleep(ctx, wait); serr != nil {
+			break
+		}
+		backoff *= 2
+		err = fn()
+	}
+	return err
+}
+
+func (c *retryingContext) AuthorContributions(ctx context.Context) (int, error) {
+	var authorContributions int
+	err := c.retry(ctx, func() (err error) {
+		authorContributions, err = c.Context.AuthorContributions(ctx)
+		return err
+	})
+	return authorContributions, err
+}
+
+func (c *retryingContext) AuthorCreatedAt(ctx context.Context) (time.Time, error) {
+	var authorCreatedAt time.Time
+	err := c.retry(ctx, func() (err error) {
+		authorCreatedAt, err = c.Context.AuthorCreatedAt(ctx)
+		return err
+	})
+	return authorCreatedAt, err
+}
+
+func (c *retryingContext) MergeState(ctx context.Context) (*pull.MergeState, error) {
+	var mergeState *pull.MergeState
+	err := c.retry(ctx, func() (err error) {
+		mergeState, err = c.Context.MergeState(ctx)
+		return err
+	})
+	return mergeState, err
+}
+
+func (c *retryingContext) AutoMerge(ctx context.Context) (*pull.AutoMerge, error) {
+	var autoMerge *pull.AutoMerge
+	err := c.retry(ctx, func() (err error) {
+		autoMerge, err = c.Context.AutoMerge(ctx)
+		return err
+	})
+	return autoMerge, err
+}
+
+func (c *retryingContext) MergeSettings(ctx context.Context) (*pull.MergeSettings, error) {
+	var mergeSettings *pull.MergeSettings
+	err := c.retry(ctx, func() (err error) {
+		mergeSettings, err = c.Context.MergeSettings(ctx)
+		return err
+	})
+	return mergeSettings, err
+}
+
+func (c *retryingContext) DefaultBranch(ctx context.Context) (string, error) {
+	var defaultBranch string
+	err := c.retry(ctx, func() (err error) {
+		defaultBranch, err = c.Context.DefaultBranch(ctx)
+		return err
+	})
+	return defaultBranch, err
+}
+
+func (c *retryingContext) Topics(ctx context.Context) ([]string, error) {
+	var topics []string
+	err := c.retry(ctx, func() (err error) {
+		topics, err = c.Context.Topics(ctx)
+		return err
+	})
+	return topics, err
+}
+
+func (c *retryingContext) RepositoryProperties(ctx context.Context) (map[string][]string, error) {
+	var repositoryProperties map[string][]string
+	err := c.retry(ctx, func() (err error) {
+		repositoryProperties, err = c.Context.RepositoryProperties(ctx)
+		return err
+	})
+	return repositoryProperties, err
+}
+
+func (c *retryingContext) RequiredStatuses(ctx context.Context) ([]string, error) {
+	var requiredStatuses []string
+	err := c.retry(ctx, func() (err error) {
+		requiredStatuses, err = c.Context.RequiredStatuses(ctx)
+		return err
+	})
+	return requiredStatuses, err
+}
+
+func (c *retryingContext) PushRestrictions(ctx context.Context) (bool, error) {
+	var pushRestrictions bool
+	err := c.retry(ctx, func() (err error) {
+		pushRestrictions, err = c.Context.PushRestrictions(ctx)
+		return err
+	})
+	return pushRestrictions, err
+}
+
+func (c *retryingContext) RequiredApprovals(ctx context.Context) (int, error) {
+	var requiredApprovals int
+	err := c.retry(ctx, func() (err error) {
+		requiredApprovals, err = c.Context.RequiredApprovals(ctx)
+		return err
+	})
+	return requiredApprovals, err
+}
+
+func (c *retryingContext) RequiresConversationResolution(ctx context.Context) (bool, error) {
+	var requiresConversationResolution bool
+	err := c.retry(ctx, func() (err error) {
+		requiresConversationResolution, err = c.Context.RequiresConversationResolution(ctx)
+		return err
+	})
+	return requiresConversationResolution, err
+}
+
+func (c *retryingContext) IsBranchProtected(ctx context.Context, branch string) (bool, error) {
+	var isBranchProtected bool
+	err := c.retry(ctx, func() (err error) {
+		isBranchProtected, err = c.Context.IsBranchProtected(ctx, branch)
+		return err
+	})
+	return isBranchProtected, err
+}
+
+func (c *retryingContext) BranchExists(ctx context.Context, branch string) (bool, error) {
+	var branchExists bool
+	err := c.retry(ctx, func() (err error) {
+		branchExists, err = c.Context.BranchExists(ctx, branch)
+		return err
+	})
+	return branchExists, err
+}
+
+func (c *retryingContext) CurrentSuccessStatuses(ctx context.Context) ([]string, error) {
+	var currentSuccessStatuses []string
+	err := c.retry(ctx, func() (err error) {
+		currentSuccessStatuses, err = c.Context.CurrentSuccessStatuses(ctx)
+		return err
+	})
+	return currentSuccessStatuses, err
+}
+
+func (c *retryingContext) Statuses(ctx context.Context) ([]*pull.Status, error) {
+	var statuses []*pull.Status
+	err := c.retry(ctx, func() (err error) {
+		statuses, err = c.Context.Statuses(ctx)
+		return err
+	})
+	return statuses, err
+}
+
+func (c *retryingContext) CheckOutputs(ctx context.Context, check string) ([]*pull.CheckOutput, error) {
+	var checkOutputs []*pull.CheckOutput
+	err := c.retry(ctx, func() (err error) {
+		checkOutputs, err = c.Context.CheckOutputs(ctx, check)
+		return err
+	})
+	return checkOutputs, err
+}
+
+func (c *retryingContext) Deployments(ctx context.Context) ([]*pull.Deployment, error) {
+	var deployments []*pull.Deployment
+	err := c.retry(ctx, func() (err error) {
+		deployments, err = c.Context.Deployments(ctx)
+		return err
+	})
+	return deployments, err
+}
+
+func (c *retryingContext) WorkflowRuns(ctx context.Context) ([]*pull.WorkflowRun, error) {
+	var workflowRuns []*pull.WorkflowRun
+	err := c.retry(ctx, func() (err error) {
+		workflowRuns, err = c.Context.WorkflowRuns(ctx)
+		return err
+	})
+	return workflowRuns, err
+}
+
+func (c *retryingContext) LabelEvents(ctx context.Context) ([]*pull.LabelEvent, error) {
+	var labelEvents []*pull.LabelEvent
+	err := c.retry(ctx, func() (err error) {
+		labelEvents, err = c.Context.LabelEvents(ctx)
+		return err
+	})
+	return labelEvents, err
+}
+
+func (c *retryingContext) Timeline(ctx context.Context) ([]*pull.TimelineEvent, error) {
+	var timeline []*pull.TimelineEvent
+	err := c.retry(ctx, func() (err error) {
+		timeline, err = c.Context.Timeline(ctx)
+		return err
+	})
+	return timeline, err
+}
+
+func (c *retryingContext) RequestedReviewers(ctx context.Context) (*pull.RequestedReviewers, error) {
+	var requestedReviewers *pull.RequestedReviewers
+	err := c.retry(ctx, func() (err error) {
+		requestedReviewers, err = c.Context.RequestedReviewers(ctx)
+		return err
+	})
+	return requestedReviewers, err
+}
+
+func (c *retryingContext) Reviews(ctx context.Context) ([]*pull.Review, error) {
+	var reviews []*pull.Review
+	err := c.retry(ctx, func() (err error) {
+		reviews, err = c.Context.Reviews(ctx)
+		return err
+	})
+	return reviews, err
+}
+
+func (c *retryingContext) Participants(ctx context.Context) ([]*pull.Participant, error) {
+	var participants []*pull.Participant
+	err := c.retry(ctx, func() (err error) {
+		participants, err = c.Context.Participants(ctx)
+		return err
+	})
+	return participants, err
+}
+
+func (c *retryingContext) ReviewThreads(ctx context.Context) ([]*pull.ReviewThread, error) {
+	var reviewThreads []*pull.ReviewThread
+	err := c.retry(ctx, func() (err error) {
+		reviewThreads, err = c.Context.ReviewThreads(ctx)
+		return err
+	})
+	return reviewThreads, err
+}
+
+func (c *retryingContext) TeamMembers(ctx context.Context, team string) ([]string, error) {
+	var teamMembers []string
+	err := c.retry(ctx, func() (err error) {
+		teamMembers, err = c.Context.TeamMembers(ctx, team)
+		return err
+	})
+	return teamMembers, err
+}
+
+func (c *retryingContext) PullRequestState(ctx context.Context, number int) (*pull.PullRequestState, error) {
+	var pullRequestState *pull.PullRequestState
+	err := c.retry(ctx, func() (err error) {
+		pullRequestState, err = c.Context.PullRequestState(ctx, number)
+		return err
+	})
+	return pullRequestState, err
+}
+
+func (c *retryingContext) OpenPullRequestsWithHead(ctx context.Context, branch string) ([]int, error) {
+	var openPullRequestsWithHead []int
+	err := c.retry(ctx, func() (err error) {
+		openPullRequestsWithHead, err = c.Context.OpenPullRequestsWithHead(ctx, branch)
+		return err
+	})
+	return openPullRequestsWithHead, err
+}
+
+func (c *retryingContext) Issue(ctx context.Context, number int) (*pull.Issue, error) {
+	var issue *pull.Issue
+	err := c.retry(ctx, func() (err error) {
+		issue, err = c.Context.Issue(ctx, number)
+		return err
+	})
+	return issue, err
+}
+
+func (c *retryingContext) DiscussionExists(ctx context.Context, owner, repo string, number int) (bool, error) {
+	var discussionExists bool
+	err := c.retry(ctx, func() (err error) {
+		discussionExists, err = c.Context.DiscussionExists(ctx, owner, repo, number)
+		return err
+	})
+	return discussionExists, err
+}
+
+func (c *retryingContext) Comments(ctx context.Context) ([]string, error) {
+	var comments []string
+	err := c.retry(ctx, func() (err error) {
+		comments, err = c.Context.Comments(ctx)
+		return err
+	})
+	return comments, err
+}
+
+func (c *retryingContext) AuthoredComments(ctx context.Context) ([]*pull.Comment, error) {
+	var authoredComments []*pull.Comment
+	err := c.retry(ctx, func() (err error) {
+		authoredComments, err = c.Context.AuthoredComments(ctx)
+		return err
+	})
+	return authoredComments, err
+}
+
+func (c *retryingContext) Reactions(ctx context.Context) ([]*pull.Reaction, error) {
+	var reactions []*pull.Reaction
+	err := c.retry(ctx, func() (err error) {
+		reactions, err = c.Context.Reactions(ctx)
+		return err
+	})
+	return reactions, err
+}
+
+func (c *retryingContext) Commits(ctx context.Context) ([]*pull.Commit, error) {
+	var commits []*pull.Commit
+	err := c.retry(ctx, func() (err error) {
+		commits, err = c.Context.Commits(ctx)
+		return err
+	})
+	return commits, err
+}
+
+func (c *retryingContext) ChangedFiles(ctx context.Context) ([]*pull.File, error) {
+	var changedFiles []*pull.File
+	err := c.retry(ctx, func() (err error) {
+		changedFiles, err = c.Context.ChangedFiles(ctx)
+		return err
+	})
+	return changedFiles, err
+}
+
+func (c *retryingContext) FileSizes(ctx context.Context) (map[string]int64, error) {
+	var fileSizes map[string]int64
+	err := c.retry(ctx, func() (err error) {
+		fileSizes, err = c.Context.FileSizes(ctx)
+		return err
+	})
+	return fileSizes, err
+}
+
+func (c *retryingContext) Diff(ctx context.Context) (string, error) {
+	var diff string
+	err := c.retry(ctx, func() (err error) {
+		diff, err = c.Context.Diff(ctx)
+		return err
+	})
+	return diff, err
+}
+
+func (c *retryingContext) CodeOwners(ctx context.Context) (string, error) {
+	var codeOwners string
+	err := c.retry(ctx, func() (err error) {
+		codeOwners, err = c.Context.CodeOwners(ctx)
+		return err
+	})
+	return codeOwners, err
+}
+
+func (c *retryingContext) Labels(ctx context.Context) ([]string, error) {
+	var labels []string
+	err := c.retry(ctx, func() (err error) {
+		labels, err = c.Context.Labels(ctx)
+		return err
+	})
+	return labels, err
+}
+
+func (c *retryingContext) IsTargeted(ctx context.Context) (bool, error) {
+	var isTargeted bool
+	err := c.retry(ctx, func() (err error) {
+		isTargeted, err = c.Context.IsTargeted(ctx)
+		return err
+	})
+	return isTargeted, err
+}
+
+func (c *retryingContext) MergeAttempts(ctx context.Context) (int, error) {
+	var mergeAttempts int
+	err := c.retry(ctx, func() (err error) {
+		mergeAttempts, err = c.Context.MergeAttempts(ctx)
+		return err
+	})
+	return mergeAttempts, err
+}
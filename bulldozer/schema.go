@@ -0,0 +1,118 @@
+// Copyright 2019 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bulldozer
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// SchemaVersion is the JSON Schema dialect of the document returned by
+// SignalsSchema.
+const SchemaVersion = "http://json-schema.org/draft-07/schema#"
+
+// MatchTypes lists the values accepted for the "match" key of signals and
+// sub-signals.
+var MatchTypes = []MatchType{MatchOne, MatchAll, MatchScore}
+
+var (
+	matchTypeType = reflect.TypeOf(MatchType(""))
+	durationType  = reflect.TypeOf(time.Duration(0))
+	subSignalType = reflect.TypeOf(SubSignal{})
+	signalsType   = reflect.TypeOf(Signals{})
+)
+
+// SignalsSchema returns a JSON Schema document describing a set of signals,
+// like the "trigger" and "ignore" sections of the configuration. The schema
+// is generated from the yaml tags of Signals, so it includes every signal
+// this version of bulldozer supports, and can be used by editors to complete
+// and validate configuration files.
+func SignalsSchema() ([]byte, error) {
+	schema := structSchema(signalsType)
+	schema["$schema"] = SchemaVersion
+	schema["title"] = "bulldozer signals"
+	return json.MarshalIndent(schema, "", "  ")
+}
+
+// schemaFor returns the schema of values of type t as they appear in YAML.
+func schemaFor(t reflect.Type) map[string]interface{} {
+	switch t {
+	case signalsType:
+		// nested signals, like groups, refer back to the root of the document
+		return map[string]interface{}{"$ref": "#"}
+	case matchTypeType:
+		enum := make([]string, len(MatchTypes))
+		for i, m := range MatchTypes {
+			enum[i] = string(m)
+		}
+		return map[string]interface{}{"type": "string", "enum": enum}
+	case durationType:
+		return map[string]interface{}{
+			"type":    "string",
+			"pattern": `^([0-9]+(\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$`,
+		}
+	case subSignalType:
+		// SubSignal.UnmarshalYAML also accepts a plain list of values
+		object := structSchema(t)
+		object["required"] = []string{"values"}
+		return map[string]interface{}{
+			"oneOf": []interface{}{
+				schemaFor(reflect.TypeOf([]string{})),
+				object,
+			},
+		}
+	}
+
+	switch t.Kind() {
+	case reflect.Ptr:
+		return schemaFor(t.Elem())
+	case reflect.Struct:
+		return structSchema(t)
+	case reflect.Slice:
+		return map[string]interface{}{"type": "array", "items": schemaFor(t.Elem())}
+	case reflect.Map:
+		return map[string]interface{}{"type": "object", "additionalProperties": schemaFor(t.Elem())}
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return map[string]interface{}{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]interface{}{"type": "number"}
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}
+	}
+	return map[string]interface{}{}
+}
+
+// structSchema returns the schema of an object with a property for each
+// field of t with a yaml tag. Fields without a tag are not configurable.
+func structSchema(t reflect.Type) map[string]interface{} {
+	properties := make(map[string]interface{})
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name := strings.Split(f.Tag.Get("yaml"), ",")[0]
+		if name == "" || name == "-" {
+			continue
+		}
+		properties[name] = schemaFor(f.Type)
+	}
+	return map[string]interface{}{
+		"type":                 "object",
+		"properties":           properties,
+		"additionalProperties": false,
+	}
+}
@@ -0,0 +1,260 @@
+// Copyright 2019 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bulldozer
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v2"
+)
+
+func TestSignalsSchema(t *testing.T) {
+	data, err := SignalsSchema()
+	require.NoError(t, err)
+
+	var schema map[string]interface{}
+	require.NoError(t, json.Unmarshal(data, &schema))
+	assert.Equal(t, SchemaVersion, schema["$schema"])
+
+	properties := schema["properties"].(map[string]interface{})
+	assert.Equal(t, map[string]interface{}{
+		"type": "string",
+		"enum": []interface{}{"one", "all", "score"},
+	}, properties["match"])
+
+	tests := map[string]struct {
+		Config string
+		Valid  bool
+	}{
+		"sample": {
+			Config: `
+match: all
+extends: ["do_not_merge"]
+labels: ["merge when ready"]
+comments:
+  values: ["/merge"]
+  match: one
+  required: true
+branch_prefixes:
+  values: ["release/"]
+  enabled: false
+title_max_length: 72
+min_open_duration: 1h30m
+repo_properties:
+  tier: production
+path_file_counts:
+  "migrations/": 3
+branch_issue_convention:
+  pattern: "^(\\d+)-"
+  require_assignee: true
+min_patch_coverage: 80.5
+groups:
+  dependabot_main:
+    match: all
+    creators: ["dependabot[bot]"]
+    branches: ["main"]
+weights:
+  labels: 2
+`,
+			Valid: true,
+		},
+		"unknownKey": {
+			Config: `labelz: ["merge when ready"]`,
+		},
+		"invalidMatch": {
+			Config: `match: any`,
+		},
+		"subSignalWithoutValues": {
+			Config: `
+labels:
+  match: all
+`,
+		},
+		"creatorsMatch": {
+			Config: `
+match: all
+creators:
+  values: ["dependabot[bot]", "renovate[bot]"]
+  match: one
+`,
+			Valid: true,
+		},
+		"matchOnPlainList": {
+			Config: `
+required_workflows:
+  values: ["ci"]
+  match: one
+`,
+		},
+		"invalidDuration": {
+			Config: `min_open_duration: 2 days`,
+		},
+		"wrongType": {
+			Config: `title_max_length: long`,
+		},
+		"wrongNumberType": {
+			Config: `min_patch_coverage: high`,
+		},
+		"invalidGroup": {
+			Config: `
+groups:
+  dependabot_main:
+    match: any
+`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var config interface{}
+			require.NoError(t, yaml.Unmarshal([]byte(test.Config), &config))
+
+			err := validateSchema(schema, schema, jsonValue(config), "$")
+			if test.Valid {
+				assert.NoError(t, err)
+
+				// the schema should only accept configurations that parse
+				var signals Signals
+				assert.NoError(t, yaml.UnmarshalStrict([]byte(test.Config), &signals))
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+// jsonValue converts a value decoded from YAML to the types produced when
+// decoding JSON.
+func jsonValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for k, e := range v {
+			m[fmt.Sprint(k)] = jsonValue(e)
+		}
+		return m
+	case []interface{}:
+		for i, e := range v {
+			v[i] = jsonValue(e)
+		}
+		return v
+	case int:
+		return float64(v)
+	}
+	return v
+}
+
+// validateSchema validates a value against the subset of JSON Schema used by
+// SignalsSchema. References are resolved against root, which is the only
+// target SignalsSchema refers to.
+func validateSchema(root, schema map[string]interface{}, v interface{}, at string) error {
+	if ref, ok := schema["$ref"]; ok {
+		if ref != "#" {
+			return fmt.Errorf("%s: unsupported reference %q", at, ref)
+		}
+		return validateSchema(root, root, v, at)
+	}
+
+	if options, ok := schema["oneOf"].([]interface{}); ok {
+		matched := 0
+		for _, o := range options {
+			if validateSchema(root, o.(map[string]interface{}), v, at) == nil {
+				matched++
+			}
+		}
+		if matched != 1 {
+			return fmt.Errorf("%s: matches %d schemas, expected exactly one", at, matched)
+		}
+		return nil
+	}
+
+	switch schema["type"] {
+	case "object":
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("%s: expected an object", at)
+		}
+		if required, ok := schema["required"].([]interface{}); ok {
+			for _, r := range required {
+				if _, ok := m[r.(string)]; !ok {
+					return fmt.Errorf("%s: missing required property %q", at, r)
+				}
+			}
+		}
+		properties, _ := schema["properties"].(map[string]interface{})
+		for k, e := range m {
+			var s interface{}
+			if p, ok := properties[k]; ok {
+				s = p
+			} else {
+				s = schema["additionalProperties"]
+			}
+			switch s := s.(type) {
+			case bool:
+				if !s {
+					return fmt.Errorf("%s: unexpected property %q", at, k)
+				}
+			case map[string]interface{}:
+				if err := validateSchema(root, s, e, at+"."+k); err != nil {
+					return err
+				}
+			}
+		}
+	case "array":
+		a, ok := v.([]interface{})
+		if !ok {
+			return fmt.Errorf("%s: expected an array", at)
+		}
+		for i, e := range a {
+			if err := validateSchema(root, schema["items"].(map[string]interface{}), e, fmt.Sprintf("%s[%d]", at, i)); err != nil {
+				return err
+			}
+		}
+	case "string":
+		s, ok := v.(string)
+		if !ok {
+			return fmt.Errorf("%s: expected a string", at)
+		}
+		if pattern, ok := schema["pattern"].(string); ok && !regexp.MustCompile(pattern).MatchString(s) {
+			return fmt.Errorf("%s: %q does not match %q", at, s, pattern)
+		}
+		if enum, ok := schema["enum"].([]interface{}); ok {
+			for _, e := range enum {
+				if e == s {
+					return nil
+				}
+			}
+			return fmt.Errorf("%s: %q is not one of %v", at, s, enum)
+		}
+	case "integer":
+		if f, ok := v.(float64); !ok || f != float64(int64(f)) {
+			return fmt.Errorf("%s: expected an integer", at)
+		}
+	case "number":
+		if _, ok := v.(float64); !ok {
+			return fmt.Errorf("%s: expected a number", at)
+		}
+	case "boolean":
+		if _, ok := v.(bool); !ok {
+			return fmt.Errorf("%s: expected a boolean", at)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,268 @@
+// Copyright 2019 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bulldozer
+
+import (
+	"context"
+	"time"
+
+	"github.com/palantir/bulldozer/pull"
+)
+
+// SignalEvaluator evaluates a single type of signal against a pull request.
+// Evaluators for the built-in signals are always registered; additional
+// evaluators can be added with Register.
+type SignalEvaluator interface {
+	// Name returns a unique name for the signal type, used in logs.
+	Name() string
+
+	// Enabled returns true if the signals configure this signal type. An
+	// evaluator that is not enabled does not contribute to the result.
+	Enabled(s Signals) bool
+
+	// Match returns true if the pull request meets this signal type, along
+	// with a description of why it does or does not match. The tag argument
+	// indicates the behavior (trigger, ignore) the signals are associated
+	// with and should appear in the description.
+	Match(ctx context.Context, s Signals, pullCtx pull.Context, tag string) (bool, string, error)
+}
+
+// builtinEvaluator adapts the helper methods of Signals to SignalEvaluator.
+// The helpers report when they are not configured and the position of the
+// matched value, which is used directly when evaluating signals.
+type builtinEvaluator struct {
+	name    string
+	enabled func(s *Signals) bool
+	match   func(s *Signals, ctx context.Context, pullCtx pull.Context, tag string) (signalResult, string, int, error)
+}
+
+func (e builtinEvaluator) Name() string {
+	return e.name
+}
+
+func (e builtinEvaluator) Enabled(s Signals) bool {
+	return e.enabled(&s)
+}
+
+func (e builtinEvaluator) Match(ctx context.Context, s Signals, pullCtx pull.Context, tag string) (bool, string, error) {
+	result, reason, _, err := e.match(&s, ctx, pullCtx, tag)
+	return result == signalMatch, reason, err
+}
+
+// listEvaluator is a builtinEvaluator for a signal configured by a SubSignal,
+// which may mark the signal as required or disable it.
+type listEvaluator struct {
+	builtinEvaluator
+	values func(s *Signals) SubSignal
+}
+
+func newListEvaluator(name string, values func(s *Signals) SubSignal, match func(s *Signals, ctx context.Context, pullCtx pull.Context, tag string) (signalResult, string, int, error)) listEvaluator {
+	enabled := func(s *Signals) bool {
+		return values(s).isEnabled()
+	}
+	return listEvaluator{builtinEvaluator{name, enabled, match}, values}
+}
+
+// isRequired returns true if the signals mark the signal type evaluated by e
+// as required.
+func isRequired(e SignalEvaluator, s *Signals) bool {
+	l, ok := e.(listEvaluator)
+	return ok && l.values(s).Required && l.enabled(s) && !s.isDisabled(e.Name())
+}
+
+// evaluators lists the registered evaluators in the order they are evaluated.
+// The built-in evaluators that only use data already present on the pull
+// request come first, followed by those that make API requests, followed by
+// evaluators added with Register.
+var evaluators = []SignalEvaluator{
+	newListEvaluator("pr_body_substrings", func(s *Signals) SubSignal { return s.PRBodySubstrings }, (*Signals).doesPRBodySubstringSignalMatch),
+	builtinEvaluator{"title", func(s *Signals) bool { return s.TitleMaxLength > 0 || s.TitleRequiredPattern != "" }, (*Signals).doesTitleSignalMatch},
+	builtinEvaluator{"body_length", func(s *Signals) bool { return s.MinBodyLength > 0 || s.MaxBodyLength > 0 }, (*Signals).doesBodyLengthSignalMatch},
+	builtinEvaluator{"body_headers", func(s *Signals) bool { return len(s.BodyHeaders) > 0 }, (*Signals).doesBodyHeaderSignalMatch},
+	builtinEvaluator{"require_completed_checklist", func(s *Signals) bool { return s.RequireCompletedChecklist }, (*Signals).doesChecklistSignalMatch},
+	builtinEvaluator{"match_reverts", func(s *Signals) bool { return s.MatchReverts }, (*Signals).doesRevertSignalMatch},
+	builtinEvaluator{"min_open_duration", func(s *Signals) bool { return s.MinOpenDuration > 0 }, (*Signals).doesOpenDurationSignalMatch},
+	newListEvaluator("branches", func(s *Signals) SubSignal { return s.Branches }, (*Signals).doesBranchSignalMatch),
+	newListEvaluator("branch_patterns", func(s *Signals) SubSignal { return s.BranchPatterns }, (*Signals).doesBranchPatternSignalMatch),
+	newListEvaluator("branch_prefixes", func(s *Signals) SubSignal { return s.BranchPrefixes }, (*Signals).doesBranchPrefixSignalMatch),
+	newListEvaluator("branch_suffixes", func(s *Signals) SubSignal { return s.BranchSuffixes }, (*Signals).doesBranchSuffixSignalMatch),
+	builtinEvaluator{"protected_head_branches", func(s *Signals) bool { return s.protectsHeadBranches() }, (*Signals).doesProtectedHeadBranchSignalMatch},
+	builtinEvaluator{"author_association", func(s *Signals) bool { return s.minAuthorAssociation() != "" }, (*Signals).doesAuthorAssociationSignalMatch},
+	newListEvaluator("creators", func(s *Signals) SubSignal { return s.Creators }, (*Signals).doesCreatorSignalMatch),
+	builtinEvaluator{"creator_is_bot", func(s *Signals) bool { return s.CreatorIsBot != nil }, (*Signals).doesCreatorTypeSignalMatch},
+	builtinEvaluator{"author_contributions", func(s *Signals) bool { return s.MinAuthorContributions > 0 }, (*Signals).doesAuthorContributionSignalMatch},
+	builtinEvaluator{"author_account_age", func(s *Signals) bool { return s.MinAuthorAccountAge > 0 }, (*Signals).doesAuthorAccountAgeSignalMatch},
+	newListEvaluator("labels", func(s *Signals) SubSignal { return s.Labels }, (*Signals).doesLabelSignalMatch),
+	builtinEvaluator{"label_count", func(s *Signals) bool { return s.MinLabels > 0 || s.MaxLabels > 0 }, (*Signals).doesLabelCountSignalMatch},
+	builtinEvaluator{"prefixed_labels", func(s *Signals) bool { return s.MinPrefixedLabels > 0 }, (*Signals).doesPrefixedLabelSignalMatch},
+	builtinEvaluator{"require_queue_front", func(s *Signals) bool { return s.RequireQueueFront }, (*Signals).doesQueueSignalMatch},
+	newListEvaluator("comments", func(s *Signals) SubSignal { return s.Comments }, (*Signals).doesCommentSignalMatch),
+	newListEvaluator("comment_substrings", func(s *Signals) SubSignal { return s.CommentSubstrings }, (*Signals).doesCommentSubstringSignalMatch),
+	newListEvaluator("comment_patterns", func(s *Signals) SubSignal { return s.CommentPatterns }, (*Signals).doesCommentPatternSignalMatch),
+	builtinEvaluator{"min_reactions", func(s *Signals) bool { return s.MinReactions > 0 }, (*Signals).doesReactionSignalMatch},
+	builtinEvaluator{"require_reapproval_from", func(s *Signals) bool { return len(s.RequireReapprovalFrom) > 0 }, (*Signals).doesReapprovalSignalMatch},
+	builtinEvaluator{"min_participants", func(s *Signals) bool { return s.MinParticipants > 0 }, (*Signals).doesParticipantSignalMatch},
+	builtinEvaluator{"block_force_push_since_approval", func(s *Signals) bool { return s.BlockForcePushSinceApproval }, (*Signals).doesForcePushSignalMatch},
+	builtinEvaluator{"max_review_rounds", func(s *Signals) bool { return s.MaxReviewRounds > 0 }, (*Signals).doesReviewRoundSignalMatch},
+	builtinEvaluator{"unresponsive_reviewers", func(s *Signals) bool { return s.maxUnresponsiveReviewers() >= 0 }, (*Signals).doesUnresponsiveReviewerSignalMatch},
+	builtinEvaluator{"respect_required_approvals", func(s *Signals) bool { return s.RespectRequiredApprovals }, (*Signals).doesRequiredApprovalSignalMatch},
+	builtinEvaluator{"respect_conversation_resolution", func(s *Signals) bool { return s.RespectConversationResolution }, (*Signals).doesConversationResolutionSignalMatch},
+	builtinEvaluator{"approvals_excluding_author", func(s *Signals) bool { return s.ApprovalsExcludingAuthor > 0 }, (*Signals).doesIndependentApprovalSignalMatch},
+	builtinEvaluator{"require_approval_after_last_commit", func(s *Signals) bool { return s.RequireApprovalAfterLastCommit }, (*Signals).doesFreshApprovalSignalMatch},
+	builtinEvaluator{"respect_depends_on", func(s *Signals) bool { return s.RespectDependsOn }, (*Signals).doesDependsOnSignalMatch},
+	builtinEvaluator{"only_merge_stack_bottom", func(s *Signals) bool { return s.OnlyMergeStackBottom }, (*Signals).doesStackBottomSignalMatch},
+	builtinEvaluator{"closes_issues_with_labels", func(s *Signals) bool { return len(s.ClosesIssuesWithLabels) > 0 }, (*Signals).doesClosedIssueSignalMatch},
+	builtinEvaluator{"branch_issue_convention", func(s *Signals) bool { return s.BranchIssueConvention != nil }, (*Signals).doesBranchIssueSignalMatch},
+	builtinEvaluator{"require_rfc_reference", func(s *Signals) bool { return s.RequireRFCReference != nil }, (*Signals).doesRFCReferenceSignalMatch},
+	builtinEvaluator{"require_default_base_branch", func(s *Signals) bool { return s.RequireDefaultBaseBranch }, (*Signals).doesDefaultBaseSignalMatch},
+	builtinEvaluator{"repo_topics", func(s *Signals) bool { return len(s.RepoTopics) > 0 }, (*Signals).doesRepoTopicSignalMatch},
+	builtinEvaluator{"repo_properties", func(s *Signals) bool { return len(s.RepoProperties) > 0 }, (*Signals).doesRepoPropertySignalMatch},
+	builtinEvaluator{"require_protected_base", func(s *Signals) bool { return s.RequireProtectedBase }, (*Signals).doesProtectedBaseSignalMatch},
+	builtinEvaluator{"require_base_branch_exists", func(s *Signals) bool { return s.RequireBaseBranchExists }, (*Signals).doesBaseBranchExistSignalMatch},
+	builtinEvaluator{"min_checks", func(s *Signals) bool { return s.minChecks() > 0 }, (*Signals).doesCheckCountSignalMatch},
+	builtinEvaluator{"required_status_contexts_present", func(s *Signals) bool { return len(s.RequiredStatusContextsPresent) > 0 }, (*Signals).doesStatusContextSignalMatch},
+	builtinEvaluator{"max_check_age", func(s *Signals) bool { return s.MaxCheckAge > 0 }, (*Signals).doesCheckAgeSignalMatch},
+	builtinEvaluator{"hold_statuses", func(s *Signals) bool { return len(s.HoldStatuses) > 0 }, (*Signals).doesHoldStatusSignalMatch},
+	builtinEvaluator{"check_annotation_patterns", func(s *Signals) bool { return len(s.CheckAnnotationPatterns) > 0 }, (*Signals).doesCheckAnnotationSignalMatch},
+	builtinEvaluator{"min_patch_coverage", func(s *Signals) bool { return s.MinPatchCoverage > 0 }, (*Signals).doesCoverageSignalMatch},
+	builtinEvaluator{"required_workflows", func(s *Signals) bool { return len(s.RequiredWorkflows) > 0 }, (*Signals).doesWorkflowSignalMatch},
+	builtinEvaluator{"require_cla", func(s *Signals) bool { return s.RequireCLA != nil }, (*Signals).doesCLASignalMatch},
+	builtinEvaluator{"require_clean_without_admin", func(s *Signals) bool { return s.RequireCleanWithoutAdmin }, (*Signals).doesCleanWithoutAdminSignalMatch},
+	builtinEvaluator{"defer_to_native_auto_merge", func(s *Signals) bool { return s.DeferToNativeAutoMerge }, (*Signals).doesNativeAutoMergeSignalMatch},
+	builtinEvaluator{"max_merge_attempts", func(s *Signals) bool { return s.MaxMergeAttempts > 0 }, (*Signals).doesMergeAttemptsSignalMatch},
+	builtinEvaluator{"require_rebaseable", func(s *Signals) bool { return s.RequireRebaseable }, (*Signals).doesRebaseSignalMatch},
+	builtinEvaluator{"require_merge_method_compatible", func(s *Signals) bool { return s.RequireMergeMethodCompatible }, (*Signals).doesMergeMethodSignalMatch},
+	builtinEvaluator{"commits", func(s *Signals) bool { return len(s.CommitAuthors) > 0 || s.RequireVerifiedCommits }, (*Signals).doesCommitSignalMatch},
+	builtinEvaluator{"require_signed_commits", func(s *Signals) bool { return s.RequireSignedCommits }, (*Signals).doesSignedCommitSignalMatch},
+	builtinEvaluator{"ticket_trailer", func(s *Signals) bool { return s.TicketTrailer != nil }, (*Signals).doesTicketTrailerSignalMatch},
+	builtinEvaluator{"min_commit_authors", func(s *Signals) bool { return s.MinCommitAuthors > 0 }, (*Signals).doesCommitAuthorCountSignalMatch},
+	builtinEvaluator{"merge_commits", func(s *Signals) bool { return s.maxMergeCommits() >= 0 }, (*Signals).doesMergeCommitSignalMatch},
+	builtinEvaluator{"disallow_fixup_commits", func(s *Signals) bool { return s.DisallowFixupCommits }, (*Signals).doesFixupCommitSignalMatch},
+	builtinEvaluator{"binary_files", func(s *Signals) bool { return s.maxBinaryFiles() >= 0 }, (*Signals).doesBinaryFileSignalMatch},
+	builtinEvaluator{"max_added_file_bytes", func(s *Signals) bool { return s.MaxAddedFileBytes > 0 }, (*Signals).doesAddedFileSizeSignalMatch},
+	builtinEvaluator{"directories", func(s *Signals) bool { return s.MinDirectories > 0 || s.MaxDirectories > 0 }, (*Signals).doesDirectorySignalMatch},
+	builtinEvaluator{"path_file_counts", func(s *Signals) bool { return len(s.PathFileCounts) > 0 }, (*Signals).doesPathFileCountSignalMatch},
+	builtinEvaluator{"allowed_extensions", func(s *Signals) bool { return len(s.AllowedExtensions) > 0 }, (*Signals).doesExtensionSignalMatch},
+	builtinEvaluator{"require_test_changes", func(s *Signals) bool { return s.RequireTestChanges != nil }, (*Signals).doesTestChangeSignalMatch},
+	builtinEvaluator{"block_test_deletion", func(s *Signals) bool { return s.BlockTestDeletion }, (*Signals).doesTestDeletionSignalMatch},
+	builtinEvaluator{"protected_paths", func(s *Signals) bool { return len(s.ProtectedPaths) > 0 }, (*Signals).doesProtectedPathSignalMatch},
+	builtinEvaluator{"require_code_owners_requested", func(s *Signals) bool { return s.RequireCodeOwnersRequested }, (*Signals).doesCodeOwnersRequestedSignalMatch},
+	builtinEvaluator{"block_self_config_changes", func(s *Signals) bool { return s.BlockSelfConfigChanges }, (*Signals).doesSelfConfigSignalMatch},
+	newListEvaluator("environments", func(s *Signals) SubSignal { return s.Environments }, (*Signals).doesEnvironmentSignalMatch),
+	newListEvaluator("diff_patterns", func(s *Signals) SubSignal { return s.DiffPatterns }, (*Signals).doesDiffSignalMatch),
+	builtinEvaluator{"detect_conflict_markers", func(s *Signals) bool { return s.DetectConflictMarkers }, (*Signals).doesConflictMarkerSignalMatch},
+	builtinEvaluator{"block_new_dependencies", func(s *Signals) bool { return s.BlockNewDependencies }, (*Signals).doesNewDependencySignalMatch},
+	builtinEvaluator{"require_resolved_threads_on_changed_lines", func(s *Signals) bool { return s.RequireResolvedThreadsOnChangedLines }, (*Signals).doesResolvedThreadSignalMatch},
+}
+
+func init() {
+	// groups evaluate nested signals with the other evaluators, so referring
+	// to the helper in the declaration of evaluators is an initialization
+	// cycle; appending it here still puts it after the other built-ins
+	evaluators = append(evaluators, builtinEvaluator{"groups", func(s *Signals) bool { return len(s.Groups) > 0 }, (*Signals).doesGroupSignalMatch})
+}
+
+// Register adds an evaluator for a custom signal type. Registered evaluators
+// are evaluated after the built-in evaluators, in the order they were
+// registered, and apply to all signals. Register is not safe to call while
+// signals are evaluated and should be called during program initialization.
+func Register(evaluator SignalEvaluator) {
+	evaluators = append(evaluators, evaluator)
+}
+
+// MetricsRecorder observes the evaluation of signals. Observe is called once
+// for each signal type that applies to a pull request with the name of the
+// evaluator, whether the signal matched, how long the evaluation took, and
+// the error returned by the evaluation, if any.
+type MetricsRecorder interface {
+	Observe(signalType string, matched bool, d time.Duration, err error)
+}
+
+type noopMetricsRecorder struct{}
+
+func (noopMetricsRecorder) Observe(signalType string, matched bool, d time.Duration, err error) {}
+
+var metrics MetricsRecorder = noopMetricsRecorder{}
+
+// SetMetricsRecorder sets the recorder that observes signal evaluations. By
+// default, evaluations are not recorded. Passing nil restores the default.
+// Like Register, SetMetricsRecorder should be called during program
+// initialization.
+func SetMetricsRecorder(recorder MetricsRecorder) {
+	if recorder == nil {
+		recorder = noopMetricsRecorder{}
+	}
+	metrics = recorder
+}
+
+// TicketValidator reports whether a ticket referenced by a commit trailer is
+// open in an issue tracker. Tickets that do not exist are not open. It
+// returns an error if the tracker cannot be queried.
+type TicketValidator func(ctx context.Context, ticketID string) (open bool, err error)
+
+var ticketValidator TicketValidator
+
+// SetTicketValidator sets the function that checks the tickets referenced by
+// the trailers configured with TicketTrailer, which keeps the signals
+// independent of any particular issue tracker. Until a validator is set,
+// signals with TicketTrailer fail with an error. Like Register,
+// SetTicketValidator should be called during program initialization.
+func SetTicketValidator(validator TicketValidator) {
+	ticketValidator = validator
+}
+
+// evaluateSignal evaluates a single signal type, returning the result, its
+// description, and the 1-based position of the value that decided the
+// result, if any. Signal types that do not apply to the pull request are not
+// recorded by the metrics recorder.
+func evaluateSignal(ctx context.Context, e SignalEvaluator, s *Signals, pullCtx pull.Context, tag string) (signalResult, string, int, error) {
+	start := time.Now()
+	result, reason, index, err := runEvaluator(ctx, e, s, pullCtx, tag)
+
+	if result != signalNotFound || err != nil {
+		metrics.Observe(e.Name(), result == signalMatch, time.Since(start), err)
+	}
+	return result, reason, index, err
+}
+
+func runEvaluator(ctx context.Context, e SignalEvaluator, s *Signals, pullCtx pull.Context, tag string) (signalResult, string, int, error) {
+	if s.isDisabled(e.Name()) {
+		return signalNotFound, "", 0, nil
+	}
+
+	switch b := e.(type) {
+	case builtinEvaluator:
+		return b.match(s, ctx, pullCtx, tag)
+	case listEvaluator:
+		if !b.enabled(s) {
+			return signalNotFound, "", 0, nil
+		}
+		return b.match(s, ctx, pullCtx, tag)
+	}
+
+	if !e.Enabled(*s) {
+		return signalNotFound, "", 0, nil
+	}
+
+	matched, reason, err := e.Match(ctx, *s, pullCtx, tag)
+	switch {
+	case err != nil:
+		return signalNotMatch, reason, 0, err
+	case matched:
+		return signalMatch, reason, 0, nil
+	default:
+		return signalNotMatch, reason, 0, nil
+	}
+}
@@ -0,0 +1,377 @@
+// Copyright 2019 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bulldozer
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v32/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/palantir/bulldozer/pull"
+	"github.com/palantir/bulldozer/pull/pulltest"
+)
+
+// titleEvaluator is a custom signal that matches pull requests by title.
+type titleEvaluator struct {
+	title string
+}
+
+func (e titleEvaluator) Name() string {
+	return "title"
+}
+
+func (e titleEvaluator) Enabled(s Signals) bool {
+	return e.title != ""
+}
+
+func (e titleEvaluator) Match(ctx context.Context, s Signals, pullCtx pull.Context, tag string) (bool, string, error) {
+	if pullCtx.Title() == e.title {
+		return true, fmt.Sprintf("pull request has the %s title: %q", tag, e.title), nil
+	}
+	return false, fmt.Sprintf("pull request does not have the %s title: %q", tag, e.title), nil
+}
+
+func TestRegister(t *testing.T) {
+	defer func(registered []SignalEvaluator) {
+		evaluators = registered
+	}(evaluators)
+
+	Register(titleEvaluator{title: "Release 1.0"})
+
+	ctx := context.Background()
+
+	t.Run("enabledWithoutBuiltinSignals", func(t *testing.T) {
+		signals := Signals{}
+		assert.True(t, signals.Enabled())
+	})
+
+	t.Run("matchesOne", func(t *testing.T) {
+		signals := Signals{
+			Labels: SubSignal{Values: []string{"merge"}},
+		}
+		pc := &pulltest.MockPullContext{TitleValue: "Release 1.0"}
+
+		matches, reason, err := signals.Matches(ctx, pc, "testlist")
+		require.NoError(t, err)
+		assert.True(t, matches)
+		assert.Equal(t, `pull request has the testlist title: "Release 1.0"`, reason)
+	})
+
+	t.Run("matchesAll", func(t *testing.T) {
+		signals := Signals{
+			Match:  MatchAll,
+			Labels: SubSignal{Values: []string{"merge"}},
+		}
+		pc := &pulltest.MockPullContext{
+			TitleValue: "Release 2.0",
+			LabelValue: []string{"merge"},
+		}
+
+		matches, reason, err := signals.Matches(ctx, pc, "testlist")
+		require.NoError(t, err)
+		assert.False(t, matches)
+		assert.Equal(t, `pull request does not have the testlist title: "Release 1.0"`, reason)
+	})
+}
+
+func TestBuiltinEvaluatorsEnabled(t *testing.T) {
+	assert.False(t, (&Signals{}).Enabled())
+
+	for _, e := range evaluators {
+		e := e
+		t.Run(e.Name(), func(t *testing.T) {
+			assert.False(t, e.Enabled(Signals{}), "evaluator is enabled for empty signals")
+		})
+	}
+}
+
+// observation is a call to fakeRecorder.Observe.
+type observation struct {
+	signalType string
+	matched    bool
+	err        bool
+}
+
+type fakeRecorder struct {
+	observations []observation
+}
+
+func (r *fakeRecorder) Observe(signalType string, matched bool, d time.Duration, err error) {
+	r.observations = append(r.observations, observation{signalType, matched, err != nil})
+}
+
+func TestSetMetricsRecorder(t *testing.T) {
+	defer SetMetricsRecorder(nil)
+
+	ctx := context.Background()
+
+	t.Run("observesEvaluatedSignals", func(t *testing.T) {
+		recorder := &fakeRecorder{}
+		SetMetricsRecorder(recorder)
+
+		signals := Signals{
+			Match:          MatchAll,
+			Labels:         SubSignal{Values: []string{"merge"}},
+			Branches:       SubSignal{Values: []string{"develop"}},
+			BranchPrefixes: SubSignal{Values: []string{"release/"}},
+		}
+		pc := &pulltest.MockPullContext{
+			BranchBase: "develop",
+			LabelValue: []string{"merge"},
+		}
+
+		_, _, err := signals.Matches(ctx, pc, "testlist")
+		require.NoError(t, err)
+		assert.Equal(t, []observation{
+			{signalType: "branches", matched: true},
+			{signalType: "branch_prefixes", matched: false},
+		}, recorder.observations)
+	})
+
+	t.Run("observesErrors", func(t *testing.T) {
+		recorder := &fakeRecorder{}
+		SetMetricsRecorder(recorder)
+
+		signals := Signals{
+			Labels: SubSignal{Values: []string{"merge"}},
+		}
+		pc := &pulltest.MockPullContext{
+			LabelErrValue: fmt.Errorf("failed to list labels"),
+		}
+
+		_, _, err := signals.Matches(ctx, pc, "testlist")
+		require.Error(t, err)
+		assert.Equal(t, []observation{
+			{signalType: "labels", err: true},
+		}, recorder.observations)
+	})
+}
+
+// flakyPullContext fails to list labels with err, or an error response with
+// the given status code if err is nil, until it has been called failures
+// times.
+type flakyPullContext struct {
+	*pulltest.MockPullContext
+
+	err      error
+	status   int
+	failures int
+	calls    int
+}
+
+func (c *flakyPullContext) Labels(ctx context.Context) ([]string, error) {
+	c.calls++
+	if c.calls <= c.failures {
+		if c.err != nil {
+			return nil, c.err
+		}
+		return nil, &github.ErrorResponse{
+			Response: &http.Response{StatusCode: c.status},
+			Message:  http.StatusText(c.status),
+		}
+	}
+	return c.MockPullContext.Labels(ctx)
+}
+
+func TestSetRetryPolicy(t *testing.T) {
+	defer SetRetryPolicy(DefaultRetryPolicy)
+	defer func(s func(context.Context, time.Duration) error) { sleep = s }(sleep)
+
+	var waits []time.Duration
+	sleep = func(ctx context.Context, d time.Duration) error {
+		waits = append(waits, d)
+		return nil
+	}
+
+	ctx := context.Background()
+	signals := Signals{
+		Labels: SubSignal{Values: []string{"merge"}},
+	}
+
+	retryAfter := func(d time.Duration) *time.Duration { return &d }
+
+	tests := map[string]struct {
+		Policy   RetryPolicy
+		Err      error
+		Status   int
+		Failures int
+
+		Calls int
+		Waits []time.Duration
+		Error bool
+	}{
+		"retriesServerError": {
+			Policy:   RetryPolicy{Attempts: 3, Backoff: time.Second},
+			Status:   http.StatusBadGateway,
+			Failures: 1,
+			Calls:    2,
+			Waits:    []time.Duration{time.Second},
+		},
+		"exhaustsAttempts": {
+			Policy:   RetryPolicy{Attempts: 3, Backoff: time.Second},
+			Status:   http.StatusServiceUnavailable,
+			Failures: 5,
+			Calls:    3,
+			Waits:    []time.Duration{time.Second, 2 * time.Second},
+			Error:    true,
+		},
+		"permanentNotFound": {
+			Policy:   RetryPolicy{Attempts: 3, Backoff: time.Second},
+			Status:   http.StatusNotFound,
+			Failures: 1,
+			Calls:    1,
+			Error:    true,
+		},
+		"permanentForbidden": {
+			Policy:   RetryPolicy{Attempts: 3, Backoff: time.Second},
+			Status:   http.StatusForbidden,
+			Failures: 1,
+			Calls:    1,
+			Error:    true,
+		},
+		"primaryRateLimit": {
+			Policy:   RetryPolicy{Attempts: 3, Backoff: time.Second},
+			Err:      &github.RateLimitError{Message: "API rate limit exceeded"},
+			Failures: 1,
+			Calls:    1,
+			Error:    true,
+		},
+		"secondaryRateLimitRetryAfter": {
+			Policy:   RetryPolicy{Attempts: 3, Backoff: time.Second},
+			Err:      &github.AbuseRateLimitError{RetryAfter: retryAfter(30 * time.Second)},
+			Failures: 1,
+			Calls:    2,
+			Waits:    []time.Duration{30 * time.Second},
+		},
+		"secondaryRateLimitLongRetryAfter": {
+			Policy:   RetryPolicy{Attempts: 3, Backoff: time.Second},
+			Err:      &github.AbuseRateLimitError{RetryAfter: retryAfter(time.Hour)},
+			Failures: 1,
+			Calls:    1,
+			Error:    true,
+		},
+		"secondaryRateLimitBackoff": {
+			Policy:   RetryPolicy{Attempts: 3, Backoff: time.Second},
+			Err:      &github.AbuseRateLimitError{},
+			Failures: 2,
+			Calls:    3,
+			Waits:    []time.Duration{time.Second, 2 * time.Second},
+		},
+		"defaultPolicy": {
+			Policy:   DefaultRetryPolicy,
+			Status:   http.StatusBadGateway,
+			Failures: 1,
+			Calls:    1,
+			Error:    true,
+		},
+		"disabled": {
+			Policy:   RetryPolicy{Attempts: 1},
+			Status:   http.StatusBadGateway,
+			Failures: 1,
+			Calls:    1,
+			Error:    true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			waits = nil
+			SetRetryPolicy(test.Policy)
+
+			pc := &flakyPullContext{
+				MockPullContext: &pulltest.MockPullContext{LabelValue: []string{"merge"}},
+				err:             test.Err,
+				status:          test.Status,
+				failures:        test.Failures,
+			}
+
+			matches, _, err := signals.Matches(ctx, pc, "testlist")
+			if test.Error {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				assert.True(t, matches)
+			}
+			assert.Equal(t, test.Calls, pc.calls)
+			assert.Equal(t, test.Waits, waits)
+		})
+	}
+}
+
+// partlyFlakyPullContext lists commits successfully, but fails to list
+// reviews with a server error the first time.
+type partlyFlakyPullContext struct {
+	*pulltest.MockPullContext
+
+	commitCalls int
+	reviewCalls int
+}
+
+func (c *partlyFlakyPullContext) Commits(ctx context.Context) ([]*pull.Commit, error) {
+	c.commitCalls++
+	return c.MockPullContext.Commits(ctx)
+}
+
+func (c *partlyFlakyPullContext) Reviews(ctx context.Context) ([]*pull.Review, error) {
+	c.reviewCalls++
+	if c.reviewCalls == 1 {
+		return nil, &github.ErrorResponse{
+			Response: &http.Response{StatusCode: http.StatusBadGateway},
+			Message:  http.StatusText(http.StatusBadGateway),
+		}
+	}
+	return c.MockPullContext.Reviews(ctx)
+}
+
+func TestRetryPolicyRetriesOnlyFailedAccessor(t *testing.T) {
+	defer SetRetryPolicy(DefaultRetryPolicy)
+	defer func(s func(context.Context, time.Duration) error) { sleep = s }(sleep)
+
+	var waits []time.Duration
+	sleep = func(ctx context.Context, d time.Duration) error {
+		waits = append(waits, d)
+		return nil
+	}
+	SetRetryPolicy(RetryPolicy{Attempts: 3, Backoff: time.Second})
+
+	signals := Signals{
+		ApprovalsExcludingAuthor: 1,
+		ExcludeCoAuthorApprovals: true,
+	}
+	pc := &partlyFlakyPullContext{
+		MockPullContext: &pulltest.MockPullContext{
+			CreatorValue: "alice",
+			CommitsValue: []*pull.Commit{
+				{SHA: "a", Author: pull.CommitIdentity{Login: "alice"}},
+			},
+			ReviewsValue: []*pull.Review{
+				{Author: "bob", State: "APPROVED"},
+			},
+		},
+	}
+
+	matches, _, err := signals.Matches(context.Background(), pc, "testlist")
+	require.NoError(t, err)
+	assert.True(t, matches)
+	assert.Equal(t, 1, pc.commitCalls, "commits were listed again when reviews were retried")
+	assert.Equal(t, 2, pc.reviewCalls)
+	assert.Equal(t, []time.Duration{time.Second}, waits)
+}
@@ -17,114 +17,4547 @@ package bulldozer
 import (
 	"context"
 	"fmt"
+	"math"
+	"path"
 	"regexp"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
+	"unicode/utf8"
 
+	"github.com/pkg/errors"
 	"github.com/rs/zerolog"
 
 	"github.com/palantir/bulldozer/pull"
 )
 
+// MatchType determines how the values of a signal, or the signals in a set
+// of signals, are combined to decide if a pull request matches.
+type MatchType string
+
+const (
+	// MatchOne matches if any value or signal matches. It is the default.
+	MatchOne MatchType = "one"
+
+	// MatchAll matches if every configured value or signal matches.
+	MatchAll MatchType = "all"
+
+	// MatchScore matches if the weights of the signals that match add up to
+	// at least a threshold. It only applies to sets of signals; the values
+	// of a signal are combined as with MatchOne.
+	MatchScore MatchType = "score"
+)
+
+// String returns the name of the match type. The empty match type is the
+// default, MatchOne.
+func (m MatchType) String() string {
+	if m == "" {
+		return string(MatchOne)
+	}
+	return string(m)
+}
+
+// MarshalYAML writes the match type as a plain string, so that marshaled
+// signals unmarshal to the same value.
+func (m MatchType) MarshalYAML() (interface{}, error) {
+	return string(m), nil
+}
+
+// UnmarshalYAML rejects match types other than those listed in MatchTypes,
+// so that a typo in the configuration is reported instead of silently
+// using the default. An empty value leaves the default in place.
+func (m *MatchType) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var value string
+	if err := unmarshal(&value); err != nil {
+		return err
+	}
+
+	if value == "" {
+		*m = ""
+		return nil
+	}
+
+	valid := make([]string, len(MatchTypes))
+	for i, t := range MatchTypes {
+		if value == string(t) {
+			*m = t
+			return nil
+		}
+		valid[i] = strconv.Quote(string(t))
+	}
+	return errors.Errorf("invalid match type %q: must be one of %s", value, strings.Join(valid, ", "))
+}
+
+// SubSignal is the list of values for a single signal type. If Match is set,
+// it overrides the match type of the enclosing Signals for these values.
+type SubSignal struct {
+	Values []string  `yaml:"values"`
+	Match  MatchType `yaml:"match"`
+
+	// LabelsAfterLastCommit only applies to labels. If set, a label matches
+	// only if it was most recently added after the last commit on the pull
+	// request, so that labels left over from before new commits are pushed
+	// do not count.
+	LabelsAfterLastCommit bool `yaml:"labels_after_last_commit"`
+
+	// CaseSensitive only applies to labels. If set, labels only match if
+	// their case is the same, so "WIP" and "wip" are different labels.
+	CaseSensitive bool `yaml:"case_sensitive"`
+
+	// Required only applies when signals are matched with MatchOne. If set,
+	// the values must match in addition to any one of the other signals.
+	Required bool `yaml:"required"`
+
+	// Enabled turns the signal off if it is set to false, so that the values
+	// can stay in the configuration while the signal is not used. A
+	// disabled signal is treated as if it had no values.
+	Enabled *bool `yaml:"enabled"`
+}
+
+// isEnabled returns true if the signal has values and is not disabled.
+func (ss SubSignal) isEnabled() bool {
+	return len(ss.Values) > 0 && (ss.Enabled == nil || *ss.Enabled)
+}
+
+// UnmarshalYAML accepts either a list of values or a mapping with "values"
+// and "match" keys, so that existing configurations using lists continue to
+// work.
+func (ss *SubSignal) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var values []string
+	if err := unmarshal(&values); err == nil {
+		*ss = SubSignal{Values: values}
+		return nil
+	}
+
+	type rawSubSignal SubSignal
+	var raw rawSubSignal
+	if err := unmarshal(&raw); err != nil {
+		return err
+	}
+	*ss = SubSignal(raw)
+	return nil
+}
+
 type Signals struct {
-	Labels            []string `yaml:"labels"`
-	CommentSubstrings []string `yaml:"comment_substrings"`
-	Comments          []string `yaml:"comments"`
-	PRBodySubstrings  []string `yaml:"pr_body_substrings"`
-	Branches          []string `yaml:"branches"`
-	BranchPatterns    []string `yaml:"branch_patterns"`
+	Match MatchType `yaml:"match"`
+
+	// Weights and Threshold configure signals matched with MatchScore.
+	// Weights maps the name of a signal type, like "labels", to the score
+	// it contributes when it matches; signal types without a weight
+	// contribute 1. The pull request matches if its score is at least
+	// Threshold, or at least 1 if Threshold is not positive.
+	Weights   map[string]int `yaml:"weights"`
+	Threshold int            `yaml:"threshold"`
+
+	// Invert flips the result of the signals after they are combined by
+	// Match, so that a pull request matches if it does not meet the signals.
+	// The description still explains how the signals were evaluated.
+	Invert bool `yaml:"invert"`
+
+	// Disabled lists the names of signal types, like "require_cla" or
+	// "max_check_age", that are not evaluated even if they are configured.
+	// Unlike the "enabled" key of lists of values, it turns off any signal
+	// type, including those configured by a single value. Unknown names are
+	// an error.
+	Disabled []string `yaml:"disabled"`
+
+	// OverrideLabels are break-glass labels: if the pull request has any of
+	// them, the signals match without evaluating anything else, regardless
+	// of Match and Invert. If OverrideLabelActors is set, a label only
+	// counts if one of these users added it most recently.
+	OverrideLabels      []string `yaml:"override_labels"`
+	OverrideLabelActors []string `yaml:"override_label_actors"`
+
+	// BlockedCreators and BlockedCreatorPatterns deny pull requests opened by
+	// these users, or by users whose login matches one of the regular
+	// expressions: the signals never match these pull requests, regardless
+	// of the other signals, Match, Invert, and OverrideLabels. Logins are
+	// compared without regard to case, and patterns must match the whole
+	// login. They are intended for trigger signals, where a blocked author
+	// is never merged or updated.
+	BlockedCreators        []string `yaml:"blocked_creators"`
+	BlockedCreatorPatterns []string `yaml:"blocked_creator_patterns"`
+
+	// ReportAllReasons changes how a pull request that does not meet every
+	// signal is described when Match is MatchAll. If set, all signals are
+	// evaluated and the description lists every signal that is not met,
+	// instead of only the first one. The result is the same either way.
+	ReportAllReasons bool `yaml:"report_all_reasons"`
+
+	// MarkdownReasons sets the MarkdownReason of results to a description
+	// formatted as GitHub-flavored markdown, suitable for posting on the pull
+	// request. The plain Reason is always set.
+	MarkdownReasons bool `yaml:"markdown_reasons"`
+
+	// Exhaustive evaluates every signal even after the result is decided, so
+	// that the Details of the result returned by Evaluate describe the
+	// outcome of each configured signal. The result and its description are
+	// the same either way, but more API requests may be made.
+	Exhaustive bool `yaml:"exhaustive"`
+
+	// Normalize enables Unicode normalization when comparing titles, labels,
+	// comments, body and comment substrings, and repository property values
+	// with the configured values, so that equivalent text written in
+	// different forms matches. Text is converted to Normalization Form C
+	// after folding full-width and half-width characters.
+	Normalize bool `yaml:"normalize"`
+
+	// FoldCase ignores case when comparing comments, body and comment
+	// substrings, and repository property values with the configured values.
+	// Labels always ignore case.
+	FoldCase bool `yaml:"fold_case"`
+
+	// WordBoundary requires body and comment substrings to match at word
+	// boundaries, so that "merge" does not match "mergeable". Substrings
+	// that start or end with punctuation are not restricted at that end.
+	WordBoundary bool `yaml:"word_boundary"`
+
+	// Extends lists the names of signal fragments merged into these signals
+	// when the configuration is loaded. See ResolveExtends.
+	Extends []string `yaml:"extends"`
+
+	// Groups are named sets of signals that together count as a single
+	// signal type, which matches if the pull request meets any group. Each
+	// group combines its signals with its own Match, so a condition like
+	// "opened by dependabot and targeting main" can require both parts
+	// without applying "match: all" to every other signal.
+	Groups map[string]Signals `yaml:"groups"`
+
+	Labels            SubSignal `yaml:"labels"`
+	CommentSubstrings SubSignal `yaml:"comment_substrings"`
+	Comments          SubSignal `yaml:"comments"`
+	CommentPatterns   SubSignal `yaml:"comment_patterns"`
+
+	// UseOriginalCommentBody matches the comment signals against the body
+	// comments were created with instead of their latest body, so editing
+	// an old comment cannot add a trigger phrase. Edited comments whose
+	// original body is not available are skipped.
+	UseOriginalCommentBody bool `yaml:"use_original_comment_body"`
+
+	// OnlyAuthorComments restricts the comment signals to comments written
+	// by the user who opened the pull request.
+	OnlyAuthorComments bool      `yaml:"only_author_comments"`
+	PRBodySubstrings   SubSignal `yaml:"pr_body_substrings"`
+	Branches           SubSignal `yaml:"branches"`
+	BranchPatterns     SubSignal `yaml:"branch_patterns"`
+	BranchPrefixes     SubSignal `yaml:"branch_prefixes"`
+	BranchSuffixes     SubSignal `yaml:"branch_suffixes"`
+
+	// ProtectedHeadBranches and ProtectedHeadBranchPatterns match pull
+	// requests from shared, long-lived branches in the repository, like
+	// "develop", that should not be merged and deleted automatically. They
+	// are usually used to ignore pull requests. Patterns are regular
+	// expressions matched against the whole branch name. Head branches in
+	// forks never match.
+	ProtectedHeadBranches       []string `yaml:"protected_head_branches"`
+	ProtectedHeadBranchPatterns []string `yaml:"protected_head_branch_patterns"`
+
+	// CommentsAfterMarker restricts the comment signals to comments posted
+	// after the first comment that contains this substring, such as a bot
+	// announcing that it accepts commands. The body is not matched when this
+	// is set, since it is written before any comment.
+	CommentsAfterMarker string `yaml:"comments_after_marker"`
+
+	// TitleMaxLength and TitleRequiredPattern check the pull request title,
+	// which is used to generate the commit message of squash merges.
+	TitleMaxLength       int    `yaml:"title_max_length"`
+	TitleRequiredPattern string `yaml:"title_required_pattern"`
+
+	// MinBodyLength and MaxBodyLength bound the length of the pull request
+	// body, in characters. A zero bound is not checked.
+	MinBodyLength int `yaml:"min_body_length"`
+	MaxBodyLength int `yaml:"max_body_length"`
+
+	// BodyHeaders matches pull requests whose body starts with a header
+	// block, like "Risk: low", where any of the headers has the configured
+	// value. Values are regular expressions that must match the whole header
+	// value, ignoring case, so plain values match exactly.
+	BodyHeaders map[string]string `yaml:"body_headers"`
+
+	// RequireCompletedChecklist matches pull requests where every markdown
+	// task list item in the body, like "- [ ] tests added", is checked.
+	// Items in fenced code blocks are ignored.
+	RequireCompletedChecklist bool `yaml:"require_completed_checklist"`
+
+	// MatchReverts matches pull requests that revert a previous commit,
+	// detected by matching RevertPatterns, or DefaultRevertPatterns if it is
+	// empty, against the title and body. If a pattern has a named group
+	// "sha", the reverted commit it captures is reported with the match.
+	MatchReverts   bool     `yaml:"match_reverts"`
+	RevertPatterns []string `yaml:"revert_patterns"`
+
+	// MinOpenDuration matches pull requests that have been open for at least
+	// this long, like "30m", to leave time for review.
+	MinOpenDuration time.Duration `yaml:"min_open_duration"`
+
+	// RequireReturningContributor matches pull requests whose author has
+	// contributed to the repository before, like MinAuthorAssociation set to
+	// "CONTRIBUTOR". It is usually used to avoid merging pull requests from
+	// first-time contributors.
+	RequireReturningContributor bool `yaml:"require_returning_contributor"`
+
+	// MinAuthorAssociation matches pull requests whose author has at least
+	// this association with the repository, like "COLLABORATOR", ranked as
+	// in authorAssociationTiers. Authors with an unknown association never
+	// match.
+	MinAuthorAssociation string `yaml:"min_author_association"`
+
+	// MinAuthorContributions matches pull requests whose author has opened
+	// at least this many pull requests that were merged into the
+	// repository.
+	MinAuthorContributions int `yaml:"min_author_contributions"`
+
+	// MinAuthorAccountAge matches pull requests whose author's account was
+	// created at least this long ago, like "720h". It is usually used to
+	// avoid merging pull requests from newly created accounts.
+	MinAuthorAccountAge time.Duration `yaml:"min_author_account_age"`
+
+	// Creators matches pull requests opened by any of these users. Logins
+	// are compared without regard to case. A pull request has a single
+	// author, so unlike other lists the values match with MatchOne unless
+	// the Match of the SubSignal is set, regardless of the enclosing Match.
+	Creators SubSignal `yaml:"creators"`
+
+	// CreatorIsBot matches pull requests opened by a bot account, like a
+	// GitHub App, if true, or by any other account if false. If nil, the
+	// account type of the author is not considered.
+	CreatorIsBot *bool `yaml:"creator_is_bot"`
+
+	MinLabels int `yaml:"min_labels"`
+	MaxLabels int `yaml:"max_labels"`
+
+	LabelPrefix       string `yaml:"label_prefix"`
+	MinPrefixedLabels int    `yaml:"min_prefixed_labels"`
+
+	MinReactions     int      `yaml:"min_reactions"`
+	ReactionType     string   `yaml:"reaction_type"`
+	ReactionComments []string `yaml:"reaction_comments"`
+
+	// ReactionUsers restricts MinReactions to reactions on the pull request
+	// body from these users, so reactions from anyone else do not count
+	// toward the minimum. GitHub does not report who reacted to comments,
+	// so it cannot be combined with ReactionComments.
+	ReactionUsers []string `yaml:"reaction_users"`
+
+	RequireQueueFront bool   `yaml:"require_queue_front"`
+	QueueLabelPrefix  string `yaml:"queue_label_prefix"`
+
+	RequireReapprovalFrom []string `yaml:"require_reapproval_from"`
+
+	// MinParticipants matches pull requests with at least this many distinct
+	// users who commented on or reviewed the pull request, including the
+	// author. If ExcludeBotParticipants is set, GitHub Apps are not counted.
+	MinParticipants        int  `yaml:"min_participants"`
+	ExcludeBotParticipants bool `yaml:"exclude_bot_participants"`
+
+	// BlockForcePushSinceApproval matches pull requests whose head branch
+	// was force-pushed after the latest approval, which may replace the
+	// reviewed commits. It is usually used to ignore pull requests.
+	BlockForcePushSinceApproval bool `yaml:"block_force_push_since_approval"`
+
+	// MaxReviewRounds matches pull requests with at most this many review
+	// rounds. A round is one or more requests for changes followed by a
+	// later approval, from any reviewers. See reviewRounds.
+	MaxReviewRounds int `yaml:"max_review_rounds"`
+
+	RequireReviewersResponded bool `yaml:"require_reviewers_responded"`
+	MaxUnresponsiveReviewers  int  `yaml:"max_unresponsive_reviewers"`
+	RespectRequiredApprovals  bool `yaml:"respect_required_approvals"`
+
+	// RespectConversationResolution matches pull requests without
+	// unresolved review threads if the branch protection of the target
+	// branch requires conversations to be resolved. It has no effect on
+	// other pull requests.
+	RespectConversationResolution bool `yaml:"respect_conversation_resolution"`
+
+	ApprovalsExcludingAuthor int  `yaml:"approvals_excluding_author"`
+	ExcludeCoAuthorApprovals bool `yaml:"exclude_co_author_approvals"`
+
+	RequireApprovalAfterLastCommit bool `yaml:"require_approval_after_last_commit"`
+
+	RespectDependsOn bool `yaml:"respect_depends_on"`
+
+	// OnlyMergeStackBottom requires the pull request to be at the bottom of
+	// a stack of pull requests: its target branch must not be the head
+	// branch of another open pull request in the same repository.
+	OnlyMergeStackBottom bool `yaml:"only_merge_stack_bottom"`
+
+	ClosesIssuesWithLabels []string `yaml:"closes_issues_with_labels"`
+
+	BranchIssueConvention *BranchIssueConvention `yaml:"branch_issue_convention"`
+
+	// RequireRFCReference requires the title or body of the pull request to
+	// reference an RFC or design discussion.
+	RequireRFCReference *RFCReference `yaml:"require_rfc_reference"`
+
+	// RepoTopics and RepoProperties match pull requests in repositories with
+	// any of the topics or any of the custom property values, so that
+	// organizations can opt repositories in centrally.
+	RepoTopics     []string          `yaml:"repo_topics"`
+	RepoProperties map[string]string `yaml:"repo_properties"`
+
+	RequireProtectedBase     bool `yaml:"require_protected_base"`
+	RequireBaseBranchExists  bool `yaml:"require_base_branch_exists"`
+	RequireDefaultBaseBranch bool `yaml:"require_default_base_branch"`
+	RequireChecksPresent     bool `yaml:"require_checks_present"`
+	MinChecks                int  `yaml:"min_checks"`
+
+	RequiredStatusContextsPresent []string `yaml:"required_status_contexts_present"`
+
+	// MaxCheckAge matches pull requests where the required status checks of
+	// the target branch, or all checks on the head commit if none are
+	// required, last succeeded at most this long ago.
+	MaxCheckAge time.Duration `yaml:"max_check_age"`
+
+	// HoldStatuses lists commit status contexts or check run names that
+	// hold a pull request while they are reported and not successful. They
+	// are usually used to ignore pull requests, independent of the
+	// required statuses of the target branch.
+	HoldStatuses []string `yaml:"hold_statuses"`
+
+	// CheckAnnotationPatterns maps check run names to regular expressions
+	// matched against the annotations and summary reported by those check
+	// runs, like "[1-9][0-9]* high-severity findings". They are usually used
+	// to ignore pull requests that pass a check with actionable findings.
+	CheckAnnotationPatterns map[string][]string `yaml:"check_annotation_patterns"`
+
+	// MinPatchCoverage matches pull requests where the coverage percentage
+	// in the description of the CoverageStatus status, "codecov/patch" by
+	// default, is at least this value.
+	MinPatchCoverage float64 `yaml:"min_patch_coverage"`
+	CoverageStatus   string  `yaml:"coverage_status"`
+
+	// RequiredWorkflows lists GitHub Actions workflows, by name, whose
+	// latest run for the head commit must be successful.
+	RequiredWorkflows []string `yaml:"required_workflows"`
+
+	// RequireCLA matches pull requests whose author signed the contributor
+	// license agreement, as reported by the status or label of the CLA bot.
+	RequireCLA *CLACheck `yaml:"require_cla"`
+
+	// RequireCleanWithoutAdmin matches pull requests that meet the branch
+	// protection requirements of the target branch, like required statuses
+	// and approvals, without an administrator override.
+	RequireCleanWithoutAdmin bool `yaml:"require_clean_without_admin"`
+
+	// DeferToNativeAutoMerge matches pull requests with GitHub's native
+	// auto-merge enabled. It is usually used to ignore pull requests that
+	// GitHub will merge on its own.
+	DeferToNativeAutoMerge bool `yaml:"defer_to_native_auto_merge"`
+
+	// MaxMergeAttempts matches pull requests with fewer consecutive
+	// unexpected merge failures for the head commit than this value. The
+	// failures are recorded in a hidden comment on the pull request.
+	MaxMergeAttempts int `yaml:"max_merge_attempts"`
+
+	// RequireRebaseable matches pull requests that can be merged without a
+	// merge commit by rebasing them onto the target branch.
+	RequireRebaseable bool `yaml:"require_rebaseable"`
+
+	// RequireMergeMethodCompatible requires that the pull request can be
+	// merged with at least one merge method the repository allows, like a
+	// rebase in repositories that only allow rebase merges.
+	RequireMergeMethodCompatible bool `yaml:"require_merge_method_compatible"`
+
+	CommitAuthors          []string `yaml:"commit_authors"`
+	RequireVerifiedCommits bool     `yaml:"require_verified_commits"`
+
+	// DisallowFixupCommits matches pull requests without commits created by
+	// "git commit --fixup" or "--squash", whose subjects start with
+	// "fixup!" or "squash!". Pull requests merged with the squash method
+	// always match, since their commits are combined anyway.
+	DisallowFixupCommits bool `yaml:"disallow_fixup_commits"`
+
+	// RequireSignedCommits requires every commit on the pull request to have
+	// a signature that GitHub verified. Unlike RequireVerifiedCommits, it is
+	// a standalone signal that reports why each commit failed verification.
+	RequireSignedCommits bool `yaml:"require_signed_commits"`
+
+	// TicketTrailer requires the commits on the pull request to reference
+	// open tickets in an issue tracker with a trailer, like "Ticket: ABC-1".
+	// Tickets are checked by the function passed to SetTicketValidator.
+	TicketTrailer *TicketTrailer `yaml:"ticket_trailer"`
+
+	// MinCommitAuthors requires the commits on the pull request to have at
+	// least this many distinct authors, including co-authors credited with
+	// "Co-authored-by" trailers, as evidence of pairing.
+	MinCommitAuthors int `yaml:"min_commit_authors"`
+
+	DisallowMergeCommits bool `yaml:"disallow_merge_commits"`
+	MaxMergeCommits      int  `yaml:"max_merge_commits"`
+
+	DisallowBinaryChanges bool `yaml:"disallow_binary_changes"`
+	MaxBinaryFiles        int  `yaml:"max_binary_files"`
+
+	MaxAddedFileBytes int64 `yaml:"max_added_file_bytes"`
+	EstimateFileSizes bool  `yaml:"estimate_file_sizes"`
+
+	MinDirectories int `yaml:"min_directories"`
+	MaxDirectories int `yaml:"max_directories"`
+	DirectoryDepth int `yaml:"directory_depth"`
+
+	// PathFileCounts maps a path prefix or glob pattern to the maximum number
+	// of changed files that may match it. Keys containing "*", "?", or "["
+	// are glob patterns matched against the full path of each file.
+	PathFileCounts map[string]int `yaml:"path_file_counts"`
+
+	// AllowedExtensions matches pull requests that only change files with
+	// one of these extensions, with or without the leading dot, ignoring
+	// case. Files without an extension, like "Makefile" or ".gitignore",
+	// only match if AllowExtensionlessFiles is set.
+	AllowedExtensions       []string `yaml:"allowed_extensions"`
+	AllowExtensionlessFiles bool     `yaml:"allow_extensionless_files"`
+
+	RequireTestChanges *TestChanges `yaml:"require_test_changes"`
+
+	// BlockTestDeletion matches pull requests that remove more test files
+	// than they add, where test files are those matching TestDeletionPaths,
+	// or DefaultTestDeletionPaths if it is empty. Renaming a test file to a
+	// path that is not a test removes it. Pull requests with one of
+	// TestDeletionExemptLabels do not match. It is usually used to ignore
+	// pull requests so that deleted tests are reviewed by a person.
+	BlockTestDeletion        bool     `yaml:"block_test_deletion"`
+	TestDeletionPaths        []string `yaml:"test_deletion_paths"`
+	TestDeletionExemptLabels []string `yaml:"test_deletion_exempt_labels"`
+
+	// BlockSelfConfigChanges matches pull requests that change the bulldozer
+	// configuration file at SelfConfigPath, or DefaultSelfConfigPath if it is
+	// empty. It is usually used to ignore pull requests so that changes to
+	// the configuration are reviewed and merged by a person.
+	BlockSelfConfigChanges bool   `yaml:"block_self_config_changes"`
+	SelfConfigPath         string `yaml:"self_config_path"`
+
+	// ProtectedPaths lists path prefixes or glob patterns owned by the teams
+	// in ApproverTeams. The signal matches pull requests that change a
+	// protected path without an approval from a member of one of the teams,
+	// so it is usually used to ignore pull requests.
+	ProtectedPaths []string `yaml:"protected_paths"`
+	ApproverTeams  []string `yaml:"approver_teams"`
+
+	// RequireCodeOwnersRequested matches pull requests where every code
+	// owner of the changed files, according to the CODEOWNERS file of the
+	// base branch, is requested to review. Owners identified by email
+	// address are not checked.
+	RequireCodeOwnersRequested bool `yaml:"require_code_owners_requested"`
+
+	Environments     SubSignal `yaml:"environments"`
+	EnvironmentState string    `yaml:"environment_state"`
+
+	DiffPatterns       SubSignal `yaml:"diff_patterns"`
+	DiffAddedLinesOnly bool      `yaml:"diff_added_lines_only"`
+	MaxDiffBytes       int       `yaml:"max_diff_bytes"`
+
+	// DetectConflictMarkers matches pull requests that add merge conflict
+	// markers to a file. It is usually used to ignore pull requests that
+	// commit an unresolved conflict by mistake.
+	DetectConflictMarkers bool `yaml:"detect_conflict_markers"`
+
+	// BlockNewDependencies matches pull requests that add a dependency to
+	// one of DependencyManifests, or DefaultDependencyManifests if it is
+	// empty, other than the dependencies in AllowedDependencies. It is
+	// usually used to ignore pull requests that need a security review.
+	// Changing the version of an existing dependency does not match.
+	BlockNewDependencies bool     `yaml:"block_new_dependencies"`
+	DependencyManifests  []string `yaml:"dependency_manifests"`
+	AllowedDependencies  []string `yaml:"allowed_dependencies"`
+
+	// RequireResolvedThreadsOnChangedLines matches pull requests without
+	// unresolved review threads on lines added or removed by the pull
+	// request. Unresolved threads on context lines and outdated threads do
+	// not prevent a match. Like DiffPatterns, diffs larger than MaxDiffBytes
+	// produce an error.
+	RequireResolvedThreadsOnChangedLines bool `yaml:"require_resolved_threads_on_changed_lines"`
 }
 
+// DefaultMaxDiffBytes is the size of the largest diff that is matched against
+// diff patterns if the signals do not set a different limit.
+const DefaultMaxDiffBytes = 1024 * 1024
+
+// Enabled returns true if any registered evaluator is enabled for the
+// signals.
 func (s *Signals) Enabled() bool {
-	size := 0
-	size += len(s.Labels)
-	size += len(s.CommentSubstrings)
-	size += len(s.Comments)
-	size += len(s.PRBodySubstrings)
-	size += len(s.Branches)
-	size += len(s.BranchPatterns)
-	return size > 0
-}
-
-// Matches returns true if the pull request meets one or more signals. It also
-// returns a description of the signal that was met. The tag argument appears
-// in this description and indicates the behavior (trigger, ignore) this
-// set of signals is associated with.
+	if len(s.OverrideLabels) > 0 {
+		return true
+	}
+	for _, e := range evaluators {
+		if e.Enabled(*s) && !s.isDisabled(e.Name()) {
+			return true
+		}
+	}
+	return false
+}
+
+// isDisabled returns true if Disabled turns off the named signal type.
+func (s *Signals) isDisabled(signalType string) bool {
+	for _, name := range s.Disabled {
+		if name == signalType {
+			return true
+		}
+	}
+	return false
+}
+
+// checkDisabled returns an error if Disabled names a signal type that is
+// not registered.
+func (s *Signals) checkDisabled(tag string) (string, error) {
+	for _, name := range s.Disabled {
+		known := false
+		for _, e := range evaluators {
+			if e.Name() == name {
+				known = true
+				break
+			}
+		}
+		if !known {
+			return fmt.Sprintf("invalid %s disabled signal: %q", tag, name), errors.Errorf("unknown signal type %q", name)
+		}
+	}
+	return "", nil
+}
+
+// signalResult is the outcome of evaluating a single signal type.
+type signalResult int
+
+const (
+	// signalNotFound means the signal is not configured and does not
+	// contribute to the overall result.
+	signalNotFound signalResult = iota
+	signalNotMatch
+	signalMatch
+)
+
+// Matches returns true if the pull request meets the signals. It also returns
+// a description of the signals that were met or, if the pull request does
+// not match, a description of why. The tag argument appears in this
+// description and indicates the behavior (trigger, ignore) this set of
+// signals is associated with.
+//
+// If Match is MatchAll, the pull request must meet every configured signal.
+// If Match is MatchScore, the weights of the signals it meets must add up to
+// the threshold. Otherwise, the pull request must meet at least one
+// configured signal. If Invert is set, the result is the opposite. If the
+// pull request was opened by one of BlockedCreators, it never matches;
+// otherwise, if it has one of OverrideLabels, it matches without evaluating
+// any other signal. Signals that configure more values than the limit set
+// with SetMaxEvaluatedValues are not evaluated and return an error.
+//
+// Signals are evaluated in a fixed order that does not depend on the order of
+// keys in the configuration. Signals that only use data already present on the
+// pull request (the body, the title, the time it was opened, the target and
+// head branches, and the author's login, account type, and association with the
+// repository) are evaluated before signals that require additional API requests
+// (the author's merged pull requests and account age, labels, comments,
+// reactions, reviews, timeline events, dependencies, stacked
+// pull requests, closed issues, discussions, the default branch, repository
+// metadata, branch protection, status checks, workflow runs, native auto-merge,
+// merge attempts, mergeability, commits, changed files, code owners, team
+// membership, deployments, and the diff), so a result decided by local data
+// never makes network calls. Groups are evaluated after the other built-in
+// signals, and signal types added with Register are evaluated last. The first
+// signal in this order that decides the result determines the returned
+// description.
 func (s *Signals) Matches(ctx context.Context, pullCtx pull.Context, tag string) (bool, string, error) {
-	logger := zerolog.Ctx(ctx)
+	result, err := s.evaluate(ctx, withRetries(pullCtx), tag)
+	return result.Matches, result.Reason, err
+}
 
-	labels, err := pullCtx.Labels(ctx)
+// MatchResult is the outcome of evaluating signals against a pull request.
+type MatchResult struct {
+	Matches bool
+	Reason  string
+
+	// MarkdownReason describes the result like Reason, but as
+	// GitHub-flavored markdown: the reason of each signal type is a bullet
+	// item with the type in bold and quoted values as code spans. It is only
+	// set if the signals enable MarkdownReasons.
+	MarkdownReason string
+
+	// MatchedIndex is the 1-based position of the value that matched when
+	// a single value of a list signal, like one of several comment
+	// substrings, decided that the pull request matches. It is 0 if the
+	// pull request does not match or the result was not decided by a single
+	// value of a list.
+	MatchedIndex int
+
+	// FailedReasons describes each signal the pull request does not meet when
+	// Match is MatchAll. Unless ReportAllReasons is set, it contains at most
+	// one description, since evaluation stops at the first unmet signal.
+	FailedReasons []string
+
+	// Method is the text captured by the "method" named group of a comment
+	// pattern, like "squash" in "/merge (?P<method>squash|rebase|merge)". It
+	// is empty if no comment pattern has a "method" group, if the group did
+	// not participate in the match, or if it captured an empty string; in
+	// these cases the configured merge method applies. The signals do not
+	// validate the captured text, so callers must check that it is a valid
+	// merge method before using it.
+	Method MergeMethod
+
+	// Signal is the name of the signal type described by this result. It is
+	// only set for the entries of Details.
+	Signal string
+
+	// Details contains the result of each signal type that applies to the
+	// pull request, in evaluation order, if the signals are Exhaustive.
+	// Otherwise, it is empty.
+	Details []MatchResult
+}
+
+// MethodCaptureGroup is the name of the group in a comment pattern that
+// selects the merge method for a pull request.
+const MethodCaptureGroup = "method"
+
+// RevertCaptureGroup is the name of the group in a revert pattern that
+// captures the SHA of the reverted commit.
+const RevertCaptureGroup = "sha"
+
+// DefaultRevertPatterns match the title and body that "git revert" and the
+// GitHub revert button generate.
+var DefaultRevertPatterns = []string{
+	`This reverts commit (?P<sha>[0-9a-f]{7,40})`,
+	`^Revert "`,
+}
+
+// Evaluate is like Matches, but returns additional details about the match,
+// including the merge method captured by comment patterns when the pull
+// request matches signals that are not inverted. The signals never merge a
+// pull request themselves; acting on the captured method is left to the
+// caller.
+func (s *Signals) Evaluate(ctx context.Context, pullCtx pull.Context, tag string) (MatchResult, error) {
+	pullCtx = withRetries(pullCtx)
+
+	result, err := s.evaluate(ctx, pullCtx, tag)
+	if err != nil || !result.Matches || s.Invert {
+		return result, err
+	}
+
+	method, err := s.capturedMethod(ctx, pullCtx)
 	if err != nil {
-		return false, "unable to list pull request labels", err
+		return result, err
 	}
+	result.Method = method
+	return result, nil
+}
 
-	if len(labels) == 0 {
-		logger.Debug().Msgf("No labels found to match against")
+func (s *Signals) evaluate(ctx context.Context, pullCtx pull.Context, tag string) (MatchResult, error) {
+	if reason, err := s.checkEvaluatedValues(tag); err != nil {
+		return MatchResult{Reason: reason, MarkdownReason: s.markdown("", signalReason{"", reason})}, err
+	}
+	if reason, err := s.checkDisabled(tag); err != nil {
+		return MatchResult{Reason: reason, MarkdownReason: s.markdown("", signalReason{"disabled", reason})}, err
+	}
+
+	if len(s.BlockedCreators) > 0 || len(s.BlockedCreatorPatterns) > 0 {
+		reason, err := s.blockedCreatorReason(pullCtx, tag)
+		if err != nil || reason != "" {
+			return MatchResult{Reason: reason, MarkdownReason: s.markdown("", signalReason{"blocked_creators", reason})}, err
+		}
 	}
-	for _, signalLabel := range s.Labels {
+
+	if len(s.OverrideLabels) > 0 {
+		reason, err := s.overrideReason(ctx, pullCtx, tag)
+		if err != nil || reason != "" {
+			return MatchResult{Matches: err == nil, Reason: reason, MarkdownReason: s.markdown("", signalReason{"override_labels", reason})}, err
+		}
+	}
+
+	result, err := s.combine(ctx, pullCtx, tag)
+	if err != nil || !s.Invert {
+		return result, err
+	}
+
+	inverted := MatchResult{Matches: !result.Matches, Details: result.Details}
+	summary := fmt.Sprintf("pull request does not match the inverted %s", tag)
+	if result.Matches {
+		summary = fmt.Sprintf("pull request matches the inverted %s", tag)
+	}
+	inverted.Reason = fmt.Sprintf("%s: %s", summary, result.Reason)
+	if s.MarkdownReasons {
+		inverted.MarkdownReason = fmt.Sprintf("%s:\n\n%s", markdownText(summary), result.MarkdownReason)
+	}
+	return inverted, nil
+}
+
+// blockedCreatorReason describes why the author of the pull request is
+// blocked, or returns an empty string if the author is not blocked.
+func (s *Signals) blockedCreatorReason(pullCtx pull.Context, tag string) (string, error) {
+	creator := pullCtx.Creator()
+	for _, blocked := range s.BlockedCreators {
+		if strings.EqualFold(blocked, creator) {
+			return fmt.Sprintf("pull request author %q is a blocked %s creator", creator, tag), nil
+		}
+	}
+	for _, signalPattern := range s.BlockedCreatorPatterns {
+		pattern, err := regexp.Compile(fmt.Sprintf("(?i)^(?:%s)$", signalPattern))
+		if err != nil {
+			return fmt.Sprintf("invalid %s blocked creator pattern: %q", tag, signalPattern), errors.Wrap(err, "failed to compile blocked creator pattern")
+		}
+		if pattern.MatchString(creator) {
+			return fmt.Sprintf("pull request author %q matches a blocked %s creator pattern: %q", creator, tag, signalPattern), nil
+		}
+	}
+	return "", nil
+}
+
+// overrideReason describes the first of OverrideLabels on the pull request,
+// or returns an empty string if the pull request has none of them. If
+// OverrideLabelActors is set, labels most recently added by other users are
+// skipped.
+func (s *Signals) overrideReason(ctx context.Context, pullCtx pull.Context, tag string) (string, error) {
+	labels, err := pullCtx.Labels(ctx)
+	if err != nil {
+		return "unable to list pull request labels", err
+	}
+
+	var events []*pull.LabelEvent
+	for _, overrideLabel := range s.OverrideLabels {
+		present := false
 		for _, label := range labels {
-			if strings.EqualFold(signalLabel, label) {
-				return true, fmt.Sprintf("pull request has a %s label: %q", tag, signalLabel), nil
+			if s.textEqual(overrideLabel, label, true) {
+				present = true
+				break
+			}
+		}
+		if !present {
+			continue
+		}
+
+		if len(s.OverrideLabelActors) == 0 {
+			return fmt.Sprintf("pull request has a %s override label: %q", tag, overrideLabel), nil
+		}
+
+		if events == nil {
+			if events, err = pullCtx.LabelEvents(ctx); err != nil {
+				return "unable to list pull request label events", err
+			}
+		}
+
+		var applied *pull.LabelEvent
+		for _, e := range events {
+			if s.textEqual(overrideLabel, e.Label, true) && (applied == nil || !e.CreatedAt.Before(applied.CreatedAt)) {
+				applied = e
+			}
+		}
+		if applied == nil {
+			continue
+		}
+		for _, actor := range s.OverrideLabelActors {
+			if strings.EqualFold(actor, applied.Actor) {
+				return fmt.Sprintf("pull request has a %s override label added by %q: %q", tag, applied.Actor, overrideLabel), nil
 			}
 		}
 	}
+	return "", nil
+}
 
-	body := pullCtx.Body()
-	comments, err := pullCtx.Comments(ctx)
-	if err != nil {
-		return false, "unable to list pull request comments", err
+// combine evaluates the signals and combines their results according to
+// Match.
+func (s *Signals) combine(ctx context.Context, pullCtx pull.Context, tag string) (MatchResult, error) {
+	switch s.Match {
+	case MatchAll:
+		return s.matchesForAll(ctx, pullCtx, tag)
+	case MatchScore:
+		return s.matchesByScore(ctx, pullCtx, tag)
 	}
+	return s.matchesForOne(ctx, pullCtx, tag)
+}
 
-	if len(comments) == 0 {
-		logger.Debug().Msgf("No comments found to match against")
+func (s *Signals) matchesForOne(ctx context.Context, pullCtx pull.Context, tag string) (MatchResult, error) {
+	var required, optional []SignalEvaluator
+	for _, e := range evaluators {
+		if isRequired(e, s) {
+			required = append(required, e)
+		} else {
+			optional = append(optional, e)
+		}
 	}
-	for _, signalComment := range s.Comments {
-		if body == signalComment {
-			return true, fmt.Sprintf("pull request body is a %s comment: %q", tag, signalComment), nil
+
+	// Required signals must all match before any optional signal is
+	// considered; the first one that does not match decides the result.
+	var failed *MatchResult
+	var requiredReasons []signalReason
+	var details []MatchResult
+	for _, e := range required {
+		result, reason, index, err := evaluateSignal(ctx, e, s, pullCtx, tag)
+		if err != nil {
+			return MatchResult{Reason: reason, MarkdownReason: s.markdown("", signalReason{e.Name(), reason}), Details: details}, err
 		}
-		for _, comment := range comments {
-			if comment == signalComment {
-				return true, fmt.Sprintf("pull request has a %s comment: %q", tag, signalComment), nil
+		details = s.appendDetail(details, e, result, reason, index)
+
+		switch result {
+		case signalNotMatch:
+			if failed == nil {
+				failed = &MatchResult{Reason: reason, MarkdownReason: s.markdown("", signalReason{e.Name(), reason}), FailedReasons: []string{reason}}
+				if !s.Exhaustive {
+					return *failed, nil
+				}
 			}
+		case signalMatch:
+			requiredReasons = append(requiredReasons, signalReason{e.Name(), reason})
 		}
 	}
 
-	if len(s.CommentSubstrings) == 0 {
-		logger.Debug().Msgf("No comment substrings found to match against")
-	}
-	for _, signalSubstring := range s.CommentSubstrings {
-		if strings.Contains(body, signalSubstring) {
-			return true, fmt.Sprintf("pull request body matches a %s substring: %q", tag, signalSubstring), nil
+	var matched *MatchResult
+	var matchedReason signalReason
+	foundOptional := false
+	for _, e := range optional {
+		if failed != nil && !s.Exhaustive {
+			break
 		}
-		for _, comment := range comments {
-			if strings.Contains(comment, signalSubstring) {
-				return true, fmt.Sprintf("pull request comment matches a %s substring: %q", tag, signalSubstring), nil
+		result, reason, index, err := evaluateSignal(ctx, e, s, pullCtx, tag)
+		if err != nil {
+			return MatchResult{Reason: reason, MarkdownReason: s.markdown("", signalReason{e.Name(), reason}), Details: details}, err
+		}
+		details = s.appendDetail(details, e, result, reason, index)
+
+		if result != signalNotFound {
+			foundOptional = true
+		}
+		if result == signalMatch && matched == nil {
+			matchedReason = signalReason{e.Name(), reason}
+			matched = &MatchResult{Matches: true, Reason: reason, MarkdownReason: s.markdown("", matchedReason), MatchedIndex: index}
+			if !s.Exhaustive {
+				break
 			}
 		}
 	}
 
-	if len(s.PRBodySubstrings) == 0 {
-		logger.Debug().Msgf("No PR body substrings found to match against")
+	summary := fmt.Sprintf("pull request matches required %s signals", tag)
+	switch {
+	case failed != nil:
+		failed.Details = details
+		return *failed, nil
+	case matched != nil && len(requiredReasons) > 0:
+		reasons := append(requiredReasons, matchedReason)
+		matched.Reason = fmt.Sprintf("%s: %s", summary, joinReasons(reasons))
+		matched.MarkdownReason = s.markdown(summary, reasons...)
+		fallthrough
+	case matched != nil:
+		matched.Details = details
+		return *matched, nil
+	case len(requiredReasons) > 0 && !foundOptional:
+		reason := fmt.Sprintf("%s: %s", summary, joinReasons(requiredReasons))
+		return MatchResult{Matches: true, Reason: reason, MarkdownReason: s.markdown(summary, requiredReasons...), Details: details}, nil
 	}
-	for _, signalSubstring := range s.PRBodySubstrings {
-		if strings.Contains(body, signalSubstring) {
-			return true, fmt.Sprintf("pull request body matches a %s substring: %q", tag, signalSubstring), nil
+	reason := fmt.Sprintf("pull request does not match the %s", tag)
+	return MatchResult{Reason: reason, MarkdownReason: s.markdown(reason), Details: details}, nil
+}
+
+func (s *Signals) matchesForAll(ctx context.Context, pullCtx pull.Context, tag string) (MatchResult, error) {
+	var reasons, failed []signalReason
+	var details []MatchResult
+	for _, e := range evaluators {
+		result, reason, index, err := evaluateSignal(ctx, e, s, pullCtx, tag)
+		if err != nil {
+			return MatchResult{Reason: reason, MarkdownReason: s.markdown("", signalReason{e.Name(), reason}), Details: details}, err
+		}
+		details = s.appendDetail(details, e, result, reason, index)
+
+		switch result {
+		case signalNotMatch:
+			failed = append(failed, signalReason{e.Name(), reason})
+			if !s.ReportAllReasons && !s.Exhaustive {
+				return MatchResult{Reason: reason, MarkdownReason: s.markdown("", failed...), FailedReasons: []string{reason}}, nil
+			}
+		case signalMatch:
+			reasons = append(reasons, signalReason{e.Name(), reason})
 		}
 	}
 
-	targetBranch, _ := pullCtx.Branches()
-	if len(s.Branches) == 0 || len(s.BranchPatterns) == 0 {
-		logger.Debug().Msgf("No branches or branch patterns found to match against")
+	if !s.ReportAllReasons && len(failed) > 1 {
+		failed = failed[:1]
 	}
-	for _, signalBranch := range s.Branches {
-		if targetBranch == signalBranch {
-			return true, fmt.Sprintf("pull request target is a %s branch: %q", tag, signalBranch), nil
+
+	failedReasons := make([]string, len(failed))
+	for i, r := range failed {
+		failedReasons[i] = r.text
+	}
+
+	switch {
+	case len(failed) == 1:
+		return MatchResult{Reason: failedReasons[0], MarkdownReason: s.markdown("", failed...), FailedReasons: failedReasons, Details: details}, nil
+	case len(failed) > 1:
+		summary := fmt.Sprintf("pull request does not match %d %s signals", len(failed), tag)
+		reason := fmt.Sprintf("%s: %s", summary, joinReasons(failed))
+		return MatchResult{Reason: reason, MarkdownReason: s.markdown(summary, failed...), FailedReasons: failedReasons, Details: details}, nil
+	}
+
+	if len(reasons) == 0 {
+		reason := fmt.Sprintf("pull request does not match the %s", tag)
+		return MatchResult{Reason: reason, MarkdownReason: s.markdown(reason), Details: details}, nil
+	}
+	summary := fmt.Sprintf("pull request matches all %s signals", tag)
+	reason := fmt.Sprintf("%s: %s", summary, joinReasons(reasons))
+	return MatchResult{Matches: true, Reason: reason, MarkdownReason: s.markdown(summary, reasons...), Details: details}, nil
+}
+
+// matchesByScore adds up the weights of the signal types that match. Unless
+// the signals are Exhaustive or a weight is negative, evaluation stops once
+// the score reaches the threshold.
+func (s *Signals) matchesByScore(ctx context.Context, pullCtx pull.Context, tag string) (MatchResult, error) {
+	threshold := s.Threshold
+	if threshold <= 0 {
+		threshold = 1
+	}
+
+	stopEarly := !s.Exhaustive
+	for _, w := range s.Weights {
+		if w < 0 {
+			stopEarly = false
 		}
 	}
-	for _, signalBranch := range s.BranchPatterns {
-		if matched, _ := regexp.MatchString(fmt.Sprintf("^%s$", signalBranch), targetBranch); matched {
-			return true, fmt.Sprintf("pull request target branch (%q) matches pattern: %q", targetBranch, signalBranch), nil
+
+	score := 0
+	var contributions []string
+	var reasons []signalReason
+	var details []MatchResult
+	for _, e := range evaluators {
+		if stopEarly && score >= threshold {
+			break
+		}
+
+		result, reason, index, err := evaluateSignal(ctx, e, s, pullCtx, tag)
+		if err != nil {
+			return MatchResult{Reason: reason, MarkdownReason: s.markdown("", signalReason{e.Name(), reason}), Details: details}, err
 		}
+		details = s.appendDetail(details, e, result, reason, index)
+
+		if result == signalMatch {
+			weight := s.weight(e.Name())
+			score += weight
+			contributions = append(contributions, fmt.Sprintf("%s (%d)", e.Name(), weight))
+			reasons = append(reasons, signalReason{fmt.Sprintf("%s (%d)", e.Name(), weight), reason})
+		}
+	}
+
+	summary := fmt.Sprintf("pull request scored %d, less than the %s threshold of %d", score, tag, threshold)
+	if score >= threshold {
+		summary = fmt.Sprintf("pull request scored %d, meeting the %s threshold of %d", score, tag, threshold)
 	}
 
-	return false, fmt.Sprintf("pull request does not match the %s", tag), nil
+	reason := summary
+	if len(contributions) > 0 {
+		reason += ": " + strings.Join(contributions, ", ")
+	}
+	return MatchResult{Matches: score >= threshold, Reason: reason, MarkdownReason: s.markdown(summary, reasons...), Details: details}, nil
+}
+
+// weight returns the score contributed by a matching signal type.
+func (s *Signals) weight(signalType string) int {
+	if w, ok := s.Weights[signalType]; ok {
+		return w
+	}
+	return 1
+}
+
+// appendDetail adds the result of a signal type to details if the signals
+// are Exhaustive and the signal type applies to the pull request.
+func (s *Signals) appendDetail(details []MatchResult, e SignalEvaluator, result signalResult, reason string, index int) []MatchResult {
+	if !s.Exhaustive || result == signalNotFound {
+		return details
+	}
+	return append(details, MatchResult{
+		Matches:      result == signalMatch,
+		Reason:       reason,
+		MatchedIndex: index,
+		Signal:       e.Name(),
+	})
+}
+
+// matchType returns the match type for the values of a signal, falling back
+// to the match type of the signals if the values do not set one. Values are
+// combined with MatchOne when the signals are matched with MatchScore.
+func (s *Signals) matchType(ss SubSignal) MatchType {
+	if ss.Match != "" {
+		return ss.Match
+	}
+	if s.Match != "" && s.Match != MatchScore {
+		return s.Match
+	}
+	return MatchOne
+}
+
+// matchValues evaluates the values of a signal using a match type. The match
+// function reports if a single value matches and returns a description of
+// the outcome for that value. The name appears in descriptions that cover
+// all of the values. If a single value decides the result, matchValues
+// returns its 1-based position and, for lists with more than one value,
+// includes the position in the description.
+func matchValues(name, tag string, values []string, matchType MatchType, match func(value string) (bool, string, error)) (signalResult, string, int, error) {
+	if len(values) == 0 {
+		return signalNotFound, "", 0, nil
+	}
+
+	var lastReason string
+	for i, value := range values {
+		matched, reason, err := match(value)
+		if err != nil {
+			return signalNotMatch, reason, 0, err
+		}
+		if len(values) > 1 {
+			reason = fmt.Sprintf("%s (%s %d/%d)", reason, name, i+1, len(values))
+		}
+		if matched && matchType != MatchAll {
+			return signalMatch, reason, i + 1, nil
+		}
+		if !matched && matchType == MatchAll {
+			return signalNotMatch, reason, i + 1, nil
+		}
+		lastReason = reason
+	}
+
+	if matchType != MatchAll {
+		return signalNotMatch, fmt.Sprintf("pull request does not match any %s %s", tag, name), 0, nil
+	}
+	if len(values) == 1 {
+		return signalMatch, lastReason, 1, nil
+	}
+	return signalMatch, fmt.Sprintf("pull request matches all %s %s", tag, name), 0, nil
+}
+
+func (s *Signals) doesPRBodySubstringSignalMatch(ctx context.Context, pullCtx pull.Context, tag string) (signalResult, string, int, error) {
+	logger := zerolog.Ctx(ctx)
+
+	if len(s.PRBodySubstrings.Values) == 0 {
+		logger.Debug().Msgf("No PR body substrings found to match against")
+	}
+
+	body := pullCtx.Body()
+	emptyBody := isEmptyBody(ctx, body, s.PRBodySubstrings.Values)
+	return matchValues("body substrings", tag, s.PRBodySubstrings.Values, s.matchType(s.PRBodySubstrings), func(signalSubstring string) (bool, string, error) {
+		if s.substringMatches(body, signalSubstring) {
+			return true, fmt.Sprintf("pull request body matches a %s substring: %q", tag, signalSubstring), nil
+		}
+		if emptyBody {
+			return false, fmt.Sprintf("pull request body is empty and does not match a %s substring: %q", tag, signalSubstring), nil
+		}
+		return false, fmt.Sprintf("pull request body does not match a %s substring: %q", tag, signalSubstring), nil
+	})
+}
+
+// doesTitleSignalMatch matches pull requests with titles that are at most
+// TitleMaxLength characters long and match TitleRequiredPattern. If Normalize
+// is set, the title is normalized before both checks. The reason describes
+// the first rule the title violates.
+func (s *Signals) doesTitleSignalMatch(ctx context.Context, pullCtx pull.Context, tag string) (signalResult, string, int, error) {
+	if s.TitleMaxLength <= 0 && s.TitleRequiredPattern == "" {
+		return signalNotFound, "", 0, nil
+	}
+
+	title := s.normalizeUnicode(pullCtx.Title())
+	if length := utf8.RuneCountInString(title); s.TitleMaxLength > 0 && length > s.TitleMaxLength {
+		return signalNotMatch, fmt.Sprintf("pull request title is %d characters long, longer than the %s maximum of %d", length, tag, s.TitleMaxLength), 0, nil
+	}
+
+	if s.TitleRequiredPattern != "" {
+		r, err := regexp.Compile(s.TitleRequiredPattern)
+		if err != nil {
+			return signalNotMatch, fmt.Sprintf("invalid %s title pattern: %q", tag, s.TitleRequiredPattern), 0, errors.Wrapf(err, "failed to compile title pattern %q", s.TitleRequiredPattern)
+		}
+		if !r.MatchString(title) {
+			return signalNotMatch, fmt.Sprintf("pull request title %q does not match the %s title pattern: %q", title, tag, s.TitleRequiredPattern), 0, nil
+		}
+	}
+	return signalMatch, fmt.Sprintf("pull request title %q meets the %s title rules", title, tag), 0, nil
+}
+
+// doesBodyLengthSignalMatch matches pull requests with bodies that are at
+// least MinBodyLength and at most MaxBodyLength characters long.
+func (s *Signals) doesBodyLengthSignalMatch(ctx context.Context, pullCtx pull.Context, tag string) (signalResult, string, int, error) {
+	if s.MinBodyLength <= 0 && s.MaxBodyLength <= 0 {
+		return signalNotFound, "", 0, nil
+	}
+
+	length := utf8.RuneCountInString(pullCtx.Body())
+	if s.MinBodyLength > 0 && length < s.MinBodyLength {
+		return signalNotMatch, fmt.Sprintf("pull request body is %d characters long, shorter than the %s minimum of %d", length, tag, s.MinBodyLength), 0, nil
+	}
+	if s.MaxBodyLength > 0 && length > s.MaxBodyLength {
+		return signalNotMatch, fmt.Sprintf("pull request body is %d characters long, longer than the %s maximum of %d", length, tag, s.MaxBodyLength), 0, nil
+	}
+	return signalMatch, fmt.Sprintf("pull request body is %d characters long, within the %s bounds", length, tag), 0, nil
+}
+
+// parseBodyHeaders returns the header block at the start of a pull request
+// body. The block is every line before the first blank line, and each line
+// must have the form "Key: Value", like a commit trailer; otherwise, the
+// body does not have a header block.
+func parseBodyHeaders(body string) []trailer {
+	body = strings.TrimLeft(strings.Replace(body, "\r\n", "\n", -1), " \t\n")
+	if body == "" {
+		return nil
+	}
+
+	block := strings.SplitN(body, "\n\n", 2)[0]
+	var headers []trailer
+	for _, line := range strings.Split(block, "\n") {
+		if strings.TrimSpace(line) == "" {
+			break
+		}
+		m := trailerLine.FindStringSubmatch(line)
+		if m == nil {
+			return nil
+		}
+		headers = append(headers, trailer{key: m[1], value: m[2]})
+	}
+	return headers
+}
+
+// doesBodyHeaderSignalMatch matches pull requests with a header in the body
+// that has the value configured in BodyHeaders. Header names are compared
+// without regard to case and checked in order of their names.
+func (s *Signals) doesBodyHeaderSignalMatch(ctx context.Context, pullCtx pull.Context, tag string) (signalResult, string, int, error) {
+	if len(s.BodyHeaders) == 0 {
+		return signalNotFound, "", 0, nil
+	}
+
+	headers := parseBodyHeaders(pullCtx.Body())
+	if len(headers) == 0 {
+		return signalNotMatch, "pull request body does not start with a header block", 0, nil
+	}
+
+	names := make([]string, 0, len(s.BodyHeaders))
+	for name := range s.BodyHeaders {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		signalValue := s.BodyHeaders[name]
+		pattern, err := regexp.Compile(fmt.Sprintf("(?i)^(?:%s)$", signalValue))
+		if err != nil {
+			return signalNotMatch, fmt.Sprintf("invalid %s body header value for %q: %q", tag, name, signalValue), 0, errors.Wrapf(err, "failed to compile body header pattern for %q", name)
+		}
+		for _, h := range headers {
+			if strings.EqualFold(h.key, name) && pattern.MatchString(h.value) {
+				return signalMatch, fmt.Sprintf("pull request body has the %s header %q set to %q", tag, h.key, h.value), 0, nil
+			}
+		}
+	}
+	return signalNotMatch, fmt.Sprintf("pull request body does not have any %s header values", tag), 0, nil
+}
+
+// doesRevertSignalMatch matches pull requests with a title or body matching
+// a revert pattern. Patterns are tried in order against the title and then
+// the body, but a match that captures the reverted commit is preferred.
+func (s *Signals) doesRevertSignalMatch(ctx context.Context, pullCtx pull.Context, tag string) (signalResult, string, int, error) {
+	if !s.MatchReverts {
+		return signalNotFound, "", 0, nil
+	}
+
+	patterns := s.RevertPatterns
+	if len(patterns) == 0 {
+		patterns = DefaultRevertPatterns
+	}
+
+	texts := []struct{ name, text string }{
+		{"title", pullCtx.Title()},
+		{"body", pullCtx.Body()},
+	}
+
+	reason := ""
+	for _, pattern := range patterns {
+		r, err := regexp.Compile(pattern)
+		if err != nil {
+			return signalNotMatch, fmt.Sprintf("invalid %s revert pattern: %q", tag, pattern), 0, errors.Wrapf(err, "failed to compile revert pattern %q", pattern)
+		}
+
+		for _, t := range texts {
+			m := r.FindStringSubmatch(t.text)
+			if m == nil {
+				continue
+			}
+			for i, name := range r.SubexpNames() {
+				if name == RevertCaptureGroup && m[i] != "" {
+					return signalMatch, fmt.Sprintf("pull request %s matches a %s revert pattern, reverting commit %s", t.name, tag, m[i]), 0, nil
+				}
+			}
+			if reason == "" {
+				reason = fmt.Sprintf("pull request %s matches a %s revert pattern: %q", t.name, tag, pattern)
+			}
+		}
+	}
+
+	if reason != "" {
+		return signalMatch, reason, 0, nil
+	}
+	return signalNotMatch, fmt.Sprintf("pull request title and body do not match a %s revert pattern", tag), 0, nil
+}
+
+func (s *Signals) doesChecklistSignalMatch(ctx context.Context, pullCtx pull.Context, tag string) (signalResult, string, int, error) {
+	if !s.RequireCompletedChecklist {
+		return signalNotFound, "", 0, nil
+	}
+
+	checked, unchecked := countTaskListItems(pullCtx.Body())
+	switch {
+	case checked+unchecked == 0:
+		return signalMatch, "pull request body does not have a checklist", 0, nil
+	case unchecked > 0:
+		return signalNotMatch, fmt.Sprintf("pull request body has %d unchecked checklist items out of %d, but the %s requires a completed checklist", unchecked, checked+unchecked, tag), 0, nil
+	}
+	return signalMatch, fmt.Sprintf("pull request body has all %d checklist items checked", checked), 0, nil
+}
+
+var taskListItem = regexp.MustCompile(`^\s*(?:[-*+]|\d+[.)])\s+\[([ xX])\]`)
+
+// countTaskListItems counts the checked and unchecked markdown task list
+// items in text, skipping fenced code blocks.
+func countTaskListItems(text string) (checked, unchecked int) {
+	fence := ""
+	for _, line := range strings.Split(text, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if fence != "" {
+			if strings.HasPrefix(trimmed, fence) {
+				fence = ""
+			}
+			continue
+		}
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			fence = trimmed[:3]
+			continue
+		}
+
+		if m := taskListItem.FindStringSubmatch(line); m != nil {
+			if m[1] == " " {
+				unchecked++
+			} else {
+				checked++
+			}
+		}
+	}
+	return checked, unchecked
+}
+
+// now returns the current time. Tests replace it to control the age of pull
+// requests and status checks.
+var now = time.Now
+
+func (s *Signals) doesOpenDurationSignalMatch(ctx context.Context, pullCtx pull.Context, tag string) (signalResult, string, int, error) {
+	if s.MinOpenDuration <= 0 {
+		return signalNotFound, "", 0, nil
+	}
+
+	createdAt := pullCtx.CreatedAt()
+	if createdAt.IsZero() {
+		return signalNotMatch, "unable to determine when the pull request was opened", 0, nil
+	}
+
+	age := now().Sub(createdAt).Round(time.Second)
+	if age < s.MinOpenDuration {
+		return signalNotMatch, fmt.Sprintf("pull request has been open for %s, less than the %s minimum of %s", age, tag, s.MinOpenDuration), 0, nil
+	}
+	return signalMatch, fmt.Sprintf("pull request has been open for %s, at least the %s minimum of %s", age, tag, s.MinOpenDuration), 0, nil
+}
+
+// isEmptyBody returns true if the pull request body is empty or only contains
+// whitespace. If values are configured for a signal that matches the body, it
+// logs that they are matched against an empty description.
+func isEmptyBody(ctx context.Context, body string, values []string) bool {
+	if strings.TrimSpace(body) != "" {
+		return false
+	}
+	if len(values) > 0 {
+		zerolog.Ctx(ctx).Debug().Msgf("Pull request body is empty, matching %d values against an empty description", len(values))
+	}
+	return true
+}
+
+func (s *Signals) doesBranchSignalMatch(ctx context.Context, pullCtx pull.Context, tag string) (signalResult, string, int, error) {
+	logger := zerolog.Ctx(ctx)
+
+	if len(s.Branches.Values) == 0 || len(s.BranchPatterns.Values) == 0 {
+		logger.Debug().Msgf("No branches or branch patterns found to match against")
+	}
+
+	targetBranch, _ := pullCtx.Branches()
+	return matchValues("branches", tag, s.Branches.Values, s.matchType(s.Branches), func(signalBranch string) (bool, string, error) {
+		if targetBranch == signalBranch {
+			return true, fmt.Sprintf("pull request target is a %s branch: %q", tag, signalBranch), nil
+		}
+		return false, fmt.Sprintf("pull request target branch (%q) is not a %s branch: %q", targetBranch, tag, signalBranch), nil
+	})
+}
+
+func (s *Signals) doesBranchPatternSignalMatch(ctx context.Context, pullCtx pull.Context, tag string) (signalResult, string, int, error) {
+	targetBranch, _ := pullCtx.Branches()
+	return matchValues("branch patterns", tag, s.BranchPatterns.Values, s.matchType(s.BranchPatterns), func(signalBranch string) (bool, string, error) {
+		if matched, _ := regexp.MatchString(fmt.Sprintf("^%s$", signalBranch), targetBranch); matched {
+			return true, fmt.Sprintf("pull request target branch (%q) matches pattern: %q", targetBranch, signalBranch), nil
+		}
+		return false, fmt.Sprintf("pull request target branch (%q) does not match pattern: %q", targetBranch, signalBranch), nil
+	})
+}
+
+func (s *Signals) doesBranchPrefixSignalMatch(ctx context.Context, pullCtx pull.Context, tag string) (signalResult, string, int, error) {
+	targetBranch, _ := pullCtx.Branches()
+	return matchValues("branch prefixes", tag, s.BranchPrefixes.Values, s.matchType(s.BranchPrefixes), func(signalPrefix string) (bool, string, error) {
+		if strings.HasPrefix(targetBranch, signalPrefix) {
+			return true, fmt.Sprintf("pull request target branch (%q) has a %s prefix: %q", targetBranch, tag, signalPrefix), nil
+		}
+		return false, fmt.Sprintf("pull request target branch (%q) does not have a %s prefix: %q", targetBranch, tag, signalPrefix), nil
+	})
+}
+
+func (s *Signals) doesBranchSuffixSignalMatch(ctx context.Context, pullCtx pull.Context, tag string) (signalResult, string, int, error) {
+	targetBranch, _ := pullCtx.Branches()
+	return matchValues("branch suffixes", tag, s.BranchSuffixes.Values, s.matchType(s.BranchSuffixes), func(signalSuffix string) (bool, string, error) {
+		if strings.HasSuffix(targetBranch, signalSuffix) {
+			return true, fmt.Sprintf("pull request target branch (%q) has a %s suffix: %q", targetBranch, tag, signalSuffix), nil
+		}
+		return false, fmt.Sprintf("pull request target branch (%q) does not have a %s suffix: %q", targetBranch, tag, signalSuffix), nil
+	})
+}
+
+// protectsHeadBranches returns true if the signals configure protected head
+// branches or patterns.
+func (s *Signals) protectsHeadBranches() bool {
+	return len(s.ProtectedHeadBranches) > 0 || len(s.ProtectedHeadBranchPatterns) > 0
+}
+
+// doesProtectedHeadBranchSignalMatch matches pull requests whose head branch
+// is one of the ProtectedHeadBranches or matches one of the
+// ProtectedHeadBranchPatterns.
+func (s *Signals) doesProtectedHeadBranchSignalMatch(ctx context.Context, pullCtx pull.Context, tag string) (signalResult, string, int, error) {
+	if !s.protectsHeadBranches() {
+		return signalNotFound, "", 0, nil
+	}
+
+	_, headBranch := pullCtx.Branches()
+	if strings.Contains(headBranch, ":") {
+		return signalNotMatch, fmt.Sprintf("pull request head branch (%q) is in a fork", headBranch), 0, nil
+	}
+
+	for _, branch := range s.ProtectedHeadBranches {
+		if headBranch == branch {
+			return signalMatch, fmt.Sprintf("pull request head branch (%q) is a %s protected head branch", headBranch, tag), 0, nil
+		}
+	}
+	for _, signalPattern := range s.ProtectedHeadBranchPatterns {
+		pattern, err := regexp.Compile(fmt.Sprintf("^(?:%s)$", signalPattern))
+		if err != nil {
+			return signalNotMatch, fmt.Sprintf("invalid %s protected head branch pattern: %q", tag, signalPattern), 0, errors.Wrap(err, "failed to compile protected head branch pattern")
+		}
+		if pattern.MatchString(headBranch) {
+			return signalMatch, fmt.Sprintf("pull request head branch (%q) matches a %s protected head branch pattern: %q", headBranch, tag, signalPattern), 0, nil
+		}
+	}
+	return signalNotMatch, fmt.Sprintf("pull request head branch (%q) is not a %s protected head branch", headBranch, tag), 0, nil
+}
+
+// authorAssociationTiers ranks the author associations reported by GitHub
+// from least to most trusted. Associations that are not listed, including
+// an empty association, rank below all listed associations.
+var authorAssociationTiers = map[string]int{
+	"NONE":                   0,
+	"FIRST_TIMER":            0,
+	"FIRST_TIME_CONTRIBUTOR": 0,
+	"CONTRIBUTOR":            1,
+	"COLLABORATOR":           2,
+	"MEMBER":                 3,
+	"OWNER":                  4,
+}
+
+// authorAssociationTier returns the rank of an author association, or -1 if
+// the association is unknown.
+func authorAssociationTier(association string) int {
+	if tier, ok := authorAssociationTiers[strings.ToUpper(association)]; ok {
+		return tier
+	}
+	return -1
+}
+
+// minAuthorAssociation returns the least trusted author association that
+// matches the signals, or an empty string if the signals do not restrict
+// the author association.
+func (s *Signals) minAuthorAssociation() string {
+	minimum := strings.ToUpper(s.MinAuthorAssociation)
+	if s.RequireReturningContributor && authorAssociationTier(minimum) < authorAssociationTier("CONTRIBUTOR") {
+		minimum = "CONTRIBUTOR"
+	}
+	return minimum
+}
+
+func (s *Signals) doesAuthorAssociationSignalMatch(ctx context.Context, pullCtx pull.Context, tag string) (signalResult, string, int, error) {
+	minimum := s.minAuthorAssociation()
+	if minimum == "" {
+		return signalNotFound, "", 0, nil
+	}
+
+	minTier := authorAssociationTier(minimum)
+	if minTier < 0 {
+		return signalNotMatch, fmt.Sprintf("invalid %s minimum author association: %q", tag, minimum), 0, errors.Errorf("unknown author association %q", minimum)
+	}
+
+	association := pullCtx.AuthorAssociation()
+	if authorAssociationTier(association) >= minTier {
+		return signalMatch, fmt.Sprintf("pull request author association (%q) is at least the %s minimum: %q", association, tag, minimum), 0, nil
+	}
+	return signalNotMatch, fmt.Sprintf("pull request author association (%q) is below the %s minimum: %q", association, tag, minimum), 0, nil
+}
+
+// doesAuthorContributionSignalMatch matches pull requests whose author has
+// opened at least MinAuthorContributions merged pull requests.
+func (s *Signals) doesAuthorContributionSignalMatch(ctx context.Context, pullCtx pull.Context, tag string) (signalResult, string, int, error) {
+	if s.MinAuthorContributions <= 0 {
+		return signalNotFound, "", 0, nil
+	}
+
+	creator := pullCtx.Creator()
+	contributions, err := pullCtx.AuthorContributions(ctx)
+	if err != nil {
+		return signalNotMatch, fmt.Sprintf("unable to count merged pull requests by %q", creator), 0, err
+	}
+	if contributions >= s.MinAuthorContributions {
+		return signalMatch, fmt.Sprintf("pull request author %q has %d merged pull requests, at least the %s minimum of %d", creator, contributions, tag, s.MinAuthorContributions), 0, nil
+	}
+	return signalNotMatch, fmt.Sprintf("pull request author %q has %d merged pull requests, fewer than the %s minimum of %d", creator, contributions, tag, s.MinAuthorContributions), 0, nil
+}
+
+// doesAuthorAccountAgeSignalMatch matches pull requests whose author's
+// account was created at least MinAuthorAccountAge ago. Like
+// MinOpenDuration, an unknown creation time does not match.
+func (s *Signals) doesAuthorAccountAgeSignalMatch(ctx context.Context, pullCtx pull.Context, tag string) (signalResult, string, int, error) {
+	if s.MinAuthorAccountAge <= 0 {
+		return signalNotFound, "", 0, nil
+	}
+
+	creator := pullCtx.Creator()
+	createdAt, err := pullCtx.AuthorCreatedAt(ctx)
+	if err != nil {
+		return signalNotMatch, fmt.Sprintf("unable to determine when the account of %q was created", creator), 0, err
+	}
+
+	if createdAt.IsZero() {
+		return signalNotMatch, fmt.Sprintf("unable to determine when the account of %q was created", creator), 0, nil
+	}
+
+	age := now().Sub(createdAt).Round(time.Second)
+	if age < s.MinAuthorAccountAge {
+		return signalNotMatch, fmt.Sprintf("pull request author %q has had an account for %s, less than the %s minimum of %s", creator, age, tag, s.MinAuthorAccountAge), 0, nil
+	}
+	return signalMatch, fmt.Sprintf("pull request author %q has had an account for %s, at least the %s minimum of %s", creator, age, tag, s.MinAuthorAccountAge), 0, nil
+}
+
+// doesCreatorTypeSignalMatch matches pull requests whose author is or is not
+// a bot, depending on CreatorIsBot.
+func (s *Signals) doesCreatorTypeSignalMatch(ctx context.Context, pullCtx pull.Context, tag string) (signalResult, string, int, error) {
+	if s.CreatorIsBot == nil {
+		return signalNotFound, "", 0, nil
+	}
+
+	creator, creatorType := pullCtx.Creator(), pullCtx.CreatorType()
+	isBot := strings.EqualFold(creatorType, "Bot")
+
+	kind := "a human"
+	if *s.CreatorIsBot {
+		kind = "a bot"
+	}
+	if isBot == *s.CreatorIsBot {
+		return signalMatch, fmt.Sprintf("pull request author %q is %s (account type %q)", creator, kind, creatorType), 0, nil
+	}
+	return signalNotMatch, fmt.Sprintf("pull request author %q is not %s (account type %q)", creator, kind, creatorType), 0, nil
+}
+
+// doesCreatorSignalMatch matches pull requests opened by one of Creators or,
+// if the Match of Creators is MatchAll, by every one of them.
+func (s *Signals) doesCreatorSignalMatch(ctx context.Context, pullCtx pull.Context, tag string) (signalResult, string, int, error) {
+	if len(s.Creators.Values) == 0 {
+		return signalNotFound, "", 0, nil
+	}
+
+	creator := pullCtx.Creator()
+	if s.Creators.Match == MatchAll {
+		for i, c := range s.Creators.Values {
+			if !strings.EqualFold(c, creator) {
+				return signalNotMatch, fmt.Sprintf("pull request author %q is not the %s creator %q", creator, tag, c), i + 1, nil
+			}
+		}
+		index := 0
+		if len(s.Creators.Values) == 1 {
+			index = 1
+		}
+		return signalMatch, fmt.Sprintf("pull request author %q is every %s creator", creator, tag), index, nil
+	}
+
+	for i, c := range s.Creators.Values {
+		if strings.EqualFold(c, creator) {
+			return signalMatch, fmt.Sprintf("pull request author %q is a %s creator", creator, tag), i + 1, nil
+		}
+	}
+	return signalNotMatch, fmt.Sprintf("pull request author %q is not a %s creator", creator, tag), 0, nil
+}
+
+// doesGroupSignalMatch matches pull requests that meet any of Groups. Groups
+// are evaluated in order of their names, and the reason names the group that
+// matched or describes why each group did not.
+func (s *Signals) doesGroupSignalMatch(ctx context.Context, pullCtx pull.Context, tag string) (signalResult, string, int, error) {
+	if len(s.Groups) == 0 {
+		return signalNotFound, "", 0, nil
+	}
+
+	names := make([]string, 0, len(s.Groups))
+	for name := range s.Groups {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var reasons []string
+	for i, name := range names {
+		group := s.Groups[name]
+		result, err := group.evaluate(ctx, pullCtx, tag)
+		if err != nil {
+			return signalNotMatch, fmt.Sprintf("unable to evaluate %s group %q: %s", tag, name, result.Reason), 0, errors.Wrapf(err, "failed to evaluate group %q", name)
+		}
+		if result.Matches {
+			return signalMatch, fmt.Sprintf("pull request matches the %s group %q: %s", tag, name, result.Reason), i + 1, nil
+		}
+		reasons = append(reasons, fmt.Sprintf("group %q: %s", name, result.Reason))
+	}
+	return signalNotMatch, fmt.Sprintf("pull request does not match any %s group: %s", tag, strings.Join(reasons, "; ")), 0, nil
+}
+
+func (s *Signals) doesLabelSignalMatch(ctx context.Context, pullCtx pull.Context, tag string) (signalResult, string, int, error) {
+	logger := zerolog.Ctx(ctx)
+
+	if len(s.Labels.Values) == 0 {
+		return signalNotFound, "", 0, nil
+	}
+
+	labels, err := pullCtx.Labels(ctx)
+	if err != nil {
+		return signalNotMatch, "unable to list pull request labels", 0, err
+	}
+
+	if len(labels) == 0 {
+		logger.Debug().Msgf("No labels found to match against")
+	}
+	if s.Labels.LabelsAfterLastCommit {
+		return s.doesRecentLabelSignalMatch(ctx, pullCtx, tag, labels)
+	}
+
+	return matchValues("labels", tag, s.Labels.Values, s.matchType(s.Labels), func(signalLabel string) (bool, string, error) {
+		for _, label := range labels {
+			if s.labelEqual(signalLabel, label) {
+				return true, fmt.Sprintf("pull request has a %s label: %q", tag, signalLabel), nil
+			}
+		}
+		return false, fmt.Sprintf("pull request does not have a %s label: %q", tag, signalLabel), nil
+	})
+}
+
+// labelEqual reports whether a label matches a value of the labels signal,
+// ignoring case unless the signal is case-sensitive.
+func (s *Signals) labelEqual(signalLabel, label string) bool {
+	return s.textEqual(signalLabel, label, !s.Labels.CaseSensitive)
+}
+
+// doesRecentLabelSignalMatch matches labels that are present on the pull
+// request and were most recently added after the last commit.
+func (s *Signals) doesRecentLabelSignalMatch(ctx context.Context, pullCtx pull.Context, tag string, labels []string) (signalResult, string, int, error) {
+	commits, err := pullCtx.Commits(ctx)
+	if err != nil {
+		return signalNotMatch, "unable to list pull request commits", 0, err
+	}
+
+	var lastCommit time.Time
+	for _, c := range commits {
+		if c.CommittedAt.After(lastCommit) {
+			lastCommit = c.CommittedAt
+		}
+	}
+
+	events, err := pullCtx.LabelEvents(ctx)
+	if err != nil {
+		return signalNotMatch, "unable to list pull request label events", 0, err
+	}
+
+	return matchValues("labels", tag, s.Labels.Values, s.matchType(s.Labels), func(signalLabel string) (bool, string, error) {
+		present := false
+		for _, label := range labels {
+			if s.labelEqual(signalLabel, label) {
+				present = true
+				break
+			}
+		}
+		if !present {
+			return false, fmt.Sprintf("pull request does not have a %s label: %q", tag, signalLabel), nil
+		}
+
+		var applied *pull.LabelEvent
+		for _, e := range events {
+			if s.labelEqual(signalLabel, e.Label) && (applied == nil || !e.CreatedAt.Before(applied.CreatedAt)) {
+				applied = e
+			}
+		}
+		if applied == nil {
+			return false, fmt.Sprintf("pull request %s label %q has no record of when it was applied", tag, signalLabel), nil
+		}
+
+		appliedAt := applied.CreatedAt.UTC().Format(time.RFC3339)
+		if applied.CreatedAt.After(lastCommit) {
+			return true, fmt.Sprintf("pull request has a %s label applied after the last commit: %q (applied %s)", tag, signalLabel, appliedAt), nil
+		}
+		return false, fmt.Sprintf("pull request %s label %q was applied before the last commit (applied %s)", tag, signalLabel, appliedAt), nil
+	})
+}
+
+func (s *Signals) doesLabelCountSignalMatch(ctx context.Context, pullCtx pull.Context, tag string) (signalResult, string, int, error) {
+	if s.MinLabels <= 0 && s.MaxLabels <= 0 {
+		return signalNotFound, "", 0, nil
+	}
+
+	labels, err := pullCtx.Labels(ctx)
+	if err != nil {
+		return signalNotMatch, "unable to list pull request labels", 0, err
+	}
+
+	switch {
+	case s.MinLabels > 0 && len(labels) < s.MinLabels:
+		return signalNotMatch, fmt.Sprintf("pull request has %d labels, fewer than the %s minimum of %d", len(labels), tag, s.MinLabels), 0, nil
+	case s.MaxLabels > 0 && len(labels) > s.MaxLabels:
+		return signalNotMatch, fmt.Sprintf("pull request has %d labels, more than the %s maximum of %d", len(labels), tag, s.MaxLabels), 0, nil
+	}
+	return signalMatch, fmt.Sprintf("pull request has %d labels, within the %s bounds", len(labels), tag), 0, nil
+}
+
+// doesPrefixedLabelSignalMatch matches pull requests with at least
+// MinPrefixedLabels labels that start with LabelPrefix, ignoring case. Unlike
+// the labels signal, which checks for the presence of specific labels, this
+// counts every label that shares the prefix.
+func (s *Signals) doesPrefixedLabelSignalMatch(ctx context.Context, pullCtx pull.Context, tag string) (signalResult, string, int, error) {
+	if s.MinPrefixedLabels <= 0 {
+		return signalNotFound, "", 0, nil
+	}
+
+	labels, err := pullCtx.Labels(ctx)
+	if err != nil {
+		return signalNotMatch, "unable to list pull request labels", 0, err
+	}
+
+	prefix := strings.ToLower(s.normalizeText(s.LabelPrefix))
+	count := 0
+	for _, label := range labels {
+		if strings.HasPrefix(strings.ToLower(s.normalizeText(label)), prefix) {
+			count++
+		}
+	}
+
+	if count < s.MinPrefixedLabels {
+		return signalNotMatch, fmt.Sprintf("pull request has %d labels with prefix %q, fewer than the %s minimum of %d", count, s.LabelPrefix, tag, s.MinPrefixedLabels), 0, nil
+	}
+	return signalMatch, fmt.Sprintf("pull request has %d labels with prefix %q, meeting the %s minimum of %d", count, s.LabelPrefix, tag, s.MinPrefixedLabels), 0, nil
+}
+
+// DefaultReactionType is the type of reaction counted by the min_reactions
+// signal if the signals do not set one.
+const DefaultReactionType = "+1"
+
+// doesReactionSignalMatch matches pull requests with at least MinReactions
+// reactions of the configured type. Reactions are counted on the pull request
+// body, where each user counts once, or, if ReactionComments is set, summed
+// across the comments that contain any of those substrings. If ReactionUsers
+// is set, only reactions to the body from those users count, and the reason
+// names the users that were counted.
+func (s *Signals) doesReactionSignalMatch(ctx context.Context, pullCtx pull.Context, tag string) (signalResult, string, int, error) {
+	if s.MinReactions <= 0 {
+		return signalNotFound, "", 0, nil
+	}
+
+	content := s.ReactionType
+	if content == "" {
+		content = DefaultReactionType
+	}
+
+	var count int
+	var target string
+	var reactors []string
+	if len(s.ReactionUsers) > 0 && len(s.ReactionComments) > 0 {
+		return signalNotMatch, fmt.Sprintf("%s reaction users cannot be combined with reaction comments", tag), 0, errors.New("reaction_users cannot be combined with reaction_comments")
+	}
+	if len(s.ReactionComments) == 0 {
+		reactions, err := pullCtx.Reactions(ctx)
+		if err != nil {
+			return signalNotMatch, "unable to list pull request reactions", 0, err
+		}
+
+		users := make(map[string]bool)
+		for _, r := range reactions {
+			if r.Content != content || users[strings.ToLower(r.User)] {
+				continue
+			}
+			if len(s.ReactionUsers) > 0 && !isReactionUser(r.User, s.ReactionUsers) {
+				continue
+			}
+			users[strings.ToLower(r.User)] = true
+			reactors = append(reactors, r.User)
+			count++
+		}
+		target = "the pull request body"
+		if len(s.ReactionUsers) > 0 {
+			target = fmt.Sprintf("the pull request body from %s reaction users", tag)
+		}
+	} else {
+		comments, err := pullCtx.AuthoredComments(ctx)
+		if err != nil {
+			return signalNotMatch, "unable to list pull request comments", 0, err
+		}
+
+		var matched int
+		for _, c := range comments {
+			for _, substring := range s.ReactionComments {
+				if s.textContains(c.Body, substring) {
+					count += c.Reactions[content]
+					matched++
+					break
+				}
+			}
+		}
+		if matched == 0 {
+			return signalNotMatch, fmt.Sprintf("pull request has no %s reaction comments", tag), 0, nil
+		}
+		target = fmt.Sprintf("%d %s reaction comments", matched, tag)
+	}
+
+	counted := ""
+	if len(s.ReactionUsers) > 0 && len(reactors) > 0 {
+		counted = ": " + strings.Join(reactors, ", ")
+	}
+	if count < s.MinReactions {
+		return signalNotMatch, fmt.Sprintf("pull request has %d %q reactions on %s, fewer than the %s minimum of %d%s", count, content, target, tag, s.MinReactions, counted), 0, nil
+	}
+	return signalMatch, fmt.Sprintf("pull request has %d %q reactions on %s, meeting the %s minimum of %d%s", count, content, target, tag, s.MinReactions, counted), 0, nil
+}
+
+// isReactionUser returns true if login is one of the users, ignoring case.
+func isReactionUser(login string, users []string) bool {
+	for _, user := range users {
+		if strings.EqualFold(user, login) {
+			return true
+		}
+	}
+	return false
+}
+
+// DefaultQueueLabelPrefix is the prefix of the label that records the
+// position of a pull request in a merge queue, like "queue-position:1".
+const DefaultQueueLabelPrefix = "queue-position:"
+
+// queuePosition returns the position of the pull request in the merge queue
+// from labels like "queue-position:1", where 1 is the front of the queue. If
+// the pull request has more than one position label, the lowest position is
+// used. It returns false if the pull request has no valid position label.
+func (s *Signals) queuePosition(ctx context.Context, pullCtx pull.Context) (int, bool, error) {
+	prefix := s.QueueLabelPrefix
+	if prefix == "" {
+		prefix = DefaultQueueLabelPrefix
+	}
+
+	labels, err := pullCtx.Labels(ctx)
+	if err != nil {
+		return 0, false, err
+	}
+
+	position, found := 0, false
+	for _, label := range labels {
+		if len(label) <= len(prefix) || !strings.EqualFold(label[:len(prefix)], prefix) {
+			continue
+		}
+
+		p, err := strconv.Atoi(strings.TrimSpace(label[len(prefix):]))
+		if err != nil || p < 1 {
+			zerolog.Ctx(ctx).Debug().Msgf("Ignoring invalid queue position label %q", label)
+			continue
+		}
+		if !found || p < position {
+			position, found = p, true
+		}
+	}
+	return position, found, nil
+}
+
+func (s *Signals) doesQueueSignalMatch(ctx context.Context, pullCtx pull.Context, tag string) (signalResult, string, int, error) {
+	if !s.RequireQueueFront {
+		return signalNotFound, "", 0, nil
+	}
+
+	position, found, err := s.queuePosition(ctx, pullCtx)
+	if err != nil {
+		return signalNotMatch, "unable to list pull request labels", 0, err
+	}
+
+	switch {
+	case !found:
+		return signalNotMatch, fmt.Sprintf("pull request is not in the %s queue", tag), 0, nil
+	case position == 1:
+		return signalMatch, fmt.Sprintf("pull request is at the front of the %s queue", tag), 0, nil
+	default:
+		return signalNotMatch, fmt.Sprintf("pull request is at position %d in the %s queue, not the front", position, tag), 0, nil
+	}
+}
+
+func (s *Signals) doesCommentSignalMatch(ctx context.Context, pullCtx pull.Context, tag string) (signalResult, string, int, error) {
+	body := pullCtx.Body()
+	emptyBody := s.matchesBody() && isEmptyBody(ctx, body, s.Comments.Values)
+	comments := s.pullCommentLister(ctx, pullCtx)
+
+	return matchValues("comments", tag, s.Comments.Values, s.matchType(s.Comments), func(signalComment string) (bool, string, error) {
+		if s.matchesBody() && s.textEqual(body, signalComment, false) {
+			return true, fmt.Sprintf("pull request body is a %s comment: %q", tag, signalComment), nil
+		}
+
+		comments, err := comments()
+		if err != nil {
+			return false, "unable to list pull request comments", err
+		}
+		for _, comment := range comments {
+			if s.textEqual(comment.Body, signalComment, false) {
+				if s.OnlyAuthorComments {
+					return true, s.editedNote(comment, fmt.Sprintf("pull request author self-triggered with a %s %s: %q", tag, s.commentNoun(), signalComment)), nil
+				}
+				return true, s.editedNote(comment, fmt.Sprintf("pull request has a %s %s: %q", tag, s.commentNoun(), signalComment)), nil
+			}
+		}
+		if !s.matchesBody() {
+			return false, fmt.Sprintf("pull request does not have a %s %s: %q", tag, s.commentNoun(), signalComment), nil
+		}
+		if emptyBody {
+			return false, fmt.Sprintf("pull request has an empty body and does not have a %s comment: %q", tag, signalComment), nil
+		}
+		return false, fmt.Sprintf("pull request does not have a %s comment: %q", tag, signalComment), nil
+	})
+}
+
+func (s *Signals) doesCommentSubstringSignalMatch(ctx context.Context, pullCtx pull.Context, tag string) (signalResult, string, int, error) {
+	logger := zerolog.Ctx(ctx)
+
+	if len(s.CommentSubstrings.Values) == 0 {
+		logger.Debug().Msgf("No comment substrings found to match against")
+	}
+
+	body := pullCtx.Body()
+	emptyBody := s.matchesBody() && isEmptyBody(ctx, body, s.CommentSubstrings.Values)
+	comments := s.pullCommentLister(ctx, pullCtx)
+
+	return matchValues("comment substrings", tag, s.CommentSubstrings.Values, s.matchType(s.CommentSubstrings), func(signalSubstring string) (bool, string, error) {
+		if s.matchesBody() && s.substringMatches(body, signalSubstring) {
+			return true, fmt.Sprintf("pull request body matches a %s substring: %q", tag, signalSubstring), nil
+		}
+
+		comments, err := comments()
+		if err != nil {
+			return false, "unable to list pull request comments", err
+		}
+		for _, comment := range comments {
+			if s.substringMatches(comment.Body, signalSubstring) {
+				if s.OnlyAuthorComments {
+					return true, s.editedNote(comment, fmt.Sprintf("pull request author self-triggered with a %s matching a %s substring: %q", s.commentNoun(), tag, signalSubstring)), nil
+				}
+				return true, s.editedNote(comment, fmt.Sprintf("pull request %s matches a %s substring: %q", s.commentNoun(), tag, signalSubstring)), nil
+			}
+		}
+		if !s.matchesBody() {
+			return false, fmt.Sprintf("pull request has no %s matching a %s substring: %q", s.commentNoun(), tag, signalSubstring), nil
+		}
+		if emptyBody {
+			return false, fmt.Sprintf("pull request body is empty and comments do not match a %s substring: %q", tag, signalSubstring), nil
+		}
+		return false, fmt.Sprintf("pull request body and comments do not match a %s substring: %q", tag, signalSubstring), nil
+	})
+}
+
+func (s *Signals) doesCommentPatternSignalMatch(ctx context.Context, pullCtx pull.Context, tag string) (signalResult, string, int, error) {
+	body := pullCtx.Body()
+	emptyBody := s.matchesBody() && isEmptyBody(ctx, body, s.CommentPatterns.Values)
+	comments := s.pullCommentLister(ctx, pullCtx)
+
+	return matchValues("comment patterns", tag, s.CommentPatterns.Values, s.matchType(s.CommentPatterns), func(signalPattern string) (bool, string, error) {
+		r, err := regexp.Compile(signalPattern)
+		if err != nil {
+			return false, fmt.Sprintf("invalid %s comment pattern: %q", tag, signalPattern), errors.Wrapf(err, "failed to compile comment pattern %q", signalPattern)
+		}
+
+		if s.matchesBody() && r.MatchString(body) {
+			return true, fmt.Sprintf("pull request body matches a %s comment pattern: %q", tag, signalPattern), nil
+		}
+
+		comments, err := comments()
+		if err != nil {
+			return false, "unable to list pull request comments", err
+		}
+		for _, comment := range comments {
+			if r.MatchString(comment.Body) {
+				if s.OnlyAuthorComments {
+					return true, s.editedNote(comment, fmt.Sprintf("pull request author self-triggered with a %s matching a %s comment pattern: %q", s.commentNoun(), tag, signalPattern)), nil
+				}
+				return true, s.editedNote(comment, fmt.Sprintf("pull request %s matches a %s comment pattern: %q", s.commentNoun(), tag, signalPattern)), nil
+			}
+		}
+		if !s.matchesBody() {
+			return false, fmt.Sprintf("pull request has no %s matching a %s comment pattern: %q", s.commentNoun(), tag, signalPattern), nil
+		}
+		if emptyBody {
+			return false, fmt.Sprintf("pull request body is empty and comments do not match a %s comment pattern: %q", tag, signalPattern), nil
+		}
+		return false, fmt.Sprintf("pull request body and comments do not match a %s comment pattern: %q", tag, signalPattern), nil
+	})
+}
+
+// capturedMethod returns the merge method captured by the comment patterns.
+// The body is checked first, unless CommentsAfterMarker is set, followed by
+// the comments in order, and the last non-empty capture wins so that a later
+// comment can change the method requested by an earlier one.
+func (s *Signals) capturedMethod(ctx context.Context, pullCtx pull.Context) (MergeMethod, error) {
+	if !s.CommentPatterns.isEnabled() {
+		return "", nil
+	}
+
+	var patterns []*regexp.Regexp
+	for _, signalPattern := range s.CommentPatterns.Values {
+		r, err := regexp.Compile(signalPattern)
+		if err != nil {
+			return "", errors.Wrapf(err, "failed to compile comment pattern %q", signalPattern)
+		}
+		if methodGroupIndex(r) > 0 {
+			patterns = append(patterns, r)
+		}
+	}
+	if len(patterns) == 0 {
+		return "", nil
+	}
+
+	comments, err := s.pullCommentLister(ctx, pullCtx)()
+	if err != nil {
+		return "", errors.Wrap(err, "unable to list pull request comments")
+	}
+
+	var texts []string
+	if s.matchesBody() {
+		texts = append(texts, pullCtx.Body())
+	}
+	for _, c := range comments {
+		texts = append(texts, c.Body)
+	}
+
+	var method MergeMethod
+	for _, text := range texts {
+		for _, r := range patterns {
+			m := r.FindStringSubmatch(text)
+			if m == nil {
+				continue
+			}
+			if captured := m[methodGroupIndex(r)]; captured != "" {
+				method = MergeMethod(captured)
+			}
+		}
+	}
+	return method, nil
+}
+
+// methodGroupIndex returns the index of the method group in a pattern, or -1
+// if the pattern does not have the group.
+func methodGroupIndex(r *regexp.Regexp) int {
+	for i, name := range r.SubexpNames() {
+		if name == MethodCaptureGroup {
+			return i
+		}
+	}
+	return -1
+}
+
+// pullCommentLister returns a function that lists the comments on a pull
+// request the first time it is called, so that signals matching the body
+// do not request comments unless needed. If OnlyAuthorComments is set, only
+// comments written by the pull request creator are listed, and if
+// CommentsAfterMarker is set, only comments after the marker are listed. The
+// body of each listed comment is the version chosen by UseOriginalCommentBody.
+func (s *Signals) pullCommentLister(ctx context.Context, pullCtx pull.Context) func() ([]*pull.Comment, error) {
+	var comments []*pull.Comment
+	var loaded bool
+
+	return func() ([]*pull.Comment, error) {
+		if !loaded {
+			c, err := s.listComments(ctx, pullCtx)
+			if err != nil {
+				return nil, err
+			}
+			if len(c) == 0 {
+				zerolog.Ctx(ctx).Debug().Msgf("No comments found to match against")
+			}
+			comments, loaded = c, true
+		}
+		return comments, nil
+	}
+}
+
+func (s *Signals) listComments(ctx context.Context, pullCtx pull.Context) ([]*pull.Comment, error) {
+	all, err := pullCtx.AuthoredComments(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	comments := make([]*pull.Comment, 0, len(all))
+	for _, c := range all {
+		if s.UseOriginalCommentBody && c.Edited {
+			if c.OriginalBody == "" {
+				continue
+			}
+			original := *c
+			original.Body = c.OriginalBody
+			c = &original
+		}
+		comments = append(comments, c)
+	}
+	if s.CommentsAfterMarker != "" {
+		comments = s.commentsAfterMarker(comments)
+	}
+	if !s.OnlyAuthorComments {
+		return comments, nil
+	}
+
+	creator := pullCtx.Creator()
+	var authored []*pull.Comment
+	for _, c := range comments {
+		if creator != "" && strings.EqualFold(c.Author, creator) {
+			authored = append(authored, c)
+		}
+	}
+	return authored, nil
+}
+
+// editedNote adds a note to the reason for a match by a comment that was
+// edited after it was created.
+func (s *Signals) editedNote(c *pull.Comment, reason string) string {
+	switch {
+	case !c.Edited:
+		return reason
+	case s.UseOriginalCommentBody:
+		return reason + " (in the original body of an edited comment)"
+	}
+	return reason + " (in an edited comment)"
+}
+
+// commentsAfterMarker returns the comments posted after the first comment
+// containing CommentsAfterMarker, ordered by creation time. If no comment
+// contains the marker, it returns no comments.
+func (s *Signals) commentsAfterMarker(comments []*pull.Comment) []*pull.Comment {
+	sorted := make([]*pull.Comment, len(comments))
+	copy(sorted, comments)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].CreatedAt.Before(sorted[j].CreatedAt)
+	})
+
+	for i, c := range sorted {
+		if s.textContains(c.Body, s.CommentsAfterMarker) {
+			return sorted[i+1:]
+		}
+	}
+	return nil
+}
+
+// matchesBody returns true if the comment signals also match the pull
+// request body, which is the case unless CommentsAfterMarker is set.
+func (s *Signals) matchesBody() bool {
+	return s.CommentsAfterMarker == ""
+}
+
+// commentNoun describes the comments considered by the comment signals.
+func (s *Signals) commentNoun() string {
+	if s.CommentsAfterMarker != "" {
+		return fmt.Sprintf("comment after marker %q", s.CommentsAfterMarker)
+	}
+	return "comment"
+}
+
+// latestReviewStates returns the state of the latest review from each user
+// that approved, requested changes, or had a review dismissed, keyed by
+// lowercase login. Comment-only reviews do not change whether a user's
+// requested changes are resolved, so they are skipped.
+func latestReviewStates(reviews []*pull.Review) map[string]string {
+	ordered := make([]*pull.Review, len(reviews))
+	copy(ordered, reviews)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return ordered[i].SubmittedAt.Before(ordered[j].SubmittedAt)
+	})
+
+	states := make(map[string]string)
+	for _, r := range ordered {
+		if r.State == "COMMENTED" || r.State == "PENDING" {
+			continue
+		}
+		states[strings.ToLower(r.Author)] = r.State
+	}
+	return states
+}
+
+func (s *Signals) doesReapprovalSignalMatch(ctx context.Context, pullCtx pull.Context, tag string) (signalResult, string, int, error) {
+	if len(s.RequireReapprovalFrom) == 0 {
+		return signalNotFound, "", 0, nil
+	}
+
+	reviews, err := pullCtx.Reviews(ctx)
+	if err != nil {
+		return signalNotMatch, "unable to list pull request reviews", 0, err
+	}
+
+	// A request for changes is resolved by a later approval or dismissal, so
+	// only reviewers whose latest review still requests changes block.
+	states := latestReviewStates(reviews)
+	for _, reviewer := range s.RequireReapprovalFrom {
+		if states[strings.ToLower(reviewer)] == "CHANGES_REQUESTED" {
+			return signalNotMatch, fmt.Sprintf("pull request has not been approved by %s since they requested changes", reviewer), 0, nil
+		}
+	}
+	return signalMatch, fmt.Sprintf("pull request has no unresolved changes requested by %s reviewers", tag), 0, nil
+}
+
+// doesRequiredApprovalSignalMatch matches pull requests with at least as
+// many approvals as the branch protection of the target branch requires.
+// Users are counted if their latest review is an approval.
+func (s *Signals) doesRequiredApprovalSignalMatch(ctx context.Context, pullCtx pull.Context, tag string) (signalResult, string, int, error) {
+	if !s.RespectRequiredApprovals {
+		return signalNotFound, "", 0, nil
+	}
+
+	required, err := pullCtx.RequiredApprovals(ctx)
+	if err != nil {
+		return signalNotMatch, "unable to determine required approvals", 0, err
+	}
+	if required <= 0 {
+		return signalNotFound, "", 0, nil
+	}
+
+	reviews, err := pullCtx.Reviews(ctx)
+	if err != nil {
+		return signalNotMatch, "unable to list pull request reviews", 0, err
+	}
+
+	approvals := 0
+	for _, state := range latestReviewStates(reviews) {
+		if state == "APPROVED" {
+			approvals++
+		}
+	}
+
+	reason := fmt.Sprintf("pull request has %d of %d required approvals", approvals, required)
+	if approvals < required {
+		return signalNotMatch, reason, 0, nil
+	}
+	return signalMatch, reason, 0, nil
+}
+
+// doesConversationResolutionSignalMatch matches pull requests without
+// unresolved review threads if the branch protection of the target branch
+// requires conversation resolution.
+func (s *Signals) doesConversationResolutionSignalMatch(ctx context.Context, pullCtx pull.Context, tag string) (signalResult, string, int, error) {
+	if !s.RespectConversationResolution {
+		return signalNotFound, "", 0, nil
+	}
+
+	required, err := pullCtx.RequiresConversationResolution(ctx)
+	if err != nil {
+		return signalNotMatch, "unable to determine if conversation resolution is required", 0, err
+	}
+	if !required {
+		return signalNotFound, "", 0, nil
+	}
+
+	threads, err := pullCtx.ReviewThreads(ctx)
+	if err != nil {
+		return signalNotMatch, "unable to list pull request review threads", 0, err
+	}
+
+	unresolved := 0
+	for _, t := range threads {
+		if !t.Resolved {
+			unresolved++
+		}
+	}
+
+	if unresolved > 0 {
+		return signalNotMatch, fmt.Sprintf("pull request has %d of %d review threads unresolved, but the target branch requires conversation resolution", unresolved, len(threads)), 0, nil
+	}
+	return signalMatch, fmt.Sprintf("pull request has all %d review threads resolved, as the target branch requires", len(threads)), 0, nil
+}
+
+// doesIndependentApprovalSignalMatch matches pull requests approved by at
+// least ApprovalsExcludingAuthor users other than the author. If
+// ExcludeCoAuthorApprovals is set, users who authored commits on the pull
+// request are not counted either.
+func (s *Signals) doesIndependentApprovalSignalMatch(ctx context.Context, pullCtx pull.Context, tag string) (signalResult, string, int, error) {
+	if s.ApprovalsExcludingAuthor <= 0 {
+		return signalNotFound, "", 0, nil
+	}
+
+	excluded := map[string]bool{strings.ToLower(pullCtx.Creator()): true}
+	if s.ExcludeCoAuthorApprovals {
+		commits, err := pullCtx.Commits(ctx)
+		if err != nil {
+			return signalNotMatch, "unable to list pull request commits", 0, err
+		}
+		for _, c := range commits {
+			if c.Author.Login != "" {
+				excluded[strings.ToLower(c.Author.Login)] = true
+			}
+		}
+	}
+
+	reviews, err := pullCtx.Reviews(ctx)
+	if err != nil {
+		return signalNotMatch, "unable to list pull request reviews", 0, err
+	}
+
+	approvals := 0
+	for login, state := range latestReviewStates(reviews) {
+		if state == "APPROVED" && !excluded[login] {
+			approvals++
+		}
+	}
+
+	if approvals < s.ApprovalsExcludingAuthor {
+		return signalNotMatch, fmt.Sprintf("pull request has %d approvals from users other than its authors, fewer than the %s minimum of %d", approvals, tag, s.ApprovalsExcludingAuthor), 0, nil
+	}
+	return signalMatch, fmt.Sprintf("pull request has %d approvals from users other than its authors, meeting the %s minimum of %d", approvals, tag, s.ApprovalsExcludingAuthor), 0, nil
+}
+
+// isReviewAfterCommit returns true if a review was submitted for the given
+// commit or, if the commit of the review is unknown, after the commit.
+func isReviewAfterCommit(r *pull.Review, c *pull.Commit) bool {
+	if r.CommitID != "" {
+		return r.CommitID == c.SHA
+	}
+	return !r.SubmittedAt.Before(c.CommittedAt)
+}
+
+// doesFreshApprovalSignalMatch matches pull requests with at least one
+// current approval submitted for the last commit. Approvals from before the
+// last commit was pushed are stale and do not count.
+func (s *Signals) doesFreshApprovalSignalMatch(ctx context.Context, pullCtx pull.Context, tag string) (signalResult, string, int, error) {
+	if !s.RequireApprovalAfterLastCommit {
+		return signalNotFound, "", 0, nil
+	}
+
+	commits, err := pullCtx.Commits(ctx)
+	if err != nil {
+		return signalNotMatch, "unable to list pull request commits", 0, err
+	}
+	if len(commits) == 0 {
+		return signalNotFound, "", 0, nil
+	}
+	lastCommit := commits[len(commits)-1]
+
+	reviews, err := pullCtx.Reviews(ctx)
+	if err != nil {
+		return signalNotMatch, "unable to list pull request reviews", 0, err
+	}
+
+	states := latestReviewStates(reviews)
+	var stale []string
+	for _, r := range reviews {
+		if r.State != "APPROVED" || states[strings.ToLower(r.Author)] != "APPROVED" {
+			continue
+		}
+		if isReviewAfterCommit(r, lastCommit) {
+			return signalMatch, fmt.Sprintf("pull request was approved by %s after the last commit %s", r.Author, lastCommit.SHA), 0, nil
+		}
+		stale = append(stale, r.Author)
+	}
+
+	if len(stale) == 0 {
+		return signalNotMatch, "pull request has no approvals", 0, nil
+	}
+	return signalNotMatch, fmt.Sprintf("approval is stale (before last commit %s): approved by %s", lastCommit.SHA, strings.Join(uniqueLogins(stale), ", ")), 0, nil
+}
+
+// uniqueLogins returns the logins in order, without case-insensitive
+// duplicates.
+func uniqueLogins(logins []string) []string {
+	seen := make(map[string]bool)
+	var unique []string
+	for _, login := range logins {
+		if !seen[strings.ToLower(login)] {
+			seen[strings.ToLower(login)] = true
+			unique = append(unique, login)
+		}
+	}
+	return unique
+}
+
+// maxUnresponsiveReviewers returns the largest number of requested reviewers
+// that may not have submitted a review, or -1 if the signals do not limit
+// unresponsive reviewers.
+func (s *Signals) maxUnresponsiveReviewers() int {
+	switch {
+	case s.RequireReviewersResponded:
+		return 0
+	case s.MaxUnresponsiveReviewers > 0:
+		return s.MaxUnresponsiveReviewers
+	default:
+		return -1
+	}
+}
+
+// unresponsiveReviewers returns the requested reviewers that have not
+// submitted any review. Requested teams cannot be matched with the users who
+// review on their behalf, so each requested team counts as one unresponsive
+// reviewer, named with a "team:" prefix.
+func unresponsiveReviewers(ctx context.Context, pullCtx pull.Context) ([]string, error) {
+	requested, err := pullCtx.RequestedReviewers(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	reviews, err := pullCtx.Reviews(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	reviewed := make(map[string]bool)
+	for _, r := range reviews {
+		reviewed[strings.ToLower(r.Author)] = true
+	}
+
+	var unresponsive []string
+	for _, user := range requested.Users {
+		if !reviewed[strings.ToLower(user)] {
+			unresponsive = append(unresponsive, user)
+		}
+	}
+	for _, team := range requested.Teams {
+		unresponsive = append(unresponsive, "team:"+team)
+	}
+	return unresponsive, nil
+}
+
+// doesParticipantSignalMatch matches pull requests with at least
+// MinParticipants distinct users who commented on or reviewed them.
+func (s *Signals) doesParticipantSignalMatch(ctx context.Context, pullCtx pull.Context, tag string) (signalResult, string, int, error) {
+	if s.MinParticipants <= 0 {
+		return signalNotFound, "", 0, nil
+	}
+
+	participants, err := pullCtx.Participants(ctx)
+	if err != nil {
+		return signalNotMatch, "unable to list pull request participants", 0, err
+	}
+
+	count := 0
+	for _, p := range participants {
+		if !(s.ExcludeBotParticipants && p.Bot) {
+			count++
+		}
+	}
+
+	noun := "participants"
+	if s.ExcludeBotParticipants {
+		noun = "participants, excluding bots"
+	}
+	if count < s.MinParticipants {
+		return signalNotMatch, fmt.Sprintf("pull request has %d %s, fewer than the %s minimum of %d", count, noun, tag, s.MinParticipants), 0, nil
+	}
+	return signalMatch, fmt.Sprintf("pull request has %d %s, at least the %s minimum of %d", count, noun, tag, s.MinParticipants), 0, nil
+}
+
+// doesForcePushSignalMatch matches pull requests with a force-push event on
+// their timeline after the latest approving review.
+func (s *Signals) doesForcePushSignalMatch(ctx context.Context, pullCtx pull.Context, tag string) (signalResult, string, int, error) {
+	if !s.BlockForcePushSinceApproval {
+		return signalNotFound, "", 0, nil
+	}
+
+	reviews, err := pullCtx.Reviews(ctx)
+	if err != nil {
+		return signalNotMatch, "unable to list pull request reviews", 0, err
+	}
+
+	var approvedAt time.Time
+	for _, r := range reviews {
+		if r.State == "APPROVED" && r.SubmittedAt.After(approvedAt) {
+			approvedAt = r.SubmittedAt
+		}
+	}
+	if approvedAt.IsZero() {
+		return signalNotMatch, "pull request has no approvals", 0, nil
+	}
+
+	timeline, err := pullCtx.Timeline(ctx)
+	if err != nil {
+		return signalNotMatch, "unable to list pull request timeline", 0, err
+	}
+
+	var forcePush *pull.TimelineEvent
+	for _, e := range timeline {
+		if e.Event == "head_ref_force_pushed" && e.CreatedAt.After(approvedAt) && (forcePush == nil || e.CreatedAt.Before(forcePush.CreatedAt)) {
+			forcePush = e
+		}
+	}
+
+	approved := approvedAt.UTC().Format(time.RFC3339)
+	if forcePush == nil {
+		return signalNotMatch, fmt.Sprintf("pull request was not force-pushed after the latest approval (approved %s)", approved), 0, nil
+	}
+	return signalMatch, fmt.Sprintf("pull request was force-pushed by %q at %s, after the latest approval (approved %s)", forcePush.Actor, forcePush.CreatedAt.UTC().Format(time.RFC3339), approved), 0, nil
+}
+
+// doesReviewRoundSignalMatch matches pull requests with at most
+// MaxReviewRounds review rounds.
+func (s *Signals) doesReviewRoundSignalMatch(ctx context.Context, pullCtx pull.Context, tag string) (signalResult, string, int, error) {
+	if s.MaxReviewRounds <= 0 {
+		return signalNotFound, "", 0, nil
+	}
+
+	reviews, err := pullCtx.Reviews(ctx)
+	if err != nil {
+		return signalNotMatch, "unable to list pull request reviews", 0, err
+	}
+
+	rounds := reviewRounds(reviews)
+	if rounds > s.MaxReviewRounds {
+		return signalNotMatch, fmt.Sprintf("pull request has %d review rounds, exceeding the %s limit of %d", rounds, tag, s.MaxReviewRounds), 0, nil
+	}
+	return signalMatch, fmt.Sprintf("pull request has %d review rounds, within the %s limit of %d", rounds, tag, s.MaxReviewRounds), 0, nil
+}
+
+// reviewRounds returns the number of review rounds in the history of a pull
+// request. Reviews are ordered by submission time, regardless of reviewer,
+// and a round ends at each approval that follows at least one request for
+// changes since the previous round ended. Requests for changes that are not
+// yet followed by an approval do not count, and neither do comment-only
+// reviews or approvals without earlier requests for changes, so
+// "changes, changes, approve" is one round and "changes, approve, changes,
+// approve" is two.
+func reviewRounds(reviews []*pull.Review) int {
+	ordered := make([]*pull.Review, len(reviews))
+	copy(ordered, reviews)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return ordered[i].SubmittedAt.Before(ordered[j].SubmittedAt)
+	})
+
+	rounds := 0
+	changesRequested := false
+	for _, r := range ordered {
+		switch r.State {
+		case "CHANGES_REQUESTED":
+			changesRequested = true
+		case "APPROVED":
+			if changesRequested {
+				rounds++
+				changesRequested = false
+			}
+		}
+	}
+	return rounds
+}
+
+func (s *Signals) doesUnresponsiveReviewerSignalMatch(ctx context.Context, pullCtx pull.Context, tag string) (signalResult, string, int, error) {
+	maxUnresponsive := s.maxUnresponsiveReviewers()
+	if maxUnresponsive < 0 {
+		return signalNotFound, "", 0, nil
+	}
+
+	unresponsive, err := unresponsiveReviewers(ctx, pullCtx)
+	if err != nil {
+		return signalNotMatch, "unable to determine unresponsive reviewers", 0, err
+	}
+
+	if len(unresponsive) > maxUnresponsive {
+		return signalNotMatch, fmt.Sprintf("pull request has %d requested reviewers without a review, more than the %s limit of %d: %s", len(unresponsive), tag, maxUnresponsive, strings.Join(unresponsive, ", ")), 0, nil
+	}
+	return signalMatch, fmt.Sprintf("pull request has %d requested reviewers without a review, within the %s limit of %d", len(unresponsive), tag, maxUnresponsive), 0, nil
+}
+
+var (
+	dependsOnLine      = regexp.MustCompile(`(?im)^\s*depends[- ]on:?(.*)$`)
+	pullRequestNumbers = regexp.MustCompile(`#(\d+)\b`)
+)
+
+// parseDependsOn returns the numbers of the pull requests referenced by
+// "depends-on: #123" lines in a pull request body, in order and without
+// duplicates. A line may reference more than one pull request.
+func parseDependsOn(body string) []int {
+	var numbers []int
+	seen := make(map[int]bool)
+	for _, line := range dependsOnLine.FindAllStringSubmatch(body, -1) {
+		for _, ref := range pullRequestNumbers.FindAllStringSubmatch(line[1], -1) {
+			number, err := strconv.Atoi(ref[1])
+			if err != nil || seen[number] {
+				continue
+			}
+			seen[number] = true
+			numbers = append(numbers, number)
+		}
+	}
+	return numbers
+}
+
+func (s *Signals) doesDependsOnSignalMatch(ctx context.Context, pullCtx pull.Context, tag string) (signalResult, string, int, error) {
+	if !s.RespectDependsOn {
+		return signalNotFound, "", 0, nil
+	}
+
+	dependencies := parseDependsOn(pullCtx.Body())
+	if len(dependencies) == 0 {
+		return signalNotFound, "", 0, nil
+	}
+
+	for _, number := range dependencies {
+		state, err := pullCtx.PullRequestState(ctx, number)
+		if err != nil {
+			return signalNotMatch, fmt.Sprintf("unable to determine if dependency #%d is merged", number), 0, err
+		}
+		if !state.Merged {
+			return signalNotMatch, fmt.Sprintf("pull request depends on #%d, which is %s and not merged", number, state.State), 0, nil
+		}
+	}
+	return signalMatch, fmt.Sprintf("pull request dependencies are merged: %s", formatPullRequestNumbers(dependencies)), 0, nil
+}
+
+// doesStackBottomSignalMatch matches pull requests that are not stacked on
+// another open pull request, which is detected by looking for open pull
+// requests whose head branch is the target branch of this pull request.
+// The reason names the first pull request that must be merged first.
+func (s *Signals) doesStackBottomSignalMatch(ctx context.Context, pullCtx pull.Context, tag string) (signalResult, string, int, error) {
+	if !s.OnlyMergeStackBottom {
+		return signalNotFound, "", 0, nil
+	}
+
+	base, _ := pullCtx.Branches()
+	numbers, err := pullCtx.OpenPullRequestsWithHead(ctx, base)
+	if err != nil {
+		return signalNotMatch, fmt.Sprintf("unable to list open pull requests from target branch %q", base), 0, err
+	}
+
+	for _, number := range numbers {
+		if number != pullCtx.Number() {
+			return signalNotMatch, fmt.Sprintf("pull request is stacked on #%d, which is open with head branch %q", number, base), 0, nil
+		}
+	}
+	return signalMatch, fmt.Sprintf("pull request target branch %q is not the head branch of an open pull request", base), 0, nil
+}
+
+var closingIssueReference = regexp.MustCompile(`(?i)\b(?:close[sd]?|fix(?:e[sd])?|resolve[sd]?):?\s+#(\d+)\b`)
+
+// parseClosingIssues returns the numbers of the issues in the same
+// repository that a pull request body closes using keywords like
+// "fixes #123", in order and without duplicates.
+func parseClosingIssues(body string) []int {
+	var numbers []int
+	seen := make(map[int]bool)
+	for _, ref := range closingIssueReference.FindAllStringSubmatch(body, -1) {
+		number, err := strconv.Atoi(ref[1])
+		if err != nil || seen[number] {
+			continue
+		}
+		seen[number] = true
+		numbers = append(numbers, number)
+	}
+	return numbers
+}
+
+func (s *Signals) doesClosedIssueSignalMatch(ctx context.Context, pullCtx pull.Context, tag string) (signalResult, string, int, error) {
+	if len(s.ClosesIssuesWithLabels) == 0 {
+		return signalNotFound, "", 0, nil
+	}
+
+	issues := parseClosingIssues(pullCtx.Body())
+	if len(issues) == 0 {
+		return signalNotMatch, "pull request does not close any issues", 0, nil
+	}
+
+	for _, number := range issues {
+		issue, err := pullCtx.Issue(ctx, number)
+		if err != nil {
+			return signalNotMatch, fmt.Sprintf("unable to get issue #%d", number), 0, err
+		}
+		if issue == nil {
+			continue
+		}
+		for i, signalLabel := range s.ClosesIssuesWithLabels {
+			for _, label := range issue.Labels {
+				if s.textEqual(signalLabel, label, true) {
+					return signalMatch, fmt.Sprintf("pull request closes issue #%d, which has a %s label: %q", number, tag, signalLabel), i + 1, nil
+				}
+			}
+		}
+	}
+	return signalNotMatch, fmt.Sprintf("pull request does not close an issue with a %s label: %s", tag, formatPullRequestNumbers(issues)), 0, nil
+}
+
+// BranchIssueConvention requires the head branch of a pull request to name
+// an issue in the same repository, like "1234-fix-thing" for issue 1234.
+type BranchIssueConvention struct {
+	// Pattern is a regular expression matched against the head branch. The
+	// first capture group is the issue number. If empty,
+	// DefaultBranchIssuePattern is used.
+	Pattern string `yaml:"pattern"`
+
+	// State is the state the issue must have, like "open" or "closed". If
+	// empty, the issue must be open.
+	State string `yaml:"state"`
+
+	// RequireAssignee requires the issue to have at least one assignee.
+	RequireAssignee bool `yaml:"require_assignee"`
+}
+
+// DefaultBranchIssuePattern matches branches that start with an issue
+// number followed by a dash.
+const DefaultBranchIssuePattern = `^(\d+)-`
+
+func (s *Signals) doesBranchIssueSignalMatch(ctx context.Context, pullCtx pull.Context, tag string) (signalResult, string, int, error) {
+	convention := s.BranchIssueConvention
+	if convention == nil {
+		return signalNotFound, "", 0, nil
+	}
+
+	pattern := convention.Pattern
+	if pattern == "" {
+		pattern = DefaultBranchIssuePattern
+	}
+	state := convention.State
+	if state == "" {
+		state = "open"
+	}
+
+	r, err := regexp.Compile(pattern)
+	if err != nil {
+		return signalNotMatch, fmt.Sprintf("invalid %s branch issue pattern: %q", tag, pattern), 0, errors.Wrapf(err, "failed to compile branch issue pattern %q", pattern)
+	}
+
+	_, headBranch := pullCtx.Branches()
+	m := r.FindStringSubmatch(headBranch)
+	if len(m) < 2 {
+		return signalNotMatch, fmt.Sprintf("head branch %q does not reference an issue", headBranch), 0, nil
+	}
+	number, err := strconv.Atoi(m[1])
+	if err != nil {
+		return signalNotMatch, fmt.Sprintf("head branch %q does not reference an issue", headBranch), 0, nil
+	}
+
+	issue, err := pullCtx.Issue(ctx, number)
+	if err != nil {
+		return signalNotMatch, fmt.Sprintf("unable to get issue #%d", number), 0, err
+	}
+
+	switch {
+	case issue == nil:
+		return signalNotMatch, fmt.Sprintf("head branch %q references issue #%d, which does not exist", headBranch, number), 0, nil
+	case !strings.EqualFold(issue.State, state):
+		return signalNotMatch, fmt.Sprintf("head branch %q references issue #%d, which is %s instead of %s", headBranch, number, issue.State, state), 0, nil
+	case convention.RequireAssignee && len(issue.Assignees) == 0:
+		return signalNotMatch, fmt.Sprintf("head branch %q references issue #%d, which has no assignees", headBranch, number), 0, nil
+	}
+	return signalMatch, fmt.Sprintf("head branch %q references %s issue #%d", headBranch, issue.State, number), 0, nil
+}
+
+// RFCReference requires the title or body of a pull request to reference an
+// RFC or design discussion, like "RFC-42" or the URL of a GitHub discussion.
+type RFCReference struct {
+	// Pattern is a regular expression matching a reference. If empty,
+	// DefaultRFCReferencePattern is used.
+	Pattern string `yaml:"pattern"`
+
+	// VerifyDiscussions requires references to GitHub discussions, like
+	// "https://github.com/owner/repo/discussions/12", to name a discussion
+	// that exists. Other references are accepted as written, so the signal
+	// does not make API requests unless this is set.
+	VerifyDiscussions bool `yaml:"verify_discussions"`
+}
+
+// DefaultRFCReferencePattern matches references like "RFC-42".
+const DefaultRFCReferencePattern = `RFC-\d+`
+
+var discussionURL = regexp.MustCompile(`https?://[^/\s]+/([^/\s]+)/([^/\s]+)/discussions/(\d+)`)
+
+// doesRFCReferenceSignalMatch matches pull requests whose title or body
+// contains a reference matching the RFCReference pattern. The title is
+// searched before the body, and the first valid reference decides the
+// result. If no reference is valid, the reason names the first discussion
+// that does not exist.
+func (s *Signals) doesRFCReferenceSignalMatch(ctx context.Context, pullCtx pull.Context, tag string) (signalResult, string, int, error) {
+	reference := s.RequireRFCReference
+	if reference == nil {
+		return signalNotFound, "", 0, nil
+	}
+
+	pattern := reference.Pattern
+	if pattern == "" {
+		pattern = DefaultRFCReferencePattern
+	}
+	r, err := regexp.Compile(pattern)
+	if err != nil {
+		return signalNotMatch, fmt.Sprintf("invalid %s RFC reference pattern: %q", tag, pattern), 0, errors.Wrapf(err, "failed to compile RFC reference pattern %q", pattern)
+	}
+
+	missing := ""
+	for _, text := range []string{pullCtx.Title(), pullCtx.Body()} {
+		for _, ref := range r.FindAllString(text, -1) {
+			m := discussionURL.FindStringSubmatch(ref)
+			if !reference.VerifyDiscussions || m == nil {
+				return signalMatch, fmt.Sprintf("pull request references a %s RFC: %q", tag, ref), 0, nil
+			}
+
+			exists := false
+			if number, err := strconv.Atoi(m[3]); err == nil {
+				exists, err = pullCtx.DiscussionExists(ctx, m[1], m[2], number)
+				if err != nil {
+					return signalNotMatch, fmt.Sprintf("unable to check discussion %q", ref), 0, err
+				}
+			}
+			if exists {
+				return signalMatch, fmt.Sprintf("pull request references an existing %s RFC discussion: %q", tag, ref), 0, nil
+			}
+			if missing == "" {
+				missing = ref
+			}
+		}
+	}
+
+	if missing != "" {
+		return signalNotMatch, fmt.Sprintf("pull request references %s RFC discussion %q, which does not exist", tag, missing), 0, nil
+	}
+	return signalNotMatch, fmt.Sprintf("pull request title and body do not contain a %s RFC reference matching %q", tag, pattern), 0, nil
+}
+
+func formatPullRequestNumbers(numbers []int) string {
+	refs := make([]string, len(numbers))
+	for i, number := range numbers {
+		refs[i] = fmt.Sprintf("#%d", number)
+	}
+	return strings.Join(refs, ", ")
+}
+
+func (s *Signals) doesDefaultBaseSignalMatch(ctx context.Context, pullCtx pull.Context, tag string) (signalResult, string, int, error) {
+	if !s.RequireDefaultBaseBranch {
+		return signalNotFound, "", 0, nil
+	}
+
+	defaultBranch, err := pullCtx.DefaultBranch(ctx)
+	if err != nil {
+		return signalNotMatch, "unable to determine the default branch", 0, err
+	}
+
+	targetBranch, _ := pullCtx.Branches()
+	if targetBranch == defaultBranch {
+		return signalMatch, fmt.Sprintf("pull request target branch (%q) is the %s default branch (%q)", targetBranch, tag, defaultBranch), 0, nil
+	}
+	return signalNotMatch, fmt.Sprintf("pull request target branch (%q) is not the %s default branch (%q)", targetBranch, tag, defaultBranch), 0, nil
+}
+
+func (s *Signals) doesRepoTopicSignalMatch(ctx context.Context, pullCtx pull.Context, tag string) (signalResult, string, int, error) {
+	if len(s.RepoTopics) == 0 {
+		return signalNotFound, "", 0, nil
+	}
+
+	topics, err := pullCtx.Topics(ctx)
+	if err != nil {
+		return signalNotMatch, "unable to list repository topics", 0, err
+	}
+
+	for i, signalTopic := range s.RepoTopics {
+		for _, topic := range topics {
+			if strings.EqualFold(topic, signalTopic) {
+				return signalMatch, fmt.Sprintf("repository has a %s topic: %q", tag, topic), i + 1, nil
+			}
+		}
+	}
+	return signalNotMatch, fmt.Sprintf("repository does not have any %s topics", tag), 0, nil
+}
+
+func (s *Signals) doesRepoPropertySignalMatch(ctx context.Context, pullCtx pull.Context, tag string) (signalResult, string, int, error) {
+	if len(s.RepoProperties) == 0 {
+		return signalNotFound, "", 0, nil
+	}
+
+	properties, err := pullCtx.RepositoryProperties(ctx)
+	if err != nil {
+		return signalNotMatch, "unable to list repository properties", 0, err
+	}
+
+	names := make([]string, 0, len(s.RepoProperties))
+	for name := range s.RepoProperties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		for _, value := range properties[name] {
+			if s.textEqual(s.RepoProperties[name], value, false) {
+				return signalMatch, fmt.Sprintf("repository has the %s property %q set to %q", tag, name, value), 0, nil
+			}
+		}
+	}
+	return signalNotMatch, fmt.Sprintf("repository does not have any %s property values", tag), 0, nil
+}
+
+func (s *Signals) doesProtectedBaseSignalMatch(ctx context.Context, pullCtx pull.Context, tag string) (signalResult, string, int, error) {
+	if !s.RequireProtectedBase {
+		return signalNotFound, "", 0, nil
+	}
+
+	targetBranch, _ := pullCtx.Branches()
+	protected, err := pullCtx.IsBranchProtected(ctx, targetBranch)
+	if err != nil {
+		return signalNotMatch, fmt.Sprintf("unable to determine if target branch %q is protected", targetBranch), 0, err
+	}
+	if protected {
+		return signalMatch, fmt.Sprintf("pull request target branch (%q) is a protected %s branch", targetBranch, tag), 0, nil
+	}
+	return signalNotMatch, fmt.Sprintf("pull request target branch (%q) is not a protected %s branch", targetBranch, tag), 0, nil
+}
+
+// doesBaseBranchExistSignalMatch matches pull requests whose target branch
+// still exists. If GitHub cannot say whether the branch exists, it returns an
+// error instead of treating the branch as deleted, so the pull request is not
+// merged either way.
+func (s *Signals) doesBaseBranchExistSignalMatch(ctx context.Context, pullCtx pull.Context, tag string) (signalResult, string, int, error) {
+	if !s.RequireBaseBranchExists {
+		return signalNotFound, "", 0, nil
+	}
+
+	targetBranch, _ := pullCtx.Branches()
+	exists, err := pullCtx.BranchExists(ctx, targetBranch)
+	if err != nil {
+		return signalNotMatch, fmt.Sprintf("unable to determine if target branch %q exists", targetBranch), 0, err
+	}
+	if !exists {
+		return signalNotMatch, fmt.Sprintf("pull request target branch (%q) was deleted", targetBranch), 0, nil
+	}
+	return signalMatch, fmt.Sprintf("pull request target branch (%q) exists in the %s repository", targetBranch, tag), 0, nil
+}
+
+// minChecks returns the minimum number of status checks required by the
+// signals, or zero if there is no minimum.
+func (s *Signals) minChecks() int {
+	if s.RequireChecksPresent && s.MinChecks < 1 {
+		return 1
+	}
+	return s.MinChecks
+}
+
+func (s *Signals) doesCheckCountSignalMatch(ctx context.Context, pullCtx pull.Context, tag string) (signalResult, string, int, error) {
+	minChecks := s.minChecks()
+	if minChecks <= 0 {
+		return signalNotFound, "", 0, nil
+	}
+
+	statuses, err := pullCtx.Statuses(ctx)
+	if err != nil {
+		return signalNotMatch, "unable to list pull request status checks", 0, err
+	}
+
+	if len(statuses) < minChecks {
+		return signalNotMatch, fmt.Sprintf("pull request has %d status checks, fewer than the %s minimum of %d", len(statuses), tag, minChecks), 0, nil
+	}
+	return signalMatch, fmt.Sprintf("pull request has %d status checks, meeting the %s minimum of %d", len(statuses), tag, minChecks), 0, nil
+}
+
+// doesStatusContextSignalMatch matches pull requests where every context in
+// RequiredStatusContextsPresent reported a commit status or check run on the
+// head commit, whatever its state.
+func (s *Signals) doesStatusContextSignalMatch(ctx context.Context, pullCtx pull.Context, tag string) (signalResult, string, int, error) {
+	if len(s.RequiredStatusContextsPresent) == 0 {
+		return signalNotFound, "", 0, nil
+	}
+
+	statuses, err := pullCtx.Statuses(ctx)
+	if err != nil {
+		return signalNotMatch, "unable to list pull request status checks", 0, err
+	}
+
+	present := make(map[string]bool)
+	for _, status := range statuses {
+		present[status.Context] = true
+	}
+
+	var missing []string
+	for _, name := range s.RequiredStatusContextsPresent {
+		if !present[name] {
+			missing = append(missing, fmt.Sprintf("%q", name))
+		}
+	}
+	if len(missing) > 0 {
+		return signalNotMatch, fmt.Sprintf("pull request is missing %s status contexts: %s", tag, strings.Join(missing, ", ")), 0, nil
+	}
+	return signalMatch, fmt.Sprintf("pull request has all %d %s status contexts", len(s.RequiredStatusContextsPresent), tag), 0, nil
+}
+
+// doesHoldStatusSignalMatch matches pull requests where any context in
+// HoldStatuses reported a commit status or check run on the head commit that
+// is not successful. Unlike the required statuses, which block until they
+// succeed, a hold only applies while it is present and failing or pending.
+func (s *Signals) doesHoldStatusSignalMatch(ctx context.Context, pullCtx pull.Context, tag string) (signalResult, string, int, error) {
+	if len(s.HoldStatuses) == 0 {
+		return signalNotFound, "", 0, nil
+	}
+
+	statuses, err := pullCtx.Statuses(ctx)
+	if err != nil {
+		return signalNotMatch, "unable to list pull request status checks", 0, err
+	}
+
+	states := make(map[string]string)
+	for _, status := range statuses {
+		states[status.Context] = status.State
+	}
+
+	for i, name := range s.HoldStatuses {
+		if state, ok := states[name]; ok && state != "success" {
+			return signalMatch, fmt.Sprintf("pull request has a %s hold status: %q is %q", tag, name, state), i + 1, nil
+		}
+	}
+	return signalNotMatch, fmt.Sprintf("pull request does not have a failing or pending %s hold status", tag), 0, nil
+}
+
+// doesCheckAnnotationSignalMatch matches pull requests with a check run that
+// reports an annotation message or summary matching one of the patterns for
+// the check in CheckAnnotationPatterns. Checks are evaluated in name order.
+func (s *Signals) doesCheckAnnotationSignalMatch(ctx context.Context, pullCtx pull.Context, tag string) (signalResult, string, int, error) {
+	if len(s.CheckAnnotationPatterns) == 0 {
+		return signalNotFound, "", 0, nil
+	}
+
+	checks := make([]string, 0, len(s.CheckAnnotationPatterns))
+	for check := range s.CheckAnnotationPatterns {
+		checks = append(checks, check)
+	}
+	sort.Strings(checks)
+
+	for _, check := range checks {
+		var patterns []*regexp.Regexp
+		for _, pattern := range s.CheckAnnotationPatterns[check] {
+			r, err := regexp.Compile(pattern)
+			if err != nil {
+				return signalNotMatch, fmt.Sprintf("invalid %s annotation pattern for check %q: %q", tag, check, pattern), 0, errors.Wrapf(err, "failed to compile annotation pattern %q", pattern)
+			}
+			patterns = append(patterns, r)
+		}
+
+		outputs, err := pullCtx.CheckOutputs(ctx, check)
+		if err != nil {
+			return signalNotMatch, fmt.Sprintf("unable to get the output of check %q", check), 0, err
+		}
+
+		for _, r := range patterns {
+			for _, output := range outputs {
+				for _, a := range output.Annotations {
+					if r.MatchString(a.Message) {
+						return signalMatch, fmt.Sprintf("pull request check %q has an annotation on %s:%d matching a %s pattern: %q", check, a.Path, a.Line, tag, r.String()), 0, nil
+					}
+				}
+				if r.MatchString(output.Summary) {
+					return signalMatch, fmt.Sprintf("pull request check %q has a summary matching a %s pattern: %q", check, tag, r.String()), 0, nil
+				}
+			}
+		}
+	}
+	return signalNotMatch, fmt.Sprintf("pull request checks have no annotations matching %s patterns", tag), 0, nil
+}
+
+// DefaultCoverageStatus is the status that reports patch coverage if
+// CoverageStatus is not set.
+const DefaultCoverageStatus = "codecov/patch"
+
+// doesCoverageSignalMatch matches pull requests with a coverage status that
+// reports at least MinPatchCoverage percent coverage. The status does not
+// need to be successful, since coverage bots often fail statuses below their
+// own target.
+func (s *Signals) doesCoverageSignalMatch(ctx context.Context, pullCtx pull.Context, tag string) (signalResult, string, int, error) {
+	if s.MinPatchCoverage <= 0 {
+		return signalNotFound, "", 0, nil
+	}
+
+	statusContext := s.CoverageStatus
+	if statusContext == "" {
+		statusContext = DefaultCoverageStatus
+	}
+
+	statuses, err := pullCtx.Statuses(ctx)
+	if err != nil {
+		return signalNotMatch, "unable to list pull request statuses", 0, err
+	}
+
+	for _, status := range statuses {
+		if status.Context != statusContext {
+			continue
+		}
+
+		coverage, err := parseCoverage(status.Description)
+		if err != nil {
+			return signalNotMatch, fmt.Sprintf("unable to parse coverage from status %q: %q", statusContext, status.Description), 0, err
+		}
+		if coverage < s.MinPatchCoverage {
+			return signalNotMatch, fmt.Sprintf("pull request status %q reports %s%% coverage, below the %s minimum of %s%%", statusContext, formatPercent(coverage), tag, formatPercent(s.MinPatchCoverage)), 0, nil
+		}
+		return signalMatch, fmt.Sprintf("pull request status %q reports %s%% coverage, meeting the %s minimum of %s%%", statusContext, formatPercent(coverage), tag, formatPercent(s.MinPatchCoverage)), 0, nil
+	}
+	return signalNotMatch, fmt.Sprintf("pull request does not have a %s coverage status: %q", tag, statusContext), 0, nil
+}
+
+var coveragePattern = regexp.MustCompile(`(\d+(?:\.\d+)?)\s*%`)
+
+// parseCoverage returns the first percentage in a status description, like
+// 85.5 for "85.50% of diff hit (target 80.00%)".
+func parseCoverage(description string) (float64, error) {
+	m := coveragePattern.FindStringSubmatch(description)
+	if m == nil {
+		return 0, errors.Errorf("no coverage percentage in status description %q", description)
+	}
+	coverage, err := strconv.ParseFloat(m[1], 64)
+	if err != nil {
+		return 0, errors.Wrapf(err, "invalid coverage percentage %q", m[1])
+	}
+	return coverage, nil
+}
+
+// formatPercent formats a percentage with at most two decimal places.
+func formatPercent(p float64) string {
+	return strconv.FormatFloat(math.Round(p*100)/100, 'f', -1, 64)
+}
+
+// doesWorkflowSignalMatch matches pull requests where the latest run of each
+// of RequiredWorkflows for the head commit completed successfully. The reason
+// for a failure distinguishes workflows that did not run from those whose
+// latest run is pending or failed.
+func (s *Signals) doesWorkflowSignalMatch(ctx context.Context, pullCtx pull.Context, tag string) (signalResult, string, int, error) {
+	if len(s.RequiredWorkflows) == 0 {
+		return signalNotFound, "", 0, nil
+	}
+
+	runs, err := pullCtx.WorkflowRuns(ctx)
+	if err != nil {
+		return signalNotMatch, "unable to list pull request workflow runs", 0, err
+	}
+
+	for _, workflow := range s.RequiredWorkflows {
+		var latest *pull.WorkflowRun
+		for _, r := range runs {
+			if r.Name == workflow && (latest == nil || r.CreatedAt.After(latest.CreatedAt)) {
+				latest = r
+			}
+		}
+
+		switch {
+		case latest == nil:
+			return signalNotMatch, fmt.Sprintf("pull request head commit has no run of the %s workflow %q", tag, workflow), 0, nil
+		case latest.Status != "completed":
+			return signalNotMatch, fmt.Sprintf("pull request %s workflow %q has not completed: latest run is %q", tag, workflow, latest.Status), 0, nil
+		case latest.Conclusion != "success":
+			return signalNotMatch, fmt.Sprintf("pull request %s workflow %q did not succeed: latest run concluded %q", tag, workflow, latest.Conclusion), 0, nil
+		}
+	}
+	return signalMatch, fmt.Sprintf("pull request has successful runs of all %d %s workflows", len(s.RequiredWorkflows), tag), 0, nil
+}
+
+// CLACheck identifies how a contributor license agreement (CLA) bot reports
+// that the author of a pull request signed the agreement: with a commit
+// status or check run, or with a label. If both are set, either indicator is
+// enough.
+type CLACheck struct {
+	// Status is the commit status context or check run name reported by the
+	// CLA bot. It must have the state "success".
+	Status string `yaml:"status"`
+
+	// Label is the label the CLA bot applies once the agreement is signed.
+	Label string `yaml:"label"`
+}
+
+// doesCLASignalMatch matches pull requests whose author signed the CLA, as
+// indicated by a successful status or a label.
+func (s *Signals) doesCLASignalMatch(ctx context.Context, pullCtx pull.Context, tag string) (signalResult, string, int, error) {
+	cla := s.RequireCLA
+	if cla == nil || (cla.Status == "" && cla.Label == "") {
+		return signalNotFound, "", 0, nil
+	}
+
+	var checked []string
+	if cla.Status != "" {
+		statuses, err := pullCtx.Statuses(ctx)
+		if err != nil {
+			return signalNotMatch, "unable to list pull request status checks", 0, err
+		}
+
+		state := ""
+		for _, status := range statuses {
+			if status.Context == cla.Status {
+				state = status.State
+			}
+		}
+		if state == "success" {
+			return signalMatch, fmt.Sprintf("pull request has a successful %s CLA status: %q", tag, cla.Status), 0, nil
+		}
+		if state == "" {
+			checked = append(checked, fmt.Sprintf("status %q is missing", cla.Status))
+		} else {
+			checked = append(checked, fmt.Sprintf("status %q is %q", cla.Status, state))
+		}
+	}
+
+	if cla.Label != "" {
+		labels, err := pullCtx.Labels(ctx)
+		if err != nil {
+			return signalNotMatch, "unable to list pull request labels", 0, err
+		}
+
+		for _, label := range labels {
+			if s.textEqual(cla.Label, label, true) {
+				return signalMatch, fmt.Sprintf("pull request has the %s CLA label: %q", tag, cla.Label), 0, nil
+			}
+		}
+		checked = append(checked, fmt.Sprintf("label %q is missing", cla.Label))
+	}
+
+	return signalNotMatch, fmt.Sprintf("pull request author has not signed the %s CLA: %s", tag, strings.Join(checked, " and ")), 0, nil
+}
+
+// doesCheckAgeSignalMatch matches pull requests where the latest success of
+// each checked status is at most MaxCheckAge old. The reason reports the age
+// of the oldest of these, or names a required check that has not succeeded.
+func (s *Signals) doesCheckAgeSignalMatch(ctx context.Context, pullCtx pull.Context, tag string) (signalResult, string, int, error) {
+	if s.MaxCheckAge <= 0 {
+		return signalNotFound, "", 0, nil
+	}
+
+	required, err := pullCtx.RequiredStatuses(ctx)
+	if err != nil {
+		return signalNotMatch, "unable to determine required status checks", 0, err
+	}
+
+	statuses, err := pullCtx.Statuses(ctx)
+	if err != nil {
+		return signalNotMatch, "unable to list pull request status checks", 0, err
+	}
+
+	latest := make(map[string]time.Time)
+	for _, status := range statuses {
+		if status.State == "success" && status.CompletedAt.After(latest[status.Context]) {
+			latest[status.Context] = status.CompletedAt
+		}
+	}
+
+	checks := required
+	if len(checks) == 0 {
+		for statusContext := range latest {
+			checks = append(checks, statusContext)
+		}
+		sort.Strings(checks)
+	}
+	if len(checks) == 0 {
+		return signalNotMatch, "pull request has no successful status checks", 0, nil
+	}
+
+	oldest, oldestAt := "", time.Time{}
+	for _, check := range checks {
+		completedAt, ok := latest[check]
+		if !ok {
+			return signalNotMatch, fmt.Sprintf("pull request required status check %q has not succeeded", check), 0, nil
+		}
+		if oldest == "" || completedAt.Before(oldestAt) {
+			oldest, oldestAt = check, completedAt
+		}
+	}
+
+	age := now().Sub(oldestAt).Round(time.Second)
+	if age > s.MaxCheckAge {
+		return signalNotMatch, fmt.Sprintf("pull request status check %q last succeeded %s ago, more than the %s maximum of %s", oldest, age, tag, s.MaxCheckAge), 0, nil
+	}
+	return signalMatch, fmt.Sprintf("pull request status checks last succeeded at most %s ago, within the %s maximum of %s", age, tag, s.MaxCheckAge), 0, nil
+}
+
+// adminBypasses returns descriptions of the branch protection requirements
+// of the target branch that the pull request does not meet, which only an
+// administrator could bypass when merging.
+func adminBypasses(ctx context.Context, pullCtx pull.Context) ([]string, error) {
+	var bypasses []string
+
+	required, err := pullCtx.RequiredStatuses(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to determine required status checks")
+	}
+	if len(required) > 0 {
+		successful, err := pullCtx.CurrentSuccessStatuses(ctx)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to determine successful status checks")
+		}
+		if missing := statusSetDifference(required, successful); len(missing) > 0 {
+			bypasses = append(bypasses, fmt.Sprintf("required status checks are not successful: %s", strings.Join(missing, ", ")))
+		}
+	}
+
+	requiredApprovals, err := pullCtx.RequiredApprovals(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to determine required approvals")
+	}
+	if requiredApprovals > 0 {
+		reviews, err := pullCtx.Reviews(ctx)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to list pull request reviews")
+		}
+
+		approvals := 0
+		var changesRequested []string
+		for login, state := range latestReviewStates(reviews) {
+			switch state {
+			case "APPROVED":
+				approvals++
+			case "CHANGES_REQUESTED":
+				changesRequested = append(changesRequested, login)
+			}
+		}
+		if approvals < requiredApprovals {
+			bypasses = append(bypasses, fmt.Sprintf("%d of %d required approvals", approvals, requiredApprovals))
+		}
+		if len(changesRequested) > 0 {
+			sort.Strings(changesRequested)
+			bypasses = append(bypasses, fmt.Sprintf("changes requested by %s", strings.Join(changesRequested, ", ")))
+		}
+	}
+
+	return bypasses, nil
+}
+
+func (s *Signals) doesCleanWithoutAdminSignalMatch(ctx context.Context, pullCtx pull.Context, tag string) (signalResult, string, int, error) {
+	if !s.RequireCleanWithoutAdmin {
+		return signalNotFound, "", 0, nil
+	}
+
+	bypasses, err := adminBypasses(ctx, pullCtx)
+	if err != nil {
+		return signalNotMatch, "unable to determine if merging requires an administrator override", 0, err
+	}
+	if len(bypasses) > 0 {
+		return signalNotMatch, fmt.Sprintf("merging the pull request would require an administrator to bypass branch protection: %s", strings.Join(bypasses, "; ")), 0, nil
+	}
+	return signalMatch, fmt.Sprintf("pull request meets the branch protection requirements of the %s branch without an administrator override", tag), 0, nil
+}
+
+// doesNativeAutoMergeSignalMatch matches pull requests with GitHub's native
+// auto-merge enabled. It is usually used to ignore pull requests that GitHub
+// will merge, so that bulldozer does not also act on them.
+func (s *Signals) doesNativeAutoMergeSignalMatch(ctx context.Context, pullCtx pull.Context, tag string) (signalResult, string, int, error) {
+	if !s.DeferToNativeAutoMerge {
+		return signalNotFound, "", 0, nil
+	}
+
+	autoMerge, err := pullCtx.AutoMerge(ctx)
+	if err != nil {
+		return signalNotMatch, "unable to determine if native auto-merge is enabled", 0, err
+	}
+	if autoMerge == nil {
+		return signalNotMatch, "pull request does not have native auto-merge enabled", 0, nil
+	}
+	return signalMatch, fmt.Sprintf("pull request has native auto-merge enabled by %q with the %q method", autoMerge.EnabledBy, autoMerge.MergeMethod), 0, nil
+}
+
+func (s *Signals) doesMergeAttemptsSignalMatch(ctx context.Context, pullCtx pull.Context, tag string) (signalResult, string, int, error) {
+	if s.MaxMergeAttempts <= 0 {
+		return signalNotFound, "", 0, nil
+	}
+
+	attempts, err := pullCtx.MergeAttempts(ctx)
+	if err != nil {
+		return signalNotMatch, "unable to determine failed merge attempts", 0, err
+	}
+
+	if attempts >= s.MaxMergeAttempts {
+		return signalNotMatch, fmt.Sprintf("pull request has %d failed merge attempts, reaching the %s limit of %d", attempts, tag, s.MaxMergeAttempts), 0, nil
+	}
+	return signalMatch, fmt.Sprintf("pull request has %d failed merge attempts, below the %s limit of %d", attempts, tag, s.MaxMergeAttempts), 0, nil
+}
+
+// doesRebaseSignalMatch matches pull requests that can be merged without a
+// merge commit by rebasing them onto the target branch. This requires that
+// the repository allows rebase merges, that the pull request does not contain
+// merge commits, and that GitHub reports the pull request as rebaseable,
+// which is false if rebasing the commits would cause conflicts.
+func (s *Signals) doesRebaseSignalMatch(ctx context.Context, pullCtx pull.Context, tag string) (signalResult, string, int, error) {
+	if !s.RequireRebaseable {
+		return signalNotFound, "", 0, nil
+	}
+
+	settings, err := pullCtx.MergeSettings(ctx)
+	if err != nil {
+		return signalNotMatch, "unable to determine allowed merge methods", 0, err
+	}
+	if !settings.AllowRebase {
+		return signalNotMatch, "pull request cannot be rebased because the repository does not allow rebase merges", 0, nil
+	}
+
+	commits, err := pullCtx.Commits(ctx)
+	if err != nil {
+		return signalNotMatch, "unable to list pull request commits", 0, err
+	}
+	for _, c := range commits {
+		if len(c.Parents) > 1 {
+			return signalNotMatch, fmt.Sprintf("pull request cannot be rebased because commit %s is a merge commit", c.SHA), 0, nil
+		}
+	}
+
+	state, err := pullCtx.MergeState(ctx)
+	if err != nil {
+		return signalNotMatch, "unable to determine if the pull request is rebaseable", 0, err
+	}
+
+	targetBranch, _ := pullCtx.Branches()
+	switch {
+	case state.Rebaseable == nil:
+		return signalNotMatch, "pull request rebase status is not known yet", 0, nil
+	case !*state.Rebaseable:
+		return signalNotMatch, fmt.Sprintf("pull request cannot be rebased onto %q, possibly because of conflicts", targetBranch), 0, nil
+	}
+	return signalMatch, fmt.Sprintf("pull request can be rebased onto the %s branch %q", tag, targetBranch), 0, nil
+}
+
+// doesMergeMethodSignalMatch matches pull requests that GitHub can merge with
+// one of the merge methods allowed by the repository. Merge commits and
+// squash merges require that the pull request is mergeable, while rebase
+// merges have the same requirements as RequireRebaseable.
+func (s *Signals) doesMergeMethodSignalMatch(ctx context.Context, pullCtx pull.Context, tag string) (signalResult, string, int, error) {
+	if !s.RequireMergeMethodCompatible {
+		return signalNotFound, "", 0, nil
+	}
+
+	methods, err := allowedMergeMethods(ctx, pullCtx)
+	if err != nil {
+		return signalNotMatch, "unable to determine allowed merge methods", 0, err
+	}
+	if len(methods) == 0 {
+		return signalNotMatch, "pull request cannot be merged because the repository does not allow any merge method", 0, nil
+	}
+
+	state, err := pullCtx.MergeState(ctx)
+	if err != nil {
+		return signalNotMatch, "unable to determine if the pull request is mergeable", 0, err
+	}
+
+	targetBranch, _ := pullCtx.Branches()
+	problems := make([]string, 0, len(methods))
+	for _, method := range methods {
+		var problem string
+		if method == RebaseAndMerge {
+			problem, err = rebaseProblem(ctx, pullCtx, state, targetBranch)
+			if err != nil {
+				return signalNotMatch, "unable to list pull request commits", 0, err
+			}
+		} else {
+			switch {
+			case state.Mergeable == nil:
+				problem = "its merge status is not known yet"
+			case !*state.Mergeable:
+				problem = fmt.Sprintf("it conflicts with %q", targetBranch)
+			}
+		}
+
+		if problem == "" {
+			return signalMatch, fmt.Sprintf("pull request can be merged with the %q method allowed by the %s repository", method, tag), 0, nil
+		}
+		if len(methods) == 1 {
+			return signalNotMatch, fmt.Sprintf("pull request cannot be merged with %q, the only method allowed by the repository, because %s", method, problem), 0, nil
+		}
+		problems = append(problems, fmt.Sprintf("%q: %s", method, problem))
+	}
+
+	return signalNotMatch, fmt.Sprintf("pull request cannot be merged with any method allowed by the repository: %s", strings.Join(problems, "; ")), 0, nil
+}
+
+// rebaseProblem returns why a pull request cannot be rebased onto the target
+// branch, or an empty string if it can be.
+func rebaseProblem(ctx context.Context, pullCtx pull.Context, state *pull.MergeState, targetBranch string) (string, error) {
+	commits, err := pullCtx.Commits(ctx)
+	if err != nil {
+		return "", err
+	}
+	for _, c := range commits {
+		if len(c.Parents) > 1 {
+			return fmt.Sprintf("commit %s is a merge commit", c.SHA), nil
+		}
+	}
+
+	switch {
+	case state.Rebaseable == nil:
+		return "its rebase status is not known yet", nil
+	case !*state.Rebaseable:
+		return fmt.Sprintf("it cannot be rebased onto %q, possibly because of conflicts", targetBranch), nil
+	}
+	return "", nil
+}
+
+// allowedMergeMethods returns the merge methods allowed by the repository of
+// a pull request, in the order GitHub lists them in repository settings.
+func allowedMergeMethods(ctx context.Context, pullCtx pull.Context) ([]MergeMethod, error) {
+	settings, err := pullCtx.MergeSettings(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var methods []MergeMethod
+	if settings.AllowMergeCommit {
+		methods = append(methods, MergeCommit)
+	}
+	if settings.AllowSquash {
+		methods = append(methods, SquashAndMerge)
+	}
+	if settings.AllowRebase {
+		methods = append(methods, RebaseAndMerge)
+	}
+	return methods, nil
+}
+
+// fixupPrefixes are the subject prefixes of commits that "git rebase
+// --autosquash" combines with earlier commits.
+var fixupPrefixes = []string{"fixup!", "squash!"}
+
+// doesFixupCommitSignalMatch matches pull requests without fixup or squash
+// commits, unless the pull request will be merged with the squash method.
+func (s *Signals) doesFixupCommitSignalMatch(ctx context.Context, pullCtx pull.Context, tag string) (signalResult, string, int, error) {
+	if !s.DisallowFixupCommits {
+		return signalNotFound, "", 0, nil
+	}
+
+	if method := mergeMethodFrom(ctx); method == SquashAndMerge {
+		return signalMatch, fmt.Sprintf("pull request will be merged with the %q method, which combines fixup commits", method), 0, nil
+	}
+
+	commits, err := pullCtx.Commits(ctx)
+	if err != nil {
+		return signalNotMatch, "unable to list pull request commits", 0, err
+	}
+
+	for _, c := range commits {
+		subject := strings.SplitN(c.Message, "\n", 2)[0]
+		for _, prefix := range fixupPrefixes {
+			if strings.HasPrefix(subject, prefix) {
+				return signalNotMatch, fmt.Sprintf("pull request commit %s is a %q commit that must be squashed before merging: %q", c.SHA, prefix, subject), 0, nil
+			}
+		}
+	}
+	return signalMatch, fmt.Sprintf("pull request has no fixup or squash commits among its %d commits", len(commits)), 0, nil
+}
+
+func (s *Signals) doesCommitSignalMatch(ctx context.Context, pullCtx pull.Context, tag string) (signalResult, string, int, error) {
+	if len(s.CommitAuthors) == 0 && !s.RequireVerifiedCommits {
+		return signalNotFound, "", 0, nil
+	}
+
+	commits, err := pullCtx.Commits(ctx)
+	if err != nil {
+		return signalNotMatch, "unable to list pull request commits", 0, err
+	}
+
+	for _, c := range commits {
+		if len(s.CommitAuthors) > 0 && !isCommitAuthor(c.Author, s.CommitAuthors) {
+			return signalNotMatch, fmt.Sprintf("pull request commit %s is not by a %s commit author: %s", c.SHA, tag, formatCommitIdentity(c.Author)), 0, nil
+		}
+		if s.RequireVerifiedCommits && !c.Verified {
+			return signalNotMatch, fmt.Sprintf("pull request commit %s does not have a verified signature", c.SHA), 0, nil
+		}
+	}
+
+	var constraints []string
+	if len(s.CommitAuthors) > 0 {
+		constraints = append(constraints, fmt.Sprintf("by %s commit authors", tag))
+	}
+	if s.RequireVerifiedCommits {
+		constraints = append(constraints, "verified")
+	}
+	return signalMatch, fmt.Sprintf("all %d pull request commits are %s", len(commits), strings.Join(constraints, " and ")), 0, nil
+}
+
+// TicketTrailer identifies the commit trailer that references tickets.
+type TicketTrailer struct {
+	// Key is the key of the trailer, compared without regard to case. If
+	// empty, DefaultTicketTrailerKey is used.
+	Key string `yaml:"key"`
+}
+
+// DefaultTicketTrailerKey is the key of the trailer that references tickets
+// if TicketTrailer does not set one.
+const DefaultTicketTrailerKey = "Ticket"
+
+// trailer is a "Key: value" line at the end of a commit message.
+type trailer struct {
+	key   string
+	value string
+}
+
+var trailerLine = regexp.MustCompile(`^([A-Za-z0-9][A-Za-z0-9-]*)\s*:\s*(.*\S)\s*$`)
+
+// commitTrailers returns the trailers of a commit message. Like git, it only
+// considers the last paragraph of a message with more than one paragraph,
+// and only if every line of that paragraph is a trailer.
+func commitTrailers(message string) []trailer {
+	message = strings.TrimSpace(strings.Replace(message, "\r\n", "\n", -1))
+	paragraphs := strings.Split(message, "\n\n")
+	if len(paragraphs) < 2 {
+		return nil
+	}
+
+	var trailers []trailer
+	for _, line := range strings.Split(strings.TrimSpace(paragraphs[len(paragraphs)-1]), "\n") {
+		m := trailerLine.FindStringSubmatch(line)
+		if m == nil {
+			return nil
+		}
+		trailers = append(trailers, trailer{key: m[1], value: m[2]})
+	}
+	return trailers
+}
+
+// doesTicketTrailerSignalMatch matches pull requests with at least one
+// commit that has a TicketTrailer, where every ticket referenced by the
+// commits is open according to the ticket validator. The reason names the
+// first ticket that is not open.
+func (s *Signals) doesTicketTrailerSignalMatch(ctx context.Context, pullCtx pull.Context, tag string) (signalResult, string, int, error) {
+	if s.TicketTrailer == nil {
+		return signalNotFound, "", 0, nil
+	}
+
+	key := s.TicketTrailer.Key
+	if key == "" {
+		key = DefaultTicketTrailerKey
+	}
+	if ticketValidator == nil {
+		return signalNotMatch, fmt.Sprintf("no ticket validator is configured for the %s %q trailer", tag, key), 0, errors.New("ticket trailers require a validator set with SetTicketValidator")
+	}
+
+	commits, err := pullCtx.Commits(ctx)
+	if err != nil {
+		return signalNotMatch, "unable to list pull request commits", 0, err
+	}
+
+	var tickets []string
+	seen := make(map[string]bool)
+	for _, c := range commits {
+		for _, t := range commitTrailers(c.Message) {
+			if strings.EqualFold(t.key, key) && !seen[t.value] {
+				seen[t.value] = true
+				tickets = append(tickets, t.value)
+			}
+		}
+	}
+	if len(tickets) == 0 {
+		return signalNotMatch, fmt.Sprintf("pull request commits do not have a %s %q trailer", tag, key), 0, nil
+	}
+
+	for _, ticket := range tickets {
+		open, err := ticketValidator(ctx, ticket)
+		if err != nil {
+			return signalNotMatch, fmt.Sprintf("unable to check ticket %q", ticket), 0, errors.Wrapf(err, "failed to validate ticket %q", ticket)
+		}
+		if !open {
+			return signalNotMatch, fmt.Sprintf("pull request commits reference ticket %q, which is missing or closed", ticket), 0, nil
+		}
+	}
+	return signalMatch, fmt.Sprintf("pull request commits reference open tickets in %s %q trailers: %s", tag, key, strings.Join(tickets, ", ")), 0, nil
+}
+
+// coAuthorTrailerKey is the trailer GitHub uses to credit additional authors
+// of a commit, like "Co-authored-by: Name <email>".
+const coAuthorTrailerKey = "Co-authored-by"
+
+var coAuthorValue = regexp.MustCompile(`^(.*?)\s*<([^<>\s]+)>$`)
+
+// doesCommitAuthorCountSignalMatch matches pull requests whose commits have at
+// least MinCommitAuthors distinct authors, counting both the authors of the
+// commits and the co-authors credited in "Co-authored-by" trailers. Authors
+// are identified by login when GitHub associates the commit with a user and
+// by email otherwise; a co-author whose email is also the email of a commit
+// author with a login counts as that user.
+func (s *Signals) doesCommitAuthorCountSignalMatch(ctx context.Context, pullCtx pull.Context, tag string) (signalResult, string, int, error) {
+	if s.MinCommitAuthors <= 0 {
+		return signalNotFound, "", 0, nil
+	}
+
+	commits, err := pullCtx.Commits(ctx)
+	if err != nil {
+		return signalNotMatch, "unable to list pull request commits", 0, err
+	}
+
+	logins := make(map[string]string)
+	for _, c := range commits {
+		if c.Author.Login != "" && c.Author.Email != "" {
+			logins[strings.ToLower(c.Author.Email)] = c.Author.Login
+		}
+	}
+
+	var authors []string
+	seen := make(map[string]bool)
+	addAuthor := func(login, email string) {
+		if login == "" {
+			login = logins[strings.ToLower(email)]
+		}
+		name := login
+		if name == "" {
+			name = email
+		}
+		if name == "" || seen[strings.ToLower(name)] {
+			return
+		}
+		seen[strings.ToLower(name)] = true
+		authors = append(authors, name)
+	}
+
+	for _, c := range commits {
+		addAuthor(c.Author.Login, c.Author.Email)
+		for _, t := range commitTrailers(c.Message) {
+			if !strings.EqualFold(t.key, coAuthorTrailerKey) {
+				continue
+			}
+			if m := coAuthorValue.FindStringSubmatch(t.value); m != nil {
+				addAuthor("", m[2])
+			}
+		}
+	}
+
+	noun := "authors"
+	if len(authors) == 1 {
+		noun = "author"
+	}
+	if len(authors) < s.MinCommitAuthors {
+		return signalNotMatch, fmt.Sprintf("pull request commits have %d distinct %s, fewer than the %s minimum of %d: %s", len(authors), noun, tag, s.MinCommitAuthors, strings.Join(authors, ", ")), 0, nil
+	}
+	return signalMatch, fmt.Sprintf("pull request commits have %d distinct %s, meeting the %s minimum of %d: %s", len(authors), noun, tag, s.MinCommitAuthors, strings.Join(authors, ", ")), 0, nil
+}
+
+// doesSignedCommitSignalMatch matches if every commit on the pull request has
+// a signature verified by GitHub. Failures name the first commit that is not
+// verified and the reason code reported by GitHub.
+func (s *Signals) doesSignedCommitSignalMatch(ctx context.Context, pullCtx pull.Context, tag string) (signalResult, string, int, error) {
+	if !s.RequireSignedCommits {
+		return signalNotFound, "", 0, nil
+	}
+
+	commits, err := pullCtx.Commits(ctx)
+	if err != nil {
+		return signalNotMatch, "unable to list pull request commits", 0, err
+	}
+	if len(commits) == 0 {
+		return signalNotMatch, "pull request has no commits", 0, nil
+	}
+
+	for _, c := range commits {
+		if !c.Verified {
+			reason := c.VerificationReason
+			if reason == "" {
+				reason = "unsigned"
+			}
+			return signalNotMatch, fmt.Sprintf("pull request commit %s is not signed with a verified signature (%s)", c.SHA, reason), 0, nil
+		}
+	}
+	return signalMatch, fmt.Sprintf("all %d pull request commits have verified signatures", len(commits)), 0, nil
+}
+
+// maxMergeCommits returns the largest number of merge commits a pull request
+// may contain, or -1 if the signals do not limit merge commits.
+func (s *Signals) maxMergeCommits() int {
+	switch {
+	case s.DisallowMergeCommits:
+		return 0
+	case s.MaxMergeCommits > 0:
+		return s.MaxMergeCommits
+	default:
+		return -1
+	}
+}
+
+func (s *Signals) doesMergeCommitSignalMatch(ctx context.Context, pullCtx pull.Context, tag string) (signalResult, string, int, error) {
+	maxMergeCommits := s.maxMergeCommits()
+	if maxMergeCommits < 0 {
+		return signalNotFound, "", 0, nil
+	}
+
+	commits, err := pullCtx.Commits(ctx)
+	if err != nil {
+		return signalNotMatch, "unable to list pull request commits", 0, err
+	}
+
+	var mergeCommits []string
+	for _, c := range commits {
+		if len(c.Parents) > 1 {
+			mergeCommits = append(mergeCommits, c.SHA)
+		}
+	}
+
+	if len(mergeCommits) > maxMergeCommits {
+		return signalNotMatch, fmt.Sprintf("pull request contains %d merge commits, exceeding the %s limit of %d: %s", len(mergeCommits), tag, maxMergeCommits, mergeCommits[0]), 0, nil
+	}
+	return signalMatch, fmt.Sprintf("pull request contains %d merge commits, within the %s limit of %d", len(mergeCommits), tag, maxMergeCommits), 0, nil
+}
+
+// isCommitAuthor returns true if the login or email of an identity matches
+// one of the allowed authors, ignoring case.
+func isCommitAuthor(identity pull.CommitIdentity, authors []string) bool {
+	for _, author := range authors {
+		if identity.Login != "" && strings.EqualFold(identity.Login, author) {
+			return true
+		}
+		if identity.Email != "" && strings.EqualFold(identity.Email, author) {
+			return true
+		}
+	}
+	return false
+}
+
+func formatCommitIdentity(identity pull.CommitIdentity) string {
+	if identity.Login == "" {
+		return fmt.Sprintf("<%s>", identity.Email)
+	}
+	return fmt.Sprintf("%s <%s>", identity.Login, identity.Email)
+}
+
+// maxBinaryFiles returns the largest number of binary files a pull request
+// may change, or -1 if the signals do not limit binary files.
+func (s *Signals) maxBinaryFiles() int {
+	switch {
+	case s.DisallowBinaryChanges:
+		return 0
+	case s.MaxBinaryFiles > 0:
+		return s.MaxBinaryFiles
+	default:
+		return -1
+	}
+}
+
+func (s *Signals) doesBinaryFileSignalMatch(ctx context.Context, pullCtx pull.Context, tag string) (signalResult, string, int, error) {
+	maxBinaryFiles := s.maxBinaryFiles()
+	if maxBinaryFiles < 0 {
+		return signalNotFound, "", 0, nil
+	}
+
+	files, err := pullCtx.ChangedFiles(ctx)
+	if err != nil {
+		return signalNotMatch, "unable to list pull request files", 0, err
+	}
+
+	var binaryFiles []string
+	for _, f := range files {
+		if f.Binary {
+			binaryFiles = append(binaryFiles, f.Filename)
+		}
+	}
+
+	if len(binaryFiles) > maxBinaryFiles {
+		return signalNotMatch, fmt.Sprintf("pull request changes %d binary files, exceeding the %s limit of %d: %q", len(binaryFiles), tag, maxBinaryFiles, binaryFiles[0]), 0, nil
+	}
+	return signalMatch, fmt.Sprintf("pull request changes %d binary files, within the %s limit of %d", len(binaryFiles), tag, maxBinaryFiles), 0, nil
+}
+
+// estimatedBytesPerLine is used to estimate the size of an added file from
+// its number of lines when the actual size is not available.
+const estimatedBytesPerLine = 80
+
+func (s *Signals) doesAddedFileSizeSignalMatch(ctx context.Context, pullCtx pull.Context, tag string) (signalResult, string, int, error) {
+	if s.MaxAddedFileBytes <= 0 {
+		return signalNotFound, "", 0, nil
+	}
+
+	files, err := pullCtx.ChangedFiles(ctx)
+	if err != nil {
+		return signalNotMatch, "unable to list pull request files", 0, err
+	}
+
+	var sizes map[string]int64
+	if !s.EstimateFileSizes {
+		if sizes, err = pullCtx.FileSizes(ctx); err != nil {
+			return signalNotMatch, "unable to get pull request file sizes", 0, err
+		}
+	}
+
+	for _, f := range files {
+		if f.Status != "added" {
+			continue
+		}
+
+		if size, ok := sizes[f.Filename]; ok {
+			if size > s.MaxAddedFileBytes {
+				return signalNotMatch, fmt.Sprintf("pull request adds file %q with %d bytes, exceeding the %s limit of %d bytes", f.Filename, size, tag, s.MaxAddedFileBytes), 0, nil
+			}
+			continue
+		}
+
+		if estimate := int64(f.Additions) * estimatedBytesPerLine; estimate > s.MaxAddedFileBytes {
+			return signalNotMatch, fmt.Sprintf("pull request adds file %q with an estimated %d bytes (%d lines), exceeding the %s limit of %d bytes", f.Filename, estimate, f.Additions, tag, s.MaxAddedFileBytes), 0, nil
+		}
+	}
+	return signalMatch, fmt.Sprintf("pull request adds no files larger than the %s limit of %d bytes", tag, s.MaxAddedFileBytes), 0, nil
+}
+
+func (s *Signals) doesDirectorySignalMatch(ctx context.Context, pullCtx pull.Context, tag string) (signalResult, string, int, error) {
+	if s.MinDirectories <= 0 && s.MaxDirectories <= 0 {
+		return signalNotFound, "", 0, nil
+	}
+
+	files, err := pullCtx.ChangedFiles(ctx)
+	if err != nil {
+		return signalNotMatch, "unable to list pull request files", 0, err
+	}
+
+	depth := s.DirectoryDepth
+	if depth <= 0 {
+		depth = 1
+	}
+
+	dirs := changedDirectories(files, depth)
+	switch {
+	case s.MinDirectories > 0 && len(dirs) < s.MinDirectories:
+		return signalNotMatch, fmt.Sprintf("pull request changes %d directories, fewer than the %s minimum of %d: %s", len(dirs), tag, s.MinDirectories, strings.Join(dirs, ", ")), 0, nil
+	case s.MaxDirectories > 0 && len(dirs) > s.MaxDirectories:
+		return signalNotMatch, fmt.Sprintf("pull request changes %d directories, more than the %s maximum of %d: %s", len(dirs), tag, s.MaxDirectories, strings.Join(dirs, ", ")), 0, nil
+	}
+	return signalMatch, fmt.Sprintf("pull request changes %d directories, within the %s bounds", len(dirs), tag), 0, nil
+}
+
+// doesPathFileCountSignalMatch matches pull requests that change at most the
+// configured number of files under each path in PathFileCounts. The reason
+// names every path that exceeds its limit.
+func (s *Signals) doesPathFileCountSignalMatch(ctx context.Context, pullCtx pull.Context, tag string) (signalResult, string, int, error) {
+	if len(s.PathFileCounts) == 0 {
+		return signalNotFound, "", 0, nil
+	}
+
+	files, err := pullCtx.ChangedFiles(ctx)
+	if err != nil {
+		return signalNotMatch, "unable to list pull request files", 0, err
+	}
+
+	paths := make([]string, 0, len(s.PathFileCounts))
+	for p := range s.PathFileCounts {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+
+	var exceeded []string
+	for _, p := range paths {
+		count := 0
+		for _, f := range files {
+			if matchesPath(p, f.Filename) {
+				count++
+			}
+		}
+		if max := s.PathFileCounts[p]; count > max {
+			exceeded = append(exceeded, fmt.Sprintf("%d files in %q (maximum %d)", count, p, max))
+		}
+	}
+
+	if len(exceeded) > 0 {
+		return signalNotMatch, fmt.Sprintf("pull request changes more files than the %s limits allow: %s", tag, strings.Join(exceeded, ", ")), 0, nil
+	}
+	return signalMatch, fmt.Sprintf("pull request changes no more files than the %s limits allow in %d paths", tag, len(paths)), 0, nil
+}
+
+// doesExtensionSignalMatch matches pull requests where every changed file has
+// one of the AllowedExtensions. The reason names the first file that does
+// not.
+func (s *Signals) doesExtensionSignalMatch(ctx context.Context, pullCtx pull.Context, tag string) (signalResult, string, int, error) {
+	if len(s.AllowedExtensions) == 0 {
+		return signalNotFound, "", 0, nil
+	}
+
+	allowed := make(map[string]bool)
+	for _, ext := range s.AllowedExtensions {
+		allowed[strings.ToLower(strings.TrimPrefix(ext, "."))] = true
+	}
+
+	files, err := pullCtx.ChangedFiles(ctx)
+	if err != nil {
+		return signalNotMatch, "unable to list pull request files", 0, err
+	}
+
+	for _, f := range files {
+		ext := fileExtension(f.Filename)
+		switch {
+		case ext == "" && !s.AllowExtensionlessFiles:
+			return signalNotMatch, fmt.Sprintf("pull request changes %q, which does not have an extension", f.Filename), 0, nil
+		case ext != "" && !allowed[strings.ToLower(ext)]:
+			return signalNotMatch, fmt.Sprintf("pull request changes %q, which does not have a %s extension", f.Filename, tag), 0, nil
+		}
+	}
+	return signalMatch, fmt.Sprintf("all %d files changed by the pull request have %s extensions", len(files), tag), 0, nil
+}
+
+// TestChanges configures the RequireTestChanges signal, which matches pull
+// requests that change test files whenever they change code. Paths are
+// prefixes or glob patterns, like the keys of PathFileCounts, except that
+// glob patterns without a slash, like "*_test.go", are matched against the
+// name of each file in any directory.
+type TestChanges struct {
+	// TestPaths identify test files.
+	TestPaths []string `yaml:"test_paths"`
+
+	// ExemptPaths identify files that are neither code nor tests, like
+	// documentation. Pull requests that only change exempt files and tests
+	// match without test changes.
+	ExemptPaths []string `yaml:"exempt_paths"`
+}
+
+// doesTestChangeSignalMatch matches pull requests that change a test file if
+// they change any code, which is every file that is not a test file or
+// exempt.
+func (s *Signals) doesTestChangeSignalMatch(ctx context.Context, pullCtx pull.Context, tag string) (signalResult, string, int, error) {
+	config := s.RequireTestChanges
+	if config == nil || len(config.TestPaths) == 0 {
+		return signalNotFound, "", 0, nil
+	}
+
+	files, err := pullCtx.ChangedFiles(ctx)
+	if err != nil {
+		return signalNotMatch, "unable to list pull request files", 0, err
+	}
+
+	var code, tests []string
+	for _, f := range files {
+		switch {
+		case matchesAnyTestPath(config.TestPaths, f.Filename):
+			tests = append(tests, f.Filename)
+		case !matchesAnyTestPath(config.ExemptPaths, f.Filename):
+			code = append(code, f.Filename)
+		}
+	}
+
+	switch {
+	case len(code) == 0:
+		return signalMatch, fmt.Sprintf("pull request does not change any code outside of %s test and exempt paths", tag), 0, nil
+	case len(tests) == 0:
+		return signalNotMatch, fmt.Sprintf("pull request changes %d code files, like %q, but no %s test files", len(code), code[0], tag), 0, nil
+	}
+	return signalMatch, fmt.Sprintf("pull request changes %d code files and %d %s test files", len(code), len(tests), tag), 0, nil
+}
+
+// DefaultTestDeletionPaths identify the test files checked by
+// BlockTestDeletion if TestDeletionPaths is empty.
+var DefaultTestDeletionPaths = []string{"test/", "tests/", "*_test.go", "test_*.py", "*_test.py", "*.test.js", "*.spec.js", "*.test.ts", "*.spec.ts"}
+
+// doesTestDeletionSignalMatch matches pull requests that remove more test
+// files than they add, unless they have an exemption label. A renamed file
+// counts as removed if only its previous name is a test and as added if only
+// its new name is. The reason names the removed test files.
+func (s *Signals) doesTestDeletionSignalMatch(ctx context.Context, pullCtx pull.Context, tag string) (signalResult, string, int, error) {
+	if !s.BlockTestDeletion {
+		return signalNotFound, "", 0, nil
+	}
+
+	testPaths := s.TestDeletionPaths
+	if len(testPaths) == 0 {
+		testPaths = DefaultTestDeletionPaths
+	}
+
+	files, err := pullCtx.ChangedFiles(ctx)
+	if err != nil {
+		return signalNotMatch, "unable to list pull request files", 0, err
+	}
+
+	var removed []string
+	added := 0
+	for _, f := range files {
+		isTest := matchesAnyTestPath(testPaths, f.Filename)
+		switch f.Status {
+		case "removed":
+			if isTest {
+				removed = append(removed, strconv.Quote(f.Filename))
+			}
+		case "added":
+			if isTest {
+				added++
+			}
+		case "renamed":
+			wasTest := matchesAnyTestPath(testPaths, f.PreviousFilename)
+			switch {
+			case wasTest && !isTest:
+				removed = append(removed, strconv.Quote(f.PreviousFilename))
+			case isTest && !wasTest:
+				added++
+			}
+		}
+	}
+	if len(removed) <= added {
+		return signalNotMatch, fmt.Sprintf("pull request does not remove more %s test files than it adds", tag), 0, nil
+	}
+
+	if len(s.TestDeletionExemptLabels) > 0 {
+		labels, err := pullCtx.Labels(ctx)
+		if err != nil {
+			return signalNotMatch, "unable to list pull request labels", 0, err
+		}
+		for _, exemptLabel := range s.TestDeletionExemptLabels {
+			for _, label := range labels {
+				if s.textEqual(exemptLabel, label, true) {
+					return signalNotMatch, fmt.Sprintf("pull request removes %s test files but has an exemption label: %q", tag, exemptLabel), 0, nil
+				}
+			}
+		}
+	}
+	return signalMatch, fmt.Sprintf("pull request removes %d %s test files but adds %d: %s", len(removed), tag, added, strings.Join(removed, ", ")), 0, nil
+}
+
+// matchesAnyTestPath returns true if the file matches any of the paths of a
+// TestChanges configuration.
+func matchesAnyTestPath(paths []string, filename string) bool {
+	for _, p := range paths {
+		if strings.ContainsAny(p, "*?[") && !strings.Contains(p, "/") {
+			if matched, _ := path.Match(p, path.Base(filename)); matched {
+				return true
+			}
+			continue
+		}
+		if matchesPath(p, filename) {
+			return true
+		}
+	}
+	return false
+}
+
+// fileExtension returns the extension of a file without the leading dot, or
+// an empty string if the file does not have an extension. Names that only
+// start with a dot, like ".gitignore", do not have an extension.
+func fileExtension(filename string) string {
+	base := path.Base(filename)
+	ext := path.Ext(base)
+	if ext == base {
+		return ""
+	}
+	return strings.TrimPrefix(ext, ".")
+}
+
+// doesProtectedPathSignalMatch matches pull requests that change a file under
+// one of the ProtectedPaths but are not approved by a member of any of the
+// ApproverTeams. Users are counted if their latest review is an approval.
+func (s *Signals) doesProtectedPathSignalMatch(ctx context.Context, pullCtx pull.Context, tag string) (signalResult, string, int, error) {
+	if len(s.ProtectedPaths) == 0 {
+		return signalNotFound, "", 0, nil
+	}
+
+	files, err := pullCtx.ChangedFiles(ctx)
+	if err != nil {
+		return signalNotMatch, "unable to list pull request files", 0, err
+	}
+
+	index, protectedPath, filename := 0, "", ""
+	for i, p := range s.ProtectedPaths {
+		for _, f := range files {
+			if matchesPath(p, f.Filename) {
+				index, protectedPath, filename = i+1, p, f.Filename
+				break
+			}
+		}
+		if index > 0 {
+			break
+		}
+	}
+	if index == 0 {
+		return signalNotMatch, fmt.Sprintf("pull request does not change any %s protected paths", tag), 0, nil
+	}
+
+	reviews, err := pullCtx.Reviews(ctx)
+	if err != nil {
+		return signalNotMatch, "unable to list pull request reviews", 0, err
+	}
+	states := latestReviewStates(reviews)
+
+	for _, team := range s.ApproverTeams {
+		members, err := pullCtx.TeamMembers(ctx, team)
+		if err != nil {
+			return signalNotMatch, fmt.Sprintf("unable to list members of team %q", team), 0, err
+		}
+		for _, member := range members {
+			if states[strings.ToLower(member)] == "APPROVED" {
+				return signalNotMatch, fmt.Sprintf("pull request changes %s protected path %q and was approved by %s, a member of team %q", tag, protectedPath, member, team), 0, nil
+			}
+		}
+	}
+
+	teams := "any approver team"
+	if len(s.ApproverTeams) > 0 {
+		teams = "team " + strings.Join(s.ApproverTeams, " or ")
+	}
+	return signalMatch, fmt.Sprintf("pull request changes %s protected path %q (%s) without an approval from a member of %s", tag, protectedPath, filename, teams), index, nil
+}
+
+// DefaultSelfConfigPath is the path of the configuration file checked by
+// BlockSelfConfigChanges if SelfConfigPath is not set.
+const DefaultSelfConfigPath = ".bulldozer.yml"
+
+// doesSelfConfigSignalMatch matches pull requests that change the bulldozer
+// configuration file at SelfConfigPath, or DefaultSelfConfigPath if it is not
+// set. It is intended for the ignore list, so that pull requests cannot merge
+// themselves by changing the configuration that evaluates them.
+func (s *Signals) doesSelfConfigSignalMatch(ctx context.Context, pullCtx pull.Context, tag string) (signalResult, string, int, error) {
+	if !s.BlockSelfConfigChanges {
+		return signalNotFound, "", 0, nil
+	}
+
+	configPath := strings.TrimPrefix(s.SelfConfigPath, "/")
+	if configPath == "" {
+		configPath = DefaultSelfConfigPath
+	}
+
+	files, err := pullCtx.ChangedFiles(ctx)
+	if err != nil {
+		return signalNotMatch, "unable to list pull request files", 0, err
+	}
+
+	for _, f := range files {
+		if f.Filename == configPath {
+			return signalMatch, fmt.Sprintf("pull request changes the bulldozer configuration file %q", configPath), 0, nil
+		}
+	}
+	return signalNotMatch, fmt.Sprintf("pull request does not change the bulldozer configuration file %q", configPath), 0, nil
+}
+
+// doesCodeOwnersRequestedSignalMatch matches pull requests where each code
+// owner of the changed files is requested to review. GitHub removes users
+// from the requested reviewers when they submit a review, so owners who
+// already reviewed count as requested, as do teams with a member who
+// reviewed. The reason names the first owner that is missing.
+func (s *Signals) doesCodeOwnersRequestedSignalMatch(ctx context.Context, pullCtx pull.Context, tag string) (signalResult, string, int, error) {
+	if !s.RequireCodeOwnersRequested {
+		return signalNotFound, "", 0, nil
+	}
+
+	contents, err := pullCtx.CodeOwners(ctx)
+	if err != nil {
+		return signalNotMatch, "unable to read the CODEOWNERS file", 0, err
+	}
+
+	files, err := pullCtx.ChangedFiles(ctx)
+	if err != nil {
+		return signalNotMatch, "unable to list pull request files", 0, err
+	}
+
+	rules := parseCodeOwners(contents)
+	var owners []string
+	ownedFiles := make(map[string]string)
+	for _, f := range files {
+		for _, owner := range ownersOf(rules, f.Filename) {
+			key := strings.ToLower(owner)
+			if _, ok := ownedFiles[key]; !ok && strings.HasPrefix(owner, "@") {
+				owners = append(owners, owner)
+				ownedFiles[key] = f.Filename
+			}
+		}
+	}
+	if len(owners) == 0 {
+		return signalMatch, "pull request does not change any files with code owners", 0, nil
+	}
+
+	requested, err := pullCtx.RequestedReviewers(ctx)
+	if err != nil {
+		return signalNotMatch, "unable to list pull request requested reviewers", 0, err
+	}
+
+	reviews, err := pullCtx.Reviews(ctx)
+	if err != nil {
+		return signalNotMatch, "unable to list pull request reviews", 0, err
+	}
+
+	present := make(map[string]bool)
+	reviewed := make(map[string]bool)
+	for _, user := range requested.Users {
+		present[strings.ToLower(user)] = true
+	}
+	for _, team := range requested.Teams {
+		present[strings.ToLower(pullCtx.Owner()+"/"+team)] = true
+	}
+	for _, r := range reviews {
+		present[strings.ToLower(r.Author)] = true
+		reviewed[strings.ToLower(r.Author)] = true
+	}
+
+	for _, owner := range owners {
+		name := strings.ToLower(strings.TrimPrefix(owner, "@"))
+		if present[name] {
+			continue
+		}
+
+		parts := strings.SplitN(name, "/", 2)
+		if len(parts) == 2 && len(reviewed) > 0 && strings.EqualFold(parts[0], pullCtx.Owner()) {
+			members, err := pullCtx.TeamMembers(ctx, parts[1])
+			if err != nil {
+				return signalNotMatch, fmt.Sprintf("unable to list members of team %q", parts[1]), 0, err
+			}
+			if anyReviewed(members, reviewed) {
+				continue
+			}
+		}
+		return signalNotMatch, fmt.Sprintf("pull request code owner %q of %q is not requested for review", owner, ownedFiles[strings.ToLower(owner)]), 0, nil
+	}
+	return signalMatch, fmt.Sprintf("pull request requests reviews from all %d code owners of the changed files", len(owners)), 0, nil
+}
+
+func anyReviewed(users []string, reviewed map[string]bool) bool {
+	for _, u := range users {
+		if reviewed[strings.ToLower(u)] {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesPath returns true if filename starts with the prefix or, if the
+// prefix contains glob characters, matches it as a pattern.
+func matchesPath(prefix, filename string) bool {
+	if strings.ContainsAny(prefix, "*?[") {
+		matched, _ := path.Match(prefix, filename)
+		return matched
+	}
+	return strings.HasPrefix(filename, prefix)
+}
+
+// changedDirectories returns the sorted, distinct directories containing the
+// changed files, truncated to the given depth. Files in the root of the
+// repository are grouped in the "." directory.
+func changedDirectories(files []*pull.File, depth int) []string {
+	seen := make(map[string]bool)
+	for _, f := range files {
+		parts := strings.Split(f.Filename, "/")
+		parts = parts[:len(parts)-1]
+		if len(parts) > depth {
+			parts = parts[:depth]
+		}
+
+		dir := "."
+		if len(parts) > 0 {
+			dir = strings.Join(parts, "/")
+		}
+		seen[dir] = true
+	}
+
+	dirs := make([]string, 0, len(seen))
+	for dir := range seen {
+		dirs = append(dirs, dir)
+	}
+	sort.Strings(dirs)
+	return dirs
+}
+
+// doesEnvironmentSignalMatch matches pull requests whose latest deployments
+// to the Environments, according to the match type, are in EnvironmentState,
+// or "success" if it is not set. An environment the pull request was never
+// deployed to does not match.
+func (s *Signals) doesEnvironmentSignalMatch(ctx context.Context, pullCtx pull.Context, tag string) (signalResult, string, int, error) {
+	if len(s.Environments.Values) == 0 {
+		return signalNotFound, "", 0, nil
+	}
+
+	deployments, err := pullCtx.Deployments(ctx)
+	if err != nil {
+		return signalNotMatch, "unable to list pull request deployments", 0, err
+	}
+
+	requiredState := s.EnvironmentState
+	if requiredState == "" {
+		requiredState = "success"
+	}
+
+	return matchValues("environments", tag, s.Environments.Values, s.matchType(s.Environments), func(environment string) (bool, string, error) {
+		var latest *pull.Deployment
+		for _, d := range deployments {
+			if d.Environment == environment && (latest == nil || d.CreatedAt.After(latest.CreatedAt)) {
+				latest = d
+			}
+		}
+
+		switch {
+		case latest == nil:
+			return false, fmt.Sprintf("pull request has no deployments to %s environment %q", tag, environment), nil
+		case latest.State == requiredState:
+			return true, fmt.Sprintf("pull request's latest deployment to %s environment %q is %q", tag, environment, latest.State), nil
+		default:
+			return false, fmt.Sprintf("pull request's latest deployment to %s environment %q is %q, not %q", tag, environment, latest.State, requiredState), nil
+		}
+	})
+}
+
+// boundedDiff returns the diff of the pull request for signals that parse it.
+// If the diff cannot be loaded or is larger than MaxDiffBytes, or
+// DefaultMaxDiffBytes if it is not set, it returns a reason that describes
+// the purpose the diff was needed for and an error.
+func (s *Signals) boundedDiff(ctx context.Context, pullCtx pull.Context, purpose string) (string, string, error) {
+	diff, err := pullCtx.Diff(ctx)
+	if err != nil {
+		return "", "unable to get pull request diff", err
+	}
+
+	maxBytes := s.MaxDiffBytes
+	if maxBytes <= 0 {
+		maxBytes = DefaultMaxDiffBytes
+	}
+	if len(diff) > maxBytes {
+		return "", fmt.Sprintf("pull request diff is too large to %s (%d bytes, limit %d bytes)", purpose, len(diff), maxBytes), errors.Errorf("diff size %d exceeds limit %d", len(diff), maxBytes)
+	}
+	return diff, "", nil
+}
+
+// doesDiffSignalMatch matches pull requests with added or removed diff lines
+// that match the DiffPatterns, according to the match type. Removed lines are
+// ignored if DiffAddedLinesOnly is set. The reason quotes the first line that
+// matched a pattern.
+func (s *Signals) doesDiffSignalMatch(ctx context.Context, pullCtx pull.Context, tag string) (signalResult, string, int, error) {
+	if len(s.DiffPatterns.Values) == 0 {
+		return signalNotFound, "", 0, nil
+	}
+
+	patterns := make(map[string]*regexp.Regexp, len(s.DiffPatterns.Values))
+	for _, signalPattern := range s.DiffPatterns.Values {
+		pattern, err := regexp.Compile(signalPattern)
+		if err != nil {
+			return signalNotMatch, fmt.Sprintf("invalid %s diff pattern: %q", tag, signalPattern), 0, errors.Wrap(err, "failed to compile diff pattern")
+		}
+		patterns[signalPattern] = pattern
+	}
+
+	diff, reason, err := s.boundedDiff(ctx, pullCtx, "match against")
+	if err != nil {
+		return signalNotMatch, reason, 0, err
+	}
+
+	lines := parseDiff(diff)
+	return matchValues("diff patterns", tag, s.DiffPatterns.Values, s.matchType(s.DiffPatterns), func(signalPattern string) (bool, string, error) {
+		for _, line := range lines {
+			if !line.Added && s.DiffAddedLinesOnly {
+				continue
+			}
+			if patterns[signalPattern].MatchString(line.Content) {
+				kind := "removed"
+				if line.Added {
+					kind = "added"
+				}
+				return true, fmt.Sprintf("pull request %s line in %q matches a %s diff pattern %q: %q", kind, line.File, tag, signalPattern, line.Content), nil
+			}
+		}
+		return false, fmt.Sprintf("pull request diff does not match a %s diff pattern: %q", tag, signalPattern), nil
+	})
+}
+
+// doesResolvedThreadSignalMatch matches pull requests where every review
+// thread on a changed line is resolved. Threads are located using the line
+// numbers of the added and removed lines in the diff.
+func (s *Signals) doesResolvedThreadSignalMatch(ctx context.Context, pullCtx pull.Context, tag string) (signalResult, string, int, error) {
+	if !s.RequireResolvedThreadsOnChangedLines {
+		return signalNotFound, "", 0, nil
+	}
+
+	threads, err := pullCtx.ReviewThreads(ctx)
+	if err != nil {
+		return signalNotMatch, "unable to list pull request review threads", 0, err
+	}
+
+	var unresolved []*pull.ReviewThread
+	for _, t := range threads {
+		if !t.Resolved && t.Line > 0 {
+			unresolved = append(unresolved, t)
+		}
+	}
+	if len(unresolved) == 0 {
+		return signalMatch, "pull request has no unresolved review threads", 0, nil
+	}
+
+	diff, reason, err := s.boundedDiff(ctx, pullCtx, "locate review threads")
+	if err != nil {
+		return signalNotMatch, reason, 0, err
+	}
+
+	changed := make(map[diffLine]bool)
+	for _, line := range parseDiff(diff) {
+		changed[diffLine{File: line.File, Added: line.Added, Line: line.Line}] = true
+	}
+
+	for _, t := range unresolved {
+		if changed[diffLine{File: t.Path, Added: t.Side != "LEFT", Line: t.Line}] {
+			return signalNotMatch, fmt.Sprintf("pull request has an unresolved review thread on changed line %s:%d", t.Path, t.Line), 0, nil
+		}
+	}
+	return signalMatch, fmt.Sprintf("pull request has no unresolved review threads on changed lines (%d on unchanged lines)", len(unresolved)), 0, nil
+}
+
+// DefaultDependencyManifests are the manifests checked by
+// BlockNewDependencies if DependencyManifests is empty.
+var DefaultDependencyManifests = []string{"go.mod", "package.json", "requirements*.txt"}
+
+// doesNewDependencySignalMatch matches pull requests that add dependencies
+// to a manifest that are not allowed. The reason names each dependency and
+// the manifest it was added to.
+func (s *Signals) doesNewDependencySignalMatch(ctx context.Context, pullCtx pull.Context, tag string) (signalResult, string, int, error) {
+	if !s.BlockNewDependencies {
+		return signalNotFound, "", 0, nil
+	}
+
+	diff, reason, err := s.boundedDiff(ctx, pullCtx, "check for new dependencies")
+	if err != nil {
+		return signalNotMatch, reason, 0, err
+	}
+
+	manifests := s.DependencyManifests
+	if len(manifests) == 0 {
+		manifests = DefaultDependencyManifests
+	}
+	isManifest := func(file string) bool {
+		return matchesManifest(manifests, file)
+	}
+
+	var added []string
+	for _, dep := range addedDependencies(parseDiff(diff), isManifest) {
+		if !s.isAllowedDependency(dep.Name) {
+			added = append(added, fmt.Sprintf("%q in %q", dep.Name, dep.Manifest))
+		}
+	}
+	if len(added) > 0 {
+		return signalMatch, fmt.Sprintf("pull request adds %s dependencies: %s", tag, strings.Join(added, ", ")), 0, nil
+	}
+	return signalNotMatch, fmt.Sprintf("pull request does not add %s dependencies", tag), 0, nil
+}
+
+// matchesManifest returns true if the file matches any of the manifests.
+// Manifests without a slash, like "package.json", match files with that
+// name in any directory; others match like other paths.
+func matchesManifest(manifests []string, filename string) bool {
+	for _, m := range manifests {
+		if !strings.Contains(m, "/") {
+			if matched, _ := path.Match(m, path.Base(filename)); matched {
+				return true
+			}
+			continue
+		}
+		if matchesPath(m, filename) {
+			return true
+		}
+	}
+	return false
+}
+
+// isAllowedDependency returns true if the dependency matches one of
+// AllowedDependencies, either exactly, ignoring case, or as a glob pattern.
+func (s *Signals) isAllowedDependency(name string) bool {
+	for _, allowed := range s.AllowedDependencies {
+		if strings.EqualFold(allowed, name) {
+			return true
+		}
+		if matched, _ := path.Match(allowed, name); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// doesConflictMarkerSignalMatch matches pull requests that add a complete set
+// of merge conflict markers to a file, as found by conflictMarkerFiles. The
+// reason lists every file with added markers.
+func (s *Signals) doesConflictMarkerSignalMatch(ctx context.Context, pullCtx pull.Context, tag string) (signalResult, string, int, error) {
+	if !s.DetectConflictMarkers {
+		return signalNotFound, "", 0, nil
+	}
+
+	diff, reason, err := s.boundedDiff(ctx, pullCtx, "check for conflict markers")
+	if err != nil {
+		return signalNotMatch, reason, 0, err
+	}
+
+	files := conflictMarkerFiles(parseDiff(diff))
+	if len(files) > 0 {
+		quoted := make([]string, len(files))
+		for i, f := range files {
+			quoted[i] = fmt.Sprintf("%q", f)
+		}
+		return signalMatch, fmt.Sprintf("pull request adds merge conflict markers to %s", strings.Join(quoted, ", ")), 0, nil
+	}
+	return signalNotMatch, "pull request does not add merge conflict markers", 0, nil
 }
@@ -16,10 +16,14 @@ package bulldozer
 
 import (
 	"context"
+	"fmt"
 	"testing"
+	"time"
 
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v2"
 
 	"github.com/palantir/bulldozer/pull"
 	"github.com/palantir/bulldozer/pull/pulltest"
@@ -27,12 +31,14 @@ import (
 
 func TestSignalsMatches(t *testing.T) {
 	signals := Signals{
-		Labels:            []string{"LABEL_MERGE"},
-		Comments:          []string{"FULL_COMMENT_PLZ_MERGE"},
-		CommentSubstrings: []string{":+1:"},
-		PRBodySubstrings:  []string{"BODY_MERGE_PLZ"},
-		Branches:          []string{"develop"},
-		BranchPatterns:    []string{"test/.*", "^feature/.*$"},
+		Labels:            SubSignal{Values: []string{"LABEL_MERGE"}},
+		Comments:          SubSignal{Values: []string{"FULL_COMMENT_PLZ_MERGE"}},
+		CommentSubstrings: SubSignal{Values: []string{":+1:"}},
+		PRBodySubstrings:  SubSignal{Values: []string{"BODY_MERGE_PLZ"}},
+		Branches:          SubSignal{Values: []string{"develop"}},
+		BranchPatterns:    SubSignal{Values: []string{"test/.*", "^feature/.*$"}},
+		BranchPrefixes:    SubSignal{Values: []string{"release/"}},
+		BranchSuffixes:    SubSignal{Values: []string{"-hotfix"}},
 	}
 
 	ctx := context.Background()
@@ -111,7 +117,7 @@ func TestSignalsMatches(t *testing.T) {
 				BranchBase: "test/v9.9.9",
 			},
 			Matches: true,
-			Reason:  `pull request target branch ("test/v9.9.9") matches pattern: "test/.*"`,
+			Reason:  `pull request target branch ("test/v9.9.9") matches pattern: "test/.*" (branch patterns 1/2)`,
 		},
 		"targetBranchLikeSignalWithSpecialChars": {
 			PullContext: &pulltest.MockPullContext{
@@ -132,7 +138,28 @@ func TestSignalsMatches(t *testing.T) {
 				BranchBase: "feature/awesomeFeature",
 			},
 			Matches: true,
-			Reason:  `pull request target branch ("feature/awesomeFeature") matches pattern: "^feature/.*$"`,
+			Reason:  `pull request target branch ("feature/awesomeFeature") matches pattern: "^feature/.*$" (branch patterns 2/2)`,
+		},
+		"targetBranchMatchesPrefix": {
+			PullContext: &pulltest.MockPullContext{
+				BranchBase: "release/1.2.x",
+			},
+			Matches: true,
+			Reason:  `pull request target branch ("release/1.2.x") has a testlist prefix: "release/"`,
+		},
+		"targetBranchMatchesSuffix": {
+			PullContext: &pulltest.MockPullContext{
+				BranchBase: "1.2.x-hotfix",
+			},
+			Matches: true,
+			Reason:  `pull request target branch ("1.2.x-hotfix") has a testlist suffix: "-hotfix"`,
+		},
+		"targetBranchContainsPrefixNotAtStart": {
+			PullContext: &pulltest.MockPullContext{
+				BranchBase: "pre-release/1.2.x",
+			},
+			Matches: false,
+			Reason:  `pull request does not match the testlist`,
 		},
 	}
 
@@ -150,3 +177,5663 @@ func TestSignalsMatches(t *testing.T) {
 		})
 	}
 }
+
+func TestMatchTypeYAML(t *testing.T) {
+	for _, m := range []MatchType{MatchOne, MatchAll, MatchScore, ""} {
+		t.Run(m.String(), func(t *testing.T) {
+			out, err := yaml.Marshal(SubSignal{Values: []string{"merge when ready"}, Match: m})
+			require.NoError(t, err)
+
+			var ss SubSignal
+			require.NoError(t, yaml.UnmarshalStrict(out, &ss))
+			assert.Equal(t, m, ss.Match)
+		})
+	}
+
+	t.Run("string", func(t *testing.T) {
+		assert.Equal(t, "all", MatchAll.String())
+		assert.Equal(t, "one", MatchType("").String())
+	})
+
+	t.Run("typo", func(t *testing.T) {
+		var signals Signals
+		err := yaml.UnmarshalStrict([]byte("match: onee\nlabels: [\"merge when ready\"]\n"), &signals)
+		assert.EqualError(t, err, `invalid match type "onee": must be one of "one", "all", "score"`)
+
+		err = yaml.UnmarshalStrict([]byte("labels:\n  values: [\"merge when ready\"]\n  match: All\n"), &signals)
+		assert.EqualError(t, err, `invalid match type "All": must be one of "one", "all", "score"`)
+	})
+}
+
+func TestSignalsMatchesProtectedBase(t *testing.T) {
+	signals := Signals{
+		RequireProtectedBase: true,
+	}
+
+	ctx := context.Background()
+
+	t.Run("protectedBaseMatches", func(t *testing.T) {
+		pc := &pulltest.MockPullContext{
+			BranchBase:             "develop",
+			IsBranchProtectedValue: true,
+		}
+
+		matches, reason, err := signals.Matches(ctx, pc, "testlist")
+		require.NoError(t, err)
+		assert.True(t, matches)
+		assert.Equal(t, `pull request target branch ("develop") is a protected testlist branch`, reason)
+	})
+
+	t.Run("unprotectedBaseDoesNotMatch", func(t *testing.T) {
+		pc := &pulltest.MockPullContext{
+			BranchBase: "develop",
+		}
+
+		matches, reason, err := signals.Matches(ctx, pc, "testlist")
+		require.NoError(t, err)
+		assert.False(t, matches)
+		assert.Equal(t, `pull request does not match the testlist`, reason)
+	})
+
+	t.Run("unreadableProtectionReturnsError", func(t *testing.T) {
+		pc := &pulltest.MockPullContext{
+			BranchBase:                "develop",
+			IsBranchProtectedErrValue: errors.New("403 Resource not accessible by integration"),
+		}
+
+		matches, reason, err := signals.Matches(ctx, pc, "testlist")
+		require.Error(t, err)
+		assert.False(t, matches)
+		assert.Equal(t, `unable to determine if target branch "develop" is protected`, reason)
+	})
+}
+
+func TestSignalsMatchesBaseBranchExists(t *testing.T) {
+	signals := Signals{
+		Match:                   MatchAll,
+		RequireBaseBranchExists: true,
+	}
+
+	ctx := context.Background()
+
+	t.Run("existingBaseMatches", func(t *testing.T) {
+		pc := &pulltest.MockPullContext{
+			BranchBase:        "develop",
+			BranchExistsValue: true,
+		}
+
+		matches, reason, err := signals.Matches(ctx, pc, "testlist")
+		require.NoError(t, err)
+		assert.True(t, matches)
+		assert.Equal(t, `pull request matches all testlist signals: pull request target branch ("develop") exists in the testlist repository`, reason)
+	})
+
+	t.Run("deletedBaseDoesNotMatch", func(t *testing.T) {
+		pc := &pulltest.MockPullContext{
+			BranchBase: "develop",
+		}
+
+		matches, reason, err := signals.Matches(ctx, pc, "testlist")
+		require.NoError(t, err)
+		assert.False(t, matches)
+		assert.Equal(t, `pull request target branch ("develop") was deleted`, reason)
+	})
+
+	t.Run("unreadableBranchReturnsError", func(t *testing.T) {
+		pc := &pulltest.MockPullContext{
+			BranchBase:           "develop",
+			BranchExistsErrValue: errors.New("403 Resource not accessible by integration"),
+		}
+
+		matches, reason, err := signals.Matches(ctx, pc, "testlist")
+		require.Error(t, err)
+		assert.False(t, matches)
+		assert.Equal(t, `unable to determine if target branch "develop" exists`, reason)
+	})
+}
+
+func TestSignalsMatchesLocalSignalsFirst(t *testing.T) {
+	signals := Signals{
+		Labels:           SubSignal{Values: []string{"LABEL_MERGE"}},
+		Comments:         SubSignal{Values: []string{"FULL_COMMENT_PLZ_MERGE"}},
+		PRBodySubstrings: SubSignal{Values: []string{"BODY_MERGE_PLZ"}},
+		Branches:         SubSignal{Values: []string{"develop"}},
+	}
+
+	ctx := context.Background()
+
+	t.Run("bodySubstringSkipsAPICalls", func(t *testing.T) {
+		pc := &pulltest.MockPullContext{
+			BodyValue:       "My PR Body\n\n\n BODY_MERGE_PLZ",
+			LabelErrValue:   errors.New("labels should not be listed"),
+			CommentErrValue: errors.New("comments should not be listed"),
+		}
+
+		matches, reason, err := signals.Matches(ctx, pc, "testlist")
+		require.NoError(t, err)
+		assert.True(t, matches)
+		assert.Equal(t, `pull request body matches a testlist substring: "BODY_MERGE_PLZ"`, reason)
+	})
+
+	t.Run("branchSkipsAPICalls", func(t *testing.T) {
+		pc := &pulltest.MockPullContext{
+			BranchBase:      "develop",
+			LabelErrValue:   errors.New("labels should not be listed"),
+			CommentErrValue: errors.New("comments should not be listed"),
+		}
+
+		matches, reason, err := signals.Matches(ctx, pc, "testlist")
+		require.NoError(t, err)
+		assert.True(t, matches)
+		assert.Equal(t, `pull request target is a testlist branch: "develop"`, reason)
+	})
+
+	t.Run("bodyReportedBeforeLabel", func(t *testing.T) {
+		pc := &pulltest.MockPullContext{
+			BodyValue:  "BODY_MERGE_PLZ",
+			LabelValue: []string{"LABEL_MERGE"},
+		}
+
+		matches, reason, err := signals.Matches(ctx, pc, "testlist")
+		require.NoError(t, err)
+		assert.True(t, matches)
+		assert.Equal(t, `pull request body matches a testlist substring: "BODY_MERGE_PLZ"`, reason)
+	})
+}
+
+func TestSignalsMatchesDiffPatterns(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("addedLineMatches", func(t *testing.T) {
+		signals := Signals{DiffPatterns: SubSignal{Values: []string{`TODO`}}}
+		pc := &pulltest.MockPullContext{DiffValue: testDiff}
+
+		matches, reason, err := signals.Matches(ctx, pc, "testlist")
+		require.NoError(t, err)
+		assert.True(t, matches)
+		assert.Equal(t, `pull request added line in "main.go" matches a testlist diff pattern "TODO": "// TODO: remove this"`, reason)
+	})
+
+	t.Run("removedLineMatches", func(t *testing.T) {
+		signals := Signals{DiffPatterns: SubSignal{Values: []string{`^secret=`}}}
+		pc := &pulltest.MockPullContext{DiffValue: testDiff}
+
+		matches, reason, err := signals.Matches(ctx, pc, "testlist")
+		require.NoError(t, err)
+		assert.True(t, matches)
+		assert.Equal(t, `pull request removed line in "old.txt" matches a testlist diff pattern "^secret=": "secret=hunter2"`, reason)
+	})
+
+	t.Run("removedLineIgnoredWhenAddedOnly", func(t *testing.T) {
+		signals := Signals{DiffPatterns: SubSignal{Values: []string{`^secret=`}}, DiffAddedLinesOnly: true}
+		pc := &pulltest.MockPullContext{DiffValue: testDiff}
+
+		matches, _, err := signals.Matches(ctx, pc, "testlist")
+		require.NoError(t, err)
+		assert.False(t, matches)
+	})
+
+	t.Run("contextLinesDoNotMatch", func(t *testing.T) {
+		signals := Signals{DiffPatterns: SubSignal{Values: []string{`^package main$`}}}
+		pc := &pulltest.MockPullContext{DiffValue: testDiff}
+
+		matches, _, err := signals.Matches(ctx, pc, "testlist")
+		require.NoError(t, err)
+		assert.False(t, matches)
+	})
+
+	t.Run("diffTooLarge", func(t *testing.T) {
+		signals := Signals{DiffPatterns: SubSignal{Values: []string{`TODO`}}, MaxDiffBytes: 16}
+		pc := &pulltest.MockPullContext{DiffValue: testDiff}
+
+		matches, reason, err := signals.Matches(ctx, pc, "testlist")
+		require.Error(t, err)
+		assert.False(t, matches)
+		assert.Contains(t, reason, "pull request diff is too large to match against")
+	})
+
+	t.Run("invalidPattern", func(t *testing.T) {
+		signals := Signals{DiffPatterns: SubSignal{Values: []string{`(`}}}
+		pc := &pulltest.MockPullContext{DiffValue: testDiff}
+
+		_, reason, err := signals.Matches(ctx, pc, "testlist")
+		require.Error(t, err)
+		assert.Equal(t, `invalid testlist diff pattern: "("`, reason)
+	})
+}
+
+func TestSignalsMatchesCheckCount(t *testing.T) {
+	ctx := context.Background()
+
+	statuses := []*pull.Status{
+		{Context: "ci/circleci: build", State: "success"},
+		{Context: "ci/circleci: test", State: "pending"},
+	}
+
+	tests := map[string]struct {
+		Signals     Signals
+		PullContext pull.Context
+		Matches     bool
+		Reason      string
+	}{
+		"checksPresent": {
+			Signals:     Signals{RequireChecksPresent: true},
+			PullContext: &pulltest.MockPullContext{StatusesValue: statuses},
+			Matches:     true,
+			Reason:      `pull request has 2 status checks, meeting the testlist minimum of 1`,
+		},
+		"noChecksPresent": {
+			Signals:     Signals{RequireChecksPresent: true},
+			PullContext: &pulltest.MockPullContext{},
+			Matches:     false,
+			Reason:      `pull request does not match the testlist`,
+		},
+		"minChecksMetByPendingChecks": {
+			Signals:     Signals{MinChecks: 2},
+			PullContext: &pulltest.MockPullContext{StatusesValue: statuses},
+			Matches:     true,
+			Reason:      `pull request has 2 status checks, meeting the testlist minimum of 2`,
+		},
+		"minChecksNotMet": {
+			Signals:     Signals{MinChecks: 3},
+			PullContext: &pulltest.MockPullContext{StatusesValue: statuses},
+			Matches:     false,
+			Reason:      `pull request does not match the testlist`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			matches, reason, err := test.Signals.Matches(ctx, test.PullContext, "testlist")
+			require.NoError(t, err)
+			assert.Equal(t, test.Matches, matches)
+			assert.Equal(t, test.Reason, reason)
+		})
+	}
+
+	t.Run("errorListingChecks", func(t *testing.T) {
+		signals := Signals{MinChecks: 1}
+		pc := &pulltest.MockPullContext{StatusesErrValue: errors.New("failure")}
+
+		_, reason, err := signals.Matches(ctx, pc, "testlist")
+		require.Error(t, err)
+		assert.Equal(t, "unable to list pull request status checks", reason)
+	})
+}
+
+func TestSignalsMatchesMatchTypes(t *testing.T) {
+	ctx := context.Background()
+
+	tests := map[string]struct {
+		Signals     Signals
+		PullContext pull.Context
+		Matches     bool
+		Reason      string
+	}{
+		"oneMatchesAnySignal": {
+			Signals: Signals{
+				Labels:   SubSignal{Values: []string{"LABEL_MERGE"}},
+				Branches: SubSignal{Values: []string{"develop"}},
+			},
+			PullContext: &pulltest.MockPullContext{
+				BranchBase: "develop",
+			},
+			Matches: true,
+			Reason:  `pull request target is a testlist branch: "develop"`,
+		},
+		"allRequiresEverySignal": {
+			Signals: Signals{
+				Match:    MatchAll,
+				Labels:   SubSignal{Values: []string{"LABEL_MERGE"}},
+				Branches: SubSignal{Values: []string{"develop"}},
+			},
+			PullContext: &pulltest.MockPullContext{
+				BranchBase: "develop",
+			},
+			Matches: false,
+			Reason:  `pull request does not have a testlist label: "LABEL_MERGE"`,
+		},
+		"allMatchesEverySignal": {
+			Signals: Signals{
+				Match:    MatchAll,
+				Labels:   SubSignal{Values: []string{"LABEL_MERGE"}},
+				Branches: SubSignal{Values: []string{"develop"}},
+			},
+			PullContext: &pulltest.MockPullContext{
+				BranchBase: "develop",
+				LabelValue: []string{"LABEL_MERGE"},
+			},
+			Matches: true,
+			Reason:  `pull request matches all testlist signals: pull request target is a testlist branch: "develop"; pull request has a testlist label: "LABEL_MERGE"`,
+		},
+		"allInheritedByValues": {
+			Signals: Signals{
+				Match:  MatchAll,
+				Labels: SubSignal{Values: []string{"LABEL_MERGE", "LABEL_REVIEWED"}},
+			},
+			PullContext: &pulltest.MockPullContext{
+				LabelValue: []string{"LABEL_MERGE"},
+			},
+			Matches: false,
+			Reason:  `pull request does not have a testlist label: "LABEL_REVIEWED" (labels 2/2)`,
+		},
+		"allSignalsWithOneLabel": {
+			Signals: Signals{
+				Match:    MatchAll,
+				Labels:   SubSignal{Values: []string{"LABEL_MERGE", "LABEL_REVIEWED"}, Match: MatchOne},
+				Branches: SubSignal{Values: []string{"develop"}},
+			},
+			PullContext: &pulltest.MockPullContext{
+				BranchBase: "develop",
+				LabelValue: []string{"LABEL_REVIEWED"},
+			},
+			Matches: true,
+			Reason:  `pull request matches all testlist signals: pull request target is a testlist branch: "develop"; pull request has a testlist label: "LABEL_REVIEWED" (labels 2/2)`,
+		},
+		"oneSignalWithAllLabels": {
+			Signals: Signals{
+				Labels:   SubSignal{Values: []string{"LABEL_MERGE", "LABEL_REVIEWED"}, Match: MatchAll},
+				Branches: SubSignal{Values: []string{"develop"}},
+			},
+			PullContext: &pulltest.MockPullContext{
+				LabelValue: []string{"LABEL_REVIEWED", "LABEL_MERGE"},
+			},
+			Matches: true,
+			Reason:  `pull request matches all testlist labels`,
+		},
+		"oneSignalWithAllLabelsMissingOne": {
+			Signals: Signals{
+				Labels:   SubSignal{Values: []string{"LABEL_MERGE", "LABEL_REVIEWED"}, Match: MatchAll},
+				Branches: SubSignal{Values: []string{"develop"}},
+			},
+			PullContext: &pulltest.MockPullContext{
+				LabelValue: []string{"LABEL_REVIEWED"},
+			},
+			Matches: false,
+			Reason:  `pull request does not match the testlist`,
+		},
+		"allLabelsWithOneCreator": {
+			Signals: Signals{
+				Match:    MatchAll,
+				Labels:   SubSignal{Values: []string{"LABEL_MERGE", "LABEL_REVIEWED"}},
+				Creators: SubSignal{Values: []string{"dependabot[bot]", "renovate[bot]"}},
+			},
+			PullContext: &pulltest.MockPullContext{
+				CreatorValue: "renovate[bot]",
+				LabelValue:   []string{"LABEL_REVIEWED", "LABEL_MERGE"},
+			},
+			Matches: true,
+			Reason:  `pull request matches all testlist signals: pull request author "renovate[bot]" is a testlist creator; pull request matches all testlist labels`,
+		},
+		"allCreators": {
+			Signals: Signals{
+				Match:    MatchAll,
+				Creators: SubSignal{Values: []string{"dependabot[bot]", "renovate[bot]"}, Match: MatchAll},
+			},
+			PullContext: &pulltest.MockPullContext{
+				CreatorValue: "renovate[bot]",
+			},
+			Matches: false,
+			Reason:  `pull request author "renovate[bot]" is not the testlist creator "dependabot[bot]"`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			matches, reason, err := test.Signals.Matches(ctx, test.PullContext, "testlist")
+			require.NoError(t, err)
+			assert.Equal(t, test.Matches, matches)
+			assert.Equal(t, test.Reason, reason)
+		})
+	}
+}
+
+func TestSignalsMatchesMergeAttempts(t *testing.T) {
+	signals := Signals{
+		Match:            MatchAll,
+		MaxMergeAttempts: 3,
+	}
+
+	ctx := context.Background()
+
+	tests := map[int]struct {
+		Matches bool
+		Reason  string
+	}{
+		0: {true, `pull request matches all testlist signals: pull request has 0 failed merge attempts, below the testlist limit of 3`},
+		2: {true, `pull request matches all testlist signals: pull request has 2 failed merge attempts, below the testlist limit of 3`},
+		3: {false, `pull request has 3 failed merge attempts, reaching the testlist limit of 3`},
+		4: {false, `pull request has 4 failed merge attempts, reaching the testlist limit of 3`},
+	}
+
+	for attempts, test := range tests {
+		t.Run(fmt.Sprintf("attempts%d", attempts), func(t *testing.T) {
+			pc := &pulltest.MockPullContext{MergeAttemptsValue: attempts}
+
+			matches, reason, err := signals.Matches(ctx, pc, "testlist")
+			require.NoError(t, err)
+			assert.Equal(t, test.Matches, matches)
+			assert.Equal(t, test.Reason, reason)
+		})
+	}
+}
+
+func TestSignalsMatchesEnvironments(t *testing.T) {
+	signals := Signals{
+		Environments: SubSignal{Values: []string{"staging"}},
+	}
+
+	ctx := context.Background()
+	now := time.Now()
+
+	tests := map[string]struct {
+		Deployments []*pull.Deployment
+		Matches     bool
+		Reason      string
+	}{
+		"noDeployments": {
+			Matches: false,
+			Reason:  `pull request does not match the testlist`,
+		},
+		"otherEnvironment": {
+			Deployments: []*pull.Deployment{
+				{Environment: "production", State: "success", CreatedAt: now},
+			},
+			Matches: false,
+			Reason:  `pull request does not match the testlist`,
+		},
+		"latestSucceeded": {
+			Deployments: []*pull.Deployment{
+				{Environment: "staging", State: "failure", CreatedAt: now.Add(-time.Hour)},
+				{Environment: "staging", State: "success", CreatedAt: now},
+			},
+			Matches: true,
+			Reason:  `pull request's latest deployment to testlist environment "staging" is "success"`,
+		},
+		"latestFailed": {
+			Deployments: []*pull.Deployment{
+				{Environment: "staging", State: "failure", CreatedAt: now},
+				{Environment: "staging", State: "success", CreatedAt: now.Add(-time.Hour)},
+			},
+			Matches: false,
+			Reason:  `pull request does not match the testlist`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			pc := &pulltest.MockPullContext{DeploymentsValue: test.Deployments}
+
+			matches, reason, err := signals.Matches(ctx, pc, "testlist")
+			require.NoError(t, err)
+			assert.Equal(t, test.Matches, matches)
+			assert.Equal(t, test.Reason, reason)
+		})
+	}
+
+	t.Run("customState", func(t *testing.T) {
+		signals := Signals{
+			Match:            MatchAll,
+			Environments:     SubSignal{Values: []string{"staging"}},
+			EnvironmentState: "inactive",
+		}
+		pc := &pulltest.MockPullContext{
+			DeploymentsValue: []*pull.Deployment{
+				{Environment: "staging", State: "success", CreatedAt: now},
+			},
+		}
+
+		matches, reason, err := signals.Matches(ctx, pc, "testlist")
+		require.NoError(t, err)
+		assert.False(t, matches)
+		assert.Equal(t, `pull request's latest deployment to testlist environment "staging" is "success", not "inactive"`, reason)
+	})
+}
+
+func TestSignalsEvaluateCommentPatterns(t *testing.T) {
+	signals := Signals{
+		CommentPatterns: SubSignal{Values: []string{
+			`^/merge(?: (?P<method>squash|rebase|merge))?$`,
+			`^/ship$`,
+		}},
+	}
+
+	ctx := context.Background()
+
+	tests := map[string]struct {
+		Comments []string
+		Matches  bool
+		Method   MergeMethod
+	}{
+		"noMatch": {
+			Comments: []string{"/merge please"},
+			Matches:  false,
+		},
+		"capturedMethod": {
+			Comments: []string{"/merge squash"},
+			Matches:  true,
+			Method:   SquashAndMerge,
+		},
+		"lastCaptureWins": {
+			Comments: []string{"/merge squash", "/merge rebase"},
+			Matches:  true,
+			Method:   RebaseAndMerge,
+		},
+		"emptyCapture": {
+			Comments: []string{"/merge squash", "/merge"},
+			Matches:  true,
+			Method:   SquashAndMerge,
+		},
+		"groupNotInMatch": {
+			Comments: []string{"/merge"},
+			Matches:  true,
+		},
+		"patternWithoutGroup": {
+			Comments: []string{"/ship"},
+			Matches:  true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			pc := &pulltest.MockPullContext{CommentValue: test.Comments}
+
+			result, err := signals.Evaluate(ctx, pc, "testlist")
+			require.NoError(t, err)
+			assert.Equal(t, test.Matches, result.Matches)
+			assert.Equal(t, test.Method, result.Method)
+		})
+	}
+
+	t.Run("invalidPattern", func(t *testing.T) {
+		signals := Signals{
+			CommentPatterns: SubSignal{Values: []string{`/merge (`}},
+		}
+		pc := &pulltest.MockPullContext{CommentValue: []string{"/merge"}}
+
+		_, err := signals.Evaluate(ctx, pc, "testlist")
+		assert.Error(t, err)
+	})
+}
+
+func TestSignalsMatchesAuthorAssociation(t *testing.T) {
+	ctx := context.Background()
+
+	tests := map[string]struct {
+		Signals     Signals
+		Association string
+		Matches     bool
+		Reason      string
+	}{
+		"returningContributor": {
+			Signals:     Signals{RequireReturningContributor: true},
+			Association: "CONTRIBUTOR",
+			Matches:     true,
+			Reason:      `pull request author association ("CONTRIBUTOR") is at least the testlist minimum: "CONTRIBUTOR"`,
+		},
+		"firstTimeContributor": {
+			Signals:     Signals{RequireReturningContributor: true},
+			Association: "FIRST_TIME_CONTRIBUTOR",
+			Matches:     false,
+			Reason:      `pull request does not match the testlist`,
+		},
+		"memberAboveMinimum": {
+			Signals:     Signals{MinAuthorAssociation: "collaborator"},
+			Association: "MEMBER",
+			Matches:     true,
+			Reason:      `pull request author association ("MEMBER") is at least the testlist minimum: "COLLABORATOR"`,
+		},
+		"unknownAssociation": {
+			Signals:     Signals{Match: MatchAll, MinAuthorAssociation: "FIRST_TIME_CONTRIBUTOR"},
+			Association: "MANNEQUIN",
+			Matches:     false,
+			Reason:      `pull request author association ("MANNEQUIN") is below the testlist minimum: "FIRST_TIME_CONTRIBUTOR"`,
+		},
+		"strongerMinimumWins": {
+			Signals:     Signals{Match: MatchAll, RequireReturningContributor: true, MinAuthorAssociation: "MEMBER"},
+			Association: "CONTRIBUTOR",
+			Matches:     false,
+			Reason:      `pull request author association ("CONTRIBUTOR") is below the testlist minimum: "MEMBER"`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			pc := &pulltest.MockPullContext{AuthorAssociationValue: test.Association}
+
+			matches, reason, err := test.Signals.Matches(ctx, pc, "testlist")
+			require.NoError(t, err)
+			assert.Equal(t, test.Matches, matches)
+			assert.Equal(t, test.Reason, reason)
+		})
+	}
+
+	t.Run("invalidMinimum", func(t *testing.T) {
+		signals := Signals{MinAuthorAssociation: "ADMIN"}
+		pc := &pulltest.MockPullContext{AuthorAssociationValue: "OWNER"}
+
+		_, _, err := signals.Matches(ctx, pc, "testlist")
+		assert.Error(t, err)
+	})
+}
+
+func TestSignalsMatchesAuthorContributions(t *testing.T) {
+	ctx := context.Background()
+
+	tests := map[string]struct {
+		Contributions int
+		Matches       bool
+		Reason        string
+	}{
+		"enough": {
+			Contributions: 3,
+			Matches:       true,
+			Reason:        `pull request matches all testlist signals: pull request author "newcomer" has 3 merged pull requests, at least the testlist minimum of 2`,
+		},
+		"tooFew": {
+			Contributions: 1,
+			Matches:       false,
+			Reason:        `pull request author "newcomer" has 1 merged pull requests, fewer than the testlist minimum of 2`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			signals := Signals{Match: MatchAll, MinAuthorContributions: 2}
+			pc := &pulltest.MockPullContext{CreatorValue: "newcomer", AuthorContributionsValue: test.Contributions}
+
+			matches, reason, err := signals.Matches(ctx, pc, "testlist")
+			require.NoError(t, err)
+			assert.Equal(t, test.Matches, matches)
+			assert.Equal(t, test.Reason, reason)
+		})
+	}
+
+	t.Run("searchError", func(t *testing.T) {
+		signals := Signals{MinAuthorContributions: 2}
+		pc := &pulltest.MockPullContext{CreatorValue: "newcomer", AuthorContributionsErrValue: errors.New("search failed")}
+
+		_, reason, err := signals.Matches(ctx, pc, "testlist")
+		assert.Error(t, err)
+		assert.Equal(t, `unable to count merged pull requests by "newcomer"`, reason)
+	})
+}
+
+func TestSignalsMatchesAuthorAccountAge(t *testing.T) {
+	ctx := context.Background()
+
+	current := time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC)
+	defer func(original func() time.Time) { now = original }(now)
+	now = func() time.Time { return current }
+
+	tests := map[string]struct {
+		CreatedAt time.Time
+		Matches   bool
+		Reason    string
+	}{
+		"tooNew": {
+			CreatedAt: current.Add(-2 * time.Hour),
+			Matches:   false,
+			Reason:    `pull request author "newcomer" has had an account for 2h0m0s, less than the testlist minimum of 24h0m0s`,
+		},
+		"oldEnough": {
+			CreatedAt: current.Add(-48 * time.Hour),
+			Matches:   true,
+			Reason:    `pull request matches all testlist signals: pull request author "newcomer" has had an account for 48h0m0s, at least the testlist minimum of 24h0m0s`,
+		},
+		"unknown": {
+			Matches: false,
+			Reason:  `unable to determine when the account of "newcomer" was created`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			signals := Signals{Match: MatchAll, MinAuthorAccountAge: 24 * time.Hour}
+			pc := &pulltest.MockPullContext{CreatorValue: "newcomer", AuthorCreatedAtValue: test.CreatedAt}
+
+			matches, reason, err := signals.Matches(ctx, pc, "testlist")
+			require.NoError(t, err)
+			assert.Equal(t, test.Matches, matches)
+			assert.Equal(t, test.Reason, reason)
+		})
+	}
+}
+
+func TestSignalsEvaluateMatchedIndex(t *testing.T) {
+	ctx := context.Background()
+
+	tests := map[string]struct {
+		Signals      Signals
+		Creator      string
+		Comments     []string
+		Matches      bool
+		Reason       string
+		MatchedIndex int
+	}{
+		"thirdOfFour": {
+			Signals: Signals{
+				CommentSubstrings: SubSignal{Values: []string{"==MERGE==", "==SHIP==", "==LGTM==", "==GO=="}},
+			},
+			Comments:     []string{"looks good ==LGTM=="},
+			Matches:      true,
+			Reason:       `pull request comment matches a testlist substring: "==LGTM==" (comment substrings 3/4)`,
+			MatchedIndex: 3,
+		},
+		"singleValue": {
+			Signals: Signals{
+				CommentSubstrings: SubSignal{Values: []string{"==MERGE=="}},
+			},
+			Comments:     []string{"==MERGE=="},
+			Matches:      true,
+			Reason:       `pull request comment matches a testlist substring: "==MERGE=="`,
+			MatchedIndex: 1,
+		},
+		"noMatch": {
+			Signals: Signals{
+				CommentSubstrings: SubSignal{Values: []string{"==MERGE==", "==SHIP=="}},
+			},
+			Comments: []string{"not yet"},
+			Matches:  false,
+			Reason:   `pull request does not match the testlist`,
+		},
+		"allFailsOnSecond": {
+			Signals: Signals{
+				Match:             MatchAll,
+				CommentSubstrings: SubSignal{Values: []string{"==MERGE==", "==SHIP=="}},
+			},
+			Comments: []string{"==MERGE=="},
+			Matches:  false,
+			Reason:   `pull request body is empty and comments do not match a testlist substring: "==SHIP==" (comment substrings 2/2)`,
+		},
+		"firstCreator": {
+			Signals: Signals{
+				Creators: SubSignal{Values: []string{"alice", "bob"}},
+			},
+			Creator:      "Alice",
+			Matches:      true,
+			Reason:       `pull request author "Alice" is a testlist creator`,
+			MatchedIndex: 1,
+		},
+		"firstGroup": {
+			Signals: Signals{
+				Groups: map[string]Signals{
+					"alice": {Creators: SubSignal{Values: []string{"alice"}}},
+					"bob":   {Creators: SubSignal{Values: []string{"bob"}}},
+				},
+			},
+			Creator:      "alice",
+			Matches:      true,
+			Reason:       `pull request matches the testlist group "alice": pull request author "alice" is a testlist creator`,
+			MatchedIndex: 1,
+		},
+		"secondGroup": {
+			Signals: Signals{
+				Groups: map[string]Signals{
+					"alice": {Creators: SubSignal{Values: []string{"alice"}}},
+					"bob":   {Creators: SubSignal{Values: []string{"bob"}}},
+				},
+			},
+			Creator:      "bob",
+			Matches:      true,
+			Reason:       `pull request matches the testlist group "bob": pull request author "bob" is a testlist creator`,
+			MatchedIndex: 2,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			pc := &pulltest.MockPullContext{CreatorValue: test.Creator, CommentValue: test.Comments}
+
+			result, err := test.Signals.Evaluate(ctx, pc, "testlist")
+			require.NoError(t, err)
+			assert.Equal(t, test.Matches, result.Matches)
+			assert.Equal(t, test.Reason, result.Reason)
+			assert.Equal(t, test.MatchedIndex, result.MatchedIndex)
+		})
+	}
+}
+
+func TestSignalsMatchesCommits(t *testing.T) {
+	ctx := context.Background()
+
+	alice := pull.CommitIdentity{Login: "alice", Email: "alice@example.com"}
+	mallory := pull.CommitIdentity{Email: "mallory@example.com"}
+
+	tests := map[string]struct {
+		Signals Signals
+		Commits []*pull.Commit
+		Matches bool
+		Reason  string
+	}{
+		"allowedAuthors": {
+			Signals: Signals{CommitAuthors: []string{"Alice", "bob@example.com"}},
+			Commits: []*pull.Commit{
+				{SHA: "a1", Author: alice},
+				{SHA: "b2", Author: pull.CommitIdentity{Email: "BOB@example.com"}},
+			},
+			Matches: true,
+			Reason:  `all 2 pull request commits are by testlist commit authors`,
+		},
+		"disallowedAuthor": {
+			Signals: Signals{Match: MatchAll, CommitAuthors: []string{"alice"}},
+			Commits: []*pull.Commit{
+				{SHA: "a1", Author: alice},
+				{SHA: "c3", Author: mallory},
+			},
+			Matches: false,
+			Reason:  `pull request commit c3 is not by a testlist commit author: <mallory@example.com>`,
+		},
+		"verified": {
+			Signals: Signals{CommitAuthors: []string{"alice"}, RequireVerifiedCommits: true},
+			Commits: []*pull.Commit{
+				{SHA: "a1", Author: alice, Verified: true},
+			},
+			Matches: true,
+			Reason:  `all 1 pull request commits are by testlist commit authors and verified`,
+		},
+		"unverified": {
+			Signals: Signals{Match: MatchAll, RequireVerifiedCommits: true},
+			Commits: []*pull.Commit{
+				{SHA: "a1", Author: alice, Verified: true},
+				{SHA: "d4", Author: alice},
+			},
+			Matches: false,
+			Reason:  `pull request commit d4 does not have a verified signature`,
+		},
+		"signed": {
+			Signals: Signals{RequireSignedCommits: true},
+			Commits: []*pull.Commit{
+				{SHA: "a1", Author: alice, Verified: true, VerificationReason: "valid"},
+				{SHA: "b2", Author: alice, Verified: true, VerificationReason: "valid"},
+			},
+			Matches: true,
+			Reason:  `all 2 pull request commits have verified signatures`,
+		},
+		"unsigned": {
+			Signals: Signals{Match: MatchAll, RequireSignedCommits: true},
+			Commits: []*pull.Commit{
+				{SHA: "a1", Author: alice, Verified: true, VerificationReason: "valid"},
+				{SHA: "e5", Author: alice, VerificationReason: "unsigned"},
+			},
+			Matches: false,
+			Reason:  `pull request commit e5 is not signed with a verified signature (unsigned)`,
+		},
+		"invalidSignature": {
+			Signals: Signals{Match: MatchAll, RequireSignedCommits: true},
+			Commits: []*pull.Commit{
+				{SHA: "f6", Author: alice, VerificationReason: "unknown_key"},
+			},
+			Matches: false,
+			Reason:  `pull request commit f6 is not signed with a verified signature (unknown_key)`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			pc := &pulltest.MockPullContext{CommitsValue: test.Commits}
+
+			matches, reason, err := test.Signals.Matches(ctx, pc, "testlist")
+			require.NoError(t, err)
+			assert.Equal(t, test.Matches, matches)
+			assert.Equal(t, test.Reason, reason)
+		})
+	}
+}
+
+func TestSignalsMatchesOnlyAuthorComments(t *testing.T) {
+	signals := Signals{
+		Match:              MatchAll,
+		Comments:           SubSignal{Values: []string{"/merge"}},
+		OnlyAuthorComments: true,
+	}
+
+	ctx := context.Background()
+
+	tests := map[string]struct {
+		Comments []*pull.Comment
+		Matches  bool
+		Reason   string
+	}{
+		"authorComment": {
+			Comments: []*pull.Comment{
+				{Author: "Alice", Body: "/merge"},
+			},
+			Matches: true,
+			Reason:  `pull request matches all testlist signals: pull request author self-triggered with a testlist comment: "/merge"`,
+		},
+		"otherUserComment": {
+			Comments: []*pull.Comment{
+				{Author: "alice", Body: "thanks!"},
+				{Author: "bob", Body: "/merge"},
+				{Author: "some-bot[bot]", Body: "/merge"},
+			},
+			Matches: false,
+			Reason:  `pull request has an empty body and does not have a testlist comment: "/merge"`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			pc := &pulltest.MockPullContext{
+				CreatorValue:         "alice",
+				AuthoredCommentValue: test.Comments,
+			}
+
+			matches, reason, err := signals.Matches(ctx, pc, "testlist")
+			require.NoError(t, err)
+			assert.Equal(t, test.Matches, matches)
+			assert.Equal(t, test.Reason, reason)
+		})
+	}
+}
+
+func TestSignalsMatchesEditedComments(t *testing.T) {
+	ctx := context.Background()
+
+	tests := map[string]struct {
+		UseOriginal bool
+		Comments    []*pull.Comment
+		Matches     bool
+		Reason      string
+	}{
+		"latestBody": {
+			Comments: []*pull.Comment{
+				{Body: "/merge", Edited: true, OriginalBody: "lgtm"},
+			},
+			Matches: true,
+			Reason:  `pull request matches all testlist signals: pull request has a testlist comment: "/merge" (in an edited comment)`,
+		},
+		"uneditedComment": {
+			UseOriginal: true,
+			Comments: []*pull.Comment{
+				{Body: "/merge"},
+			},
+			Matches: true,
+			Reason:  `pull request matches all testlist signals: pull request has a testlist comment: "/merge"`,
+		},
+		"originalBodyMatches": {
+			UseOriginal: true,
+			Comments: []*pull.Comment{
+				{Body: "/merge (edit: typo)", Edited: true, OriginalBody: "/merge"},
+			},
+			Matches: true,
+			Reason:  `pull request matches all testlist signals: pull request has a testlist comment: "/merge" (in the original body of an edited comment)`,
+		},
+		"editAddedTrigger": {
+			UseOriginal: true,
+			Comments: []*pull.Comment{
+				{Body: "/merge", Edited: true, OriginalBody: "lgtm"},
+			},
+			Matches: false,
+			Reason:  `pull request has an empty body and does not have a testlist comment: "/merge"`,
+		},
+		"originalBodyUnavailable": {
+			UseOriginal: true,
+			Comments: []*pull.Comment{
+				{Body: "/merge", Edited: true},
+			},
+			Matches: false,
+			Reason:  `pull request has an empty body and does not have a testlist comment: "/merge"`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			signals := Signals{
+				Match:                  MatchAll,
+				Comments:               SubSignal{Values: []string{"/merge"}},
+				UseOriginalCommentBody: test.UseOriginal,
+			}
+			pc := &pulltest.MockPullContext{
+				AuthoredCommentValue: test.Comments,
+			}
+
+			matches, reason, err := signals.Matches(ctx, pc, "testlist")
+			require.NoError(t, err)
+			assert.Equal(t, test.Matches, matches)
+			assert.Equal(t, test.Reason, reason)
+		})
+	}
+}
+
+func TestSignalsMatchesOverrideLabels(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+
+	tests := map[string]struct {
+		Signals Signals
+		Labels  []string
+		Events  []*pull.LabelEvent
+		Matches bool
+		Reason  string
+	}{
+		"overridePresent": {
+			Signals: Signals{
+				Match:          MatchAll,
+				OverrideLabels: []string{"force-merge"},
+				Branches:       SubSignal{Values: []string{"main"}},
+			},
+			Labels:  []string{"Force-Merge"},
+			Matches: true,
+			Reason:  `pull request has a testlist override label: "force-merge"`,
+		},
+		"overrideAbsent": {
+			Signals: Signals{
+				Match:          MatchAll,
+				OverrideLabels: []string{"force-merge"},
+				Branches:       SubSignal{Values: []string{"main"}},
+			},
+			Labels:  []string{"merge when ready"},
+			Matches: false,
+			Reason:  `pull request target branch ("develop") is not a testlist branch: "main"`,
+		},
+		"overrideIgnoresInvert": {
+			Signals: Signals{
+				Invert:         true,
+				OverrideLabels: []string{"force-merge"},
+				Branches:       SubSignal{Values: []string{"develop"}},
+			},
+			Labels:  []string{"force-merge"},
+			Matches: true,
+			Reason:  `pull request has a testlist override label: "force-merge"`,
+		},
+		"allowedActor": {
+			Signals: Signals{
+				OverrideLabels:      []string{"force-merge"},
+				OverrideLabelActors: []string{"Release-Manager"},
+				Branches:            SubSignal{Values: []string{"main"}},
+			},
+			Labels: []string{"force-merge"},
+			Events: []*pull.LabelEvent{
+				{Label: "force-merge", Actor: "mallory", CreatedAt: now.Add(-time.Hour)},
+				{Label: "force-merge", Actor: "release-manager", CreatedAt: now},
+			},
+			Matches: true,
+			Reason:  `pull request has a testlist override label added by "release-manager": "force-merge"`,
+		},
+		"disallowedActor": {
+			Signals: Signals{
+				OverrideLabels:      []string{"force-merge"},
+				OverrideLabelActors: []string{"release-manager"},
+				Branches:            SubSignal{Values: []string{"main"}},
+			},
+			Labels: []string{"force-merge"},
+			Events: []*pull.LabelEvent{
+				{Label: "force-merge", Actor: "release-manager", CreatedAt: now.Add(-time.Hour)},
+				{Label: "force-merge", Actor: "mallory", CreatedAt: now},
+			},
+			Matches: false,
+			Reason:  `pull request does not match the testlist`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			pc := &pulltest.MockPullContext{
+				BranchBase:       "develop",
+				LabelValue:       test.Labels,
+				LabelEventsValue: test.Events,
+			}
+
+			matches, reason, err := test.Signals.Matches(ctx, pc, "testlist")
+			require.NoError(t, err)
+			assert.Equal(t, test.Matches, matches)
+			assert.Equal(t, test.Reason, reason)
+		})
+	}
+
+	t.Run("enablesSignals", func(t *testing.T) {
+		signals := Signals{OverrideLabels: []string{"force-merge"}}
+		assert.True(t, signals.Enabled())
+	})
+}
+
+func TestSignalsMatchesBlockedCreators(t *testing.T) {
+	ctx := context.Background()
+
+	tests := map[string]struct {
+		Signals Signals
+		Creator string
+		Labels  []string
+		Matches bool
+		Reason  string
+	}{
+		"blockedLogin": {
+			Signals: Signals{
+				BlockedCreators: []string{"Untrusted-Bot[bot]"},
+				Labels:          SubSignal{Values: []string{"merge when ready"}},
+			},
+			Creator: "untrusted-bot[bot]",
+			Labels:  []string{"merge when ready"},
+			Matches: false,
+			Reason:  `pull request author "untrusted-bot[bot]" is a blocked testlist creator`,
+		},
+		"blockedPattern": {
+			Signals: Signals{
+				BlockedCreatorPatterns: []string{"contractor-.*"},
+				Labels:                 SubSignal{Values: []string{"merge when ready"}},
+			},
+			Creator: "Contractor-Bob",
+			Labels:  []string{"merge when ready"},
+			Matches: false,
+			Reason:  `pull request author "Contractor-Bob" matches a blocked testlist creator pattern: "contractor-.*"`,
+		},
+		"patternMatchesWholeLogin": {
+			Signals: Signals{
+				BlockedCreatorPatterns: []string{"contractor"},
+				Labels:                 SubSignal{Values: []string{"merge when ready"}},
+			},
+			Creator: "contractor-bob",
+			Labels:  []string{"merge when ready"},
+			Matches: true,
+			Reason:  `pull request has a testlist label: "merge when ready"`,
+		},
+		"notBlocked": {
+			Signals: Signals{
+				BlockedCreators:        []string{"untrusted-bot[bot]"},
+				BlockedCreatorPatterns: []string{"contractor-.*"},
+				Labels:                 SubSignal{Values: []string{"merge when ready"}},
+			},
+			Creator: "mhaypenny",
+			Labels:  []string{"merge when ready"},
+			Matches: true,
+			Reason:  `pull request has a testlist label: "merge when ready"`,
+		},
+		"blockedDespiteOverride": {
+			Signals: Signals{
+				BlockedCreators: []string{"untrusted-bot[bot]"},
+				OverrideLabels:  []string{"force-merge"},
+			},
+			Creator: "untrusted-bot[bot]",
+			Labels:  []string{"force-merge"},
+			Matches: false,
+			Reason:  `pull request author "untrusted-bot[bot]" is a blocked testlist creator`,
+		},
+		"blockedDespiteInvert": {
+			Signals: Signals{
+				Invert:          true,
+				BlockedCreators: []string{"untrusted-bot[bot]"},
+				Labels:          SubSignal{Values: []string{"do not merge"}},
+			},
+			Creator: "untrusted-bot[bot]",
+			Matches: false,
+			Reason:  `pull request author "untrusted-bot[bot]" is a blocked testlist creator`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			pc := &pulltest.MockPullContext{
+				CreatorValue: test.Creator,
+				LabelValue:   test.Labels,
+			}
+
+			matches, reason, err := test.Signals.Matches(ctx, pc, "testlist")
+			require.NoError(t, err)
+			assert.Equal(t, test.Matches, matches)
+			assert.Equal(t, test.Reason, reason)
+		})
+	}
+
+	t.Run("invalidPattern", func(t *testing.T) {
+		signals := Signals{BlockedCreatorPatterns: []string{"contractor-("}}
+		pc := &pulltest.MockPullContext{CreatorValue: "contractor-bob"}
+
+		_, _, err := signals.Matches(ctx, pc, "testlist")
+		assert.Error(t, err)
+	})
+}
+
+func TestSignalsMatchesCaseSensitiveLabels(t *testing.T) {
+	ctx := context.Background()
+
+	tests := map[string]struct {
+		CaseSensitive bool
+		Labels        []string
+		Matches       bool
+		Reason        string
+	}{
+		"caseInsensitiveByDefault": {
+			Labels:  []string{"wip"},
+			Matches: true,
+			Reason:  `pull request matches all testlist signals: pull request has a testlist label: "WIP"`,
+		},
+		"sameCase": {
+			CaseSensitive: true,
+			Labels:        []string{"bug", "WIP"},
+			Matches:       true,
+			Reason:        `pull request matches all testlist signals: pull request has a testlist label: "WIP"`,
+		},
+		"differentCase": {
+			CaseSensitive: true,
+			Labels:        []string{"wip"},
+			Matches:       false,
+			Reason:        `pull request does not have a testlist label: "WIP"`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			signals := Signals{
+				Match:  MatchAll,
+				Labels: SubSignal{Values: []string{"WIP"}, CaseSensitive: test.CaseSensitive},
+			}
+			pc := &pulltest.MockPullContext{LabelValue: test.Labels}
+
+			matches, reason, err := signals.Matches(ctx, pc, "testlist")
+			require.NoError(t, err)
+			assert.Equal(t, test.Matches, matches)
+			assert.Equal(t, test.Reason, reason)
+		})
+	}
+
+	t.Run("labelsAfterLastCommit", func(t *testing.T) {
+		lastCommit := time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC)
+		signals := Signals{
+			Match: MatchAll,
+			Labels: SubSignal{
+				Values:                []string{"WIP"},
+				LabelsAfterLastCommit: true,
+				CaseSensitive:         true,
+			},
+		}
+		pc := &pulltest.MockPullContext{
+			LabelValue:   []string{"wip"},
+			CommitsValue: []*pull.Commit{{SHA: "c1", CommittedAt: lastCommit}},
+			LabelEventsValue: []*pull.LabelEvent{
+				{Label: "wip", Actor: "alice", CreatedAt: lastCommit.Add(time.Second)},
+			},
+		}
+
+		matches, reason, err := signals.Matches(ctx, pc, "testlist")
+		require.NoError(t, err)
+		assert.False(t, matches)
+		assert.Equal(t, `pull request does not have a testlist label: "WIP"`, reason)
+	})
+}
+
+func TestSignalsMatchesLabelsAfterLastCommit(t *testing.T) {
+	signals := Signals{
+		Match: MatchAll,
+		Labels: SubSignal{
+			Values:                []string{"ready-to-merge"},
+			LabelsAfterLastCommit: true,
+		},
+	}
+
+	ctx := context.Background()
+	lastCommit := time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := map[string]struct {
+		Labels  []string
+		Events  []*pull.LabelEvent
+		Matches bool
+		Reason  string
+	}{
+		"appliedAfterLastCommit": {
+			Labels: []string{"ready-to-merge"},
+			Events: []*pull.LabelEvent{
+				{Label: "ready-to-merge", Actor: "alice", CreatedAt: lastCommit.Add(time.Second)},
+			},
+			Matches: true,
+			Reason:  `pull request matches all testlist signals: pull request has a testlist label applied after the last commit: "ready-to-merge" (applied 2020-06-01T12:00:01Z)`,
+		},
+		"appliedBeforeLastCommit": {
+			Labels: []string{"ready-to-merge"},
+			Events: []*pull.LabelEvent{
+				{Label: "ready-to-merge", Actor: "alice", CreatedAt: lastCommit.Add(-time.Hour)},
+			},
+			Matches: false,
+			Reason:  `pull request testlist label "ready-to-merge" was applied before the last commit (applied 2020-06-01T11:00:00Z)`,
+		},
+		"appliedAtLastCommit": {
+			Labels: []string{"ready-to-merge"},
+			Events: []*pull.LabelEvent{
+				{Label: "ready-to-merge", Actor: "alice", CreatedAt: lastCommit},
+			},
+			Matches: false,
+			Reason:  `pull request testlist label "ready-to-merge" was applied before the last commit (applied 2020-06-01T12:00:00Z)`,
+		},
+		"reappliedAfterLastCommit": {
+			Labels: []string{"ready-to-merge"},
+			Events: []*pull.LabelEvent{
+				{Label: "ready-to-merge", Actor: "alice", CreatedAt: lastCommit.Add(-time.Hour)},
+				{Label: "Ready-To-Merge", Actor: "bob", CreatedAt: lastCommit.Add(time.Hour)},
+			},
+			Matches: true,
+			Reason:  `pull request matches all testlist signals: pull request has a testlist label applied after the last commit: "ready-to-merge" (applied 2020-06-01T13:00:00Z)`,
+		},
+		"removedAfterApplied": {
+			Events: []*pull.LabelEvent{
+				{Label: "ready-to-merge", Actor: "alice", CreatedAt: lastCommit.Add(time.Hour)},
+			},
+			Matches: false,
+			Reason:  `pull request does not have a testlist label: "ready-to-merge"`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			pc := &pulltest.MockPullContext{
+				LabelValue:       test.Labels,
+				LabelEventsValue: test.Events,
+				CommitsValue: []*pull.Commit{
+					{SHA: "a1", CommittedAt: lastCommit.Add(-2 * time.Hour)},
+					{SHA: "b2", CommittedAt: lastCommit},
+				},
+			}
+
+			matches, reason, err := signals.Matches(ctx, pc, "testlist")
+			require.NoError(t, err)
+			assert.Equal(t, test.Matches, matches)
+			assert.Equal(t, test.Reason, reason)
+		})
+	}
+}
+
+func TestSignalsMatchesBinaryFiles(t *testing.T) {
+	ctx := context.Background()
+
+	files := []*pull.File{
+		{Filename: "README.md", Status: "modified", Additions: 2},
+		{Filename: "assets/logo.png", Status: "added", Binary: true},
+		{Filename: "dist/app.jar", Status: "modified", Binary: true},
+	}
+
+	tests := map[string]struct {
+		Signals Signals
+		Matches bool
+		Reason  string
+	}{
+		"disallowed": {
+			Signals: Signals{Match: MatchAll, DisallowBinaryChanges: true},
+			Matches: false,
+			Reason:  `pull request changes 2 binary files, exceeding the testlist limit of 0: "assets/logo.png"`,
+		},
+		"belowLimit": {
+			Signals: Signals{Match: MatchAll, MaxBinaryFiles: 2},
+			Matches: true,
+			Reason:  `pull request matches all testlist signals: pull request changes 2 binary files, within the testlist limit of 2`,
+		},
+		"aboveLimit": {
+			Signals: Signals{Match: MatchAll, MaxBinaryFiles: 1},
+			Matches: false,
+			Reason:  `pull request changes 2 binary files, exceeding the testlist limit of 1: "assets/logo.png"`,
+		},
+		"disallowOverridesLimit": {
+			Signals: Signals{Match: MatchAll, DisallowBinaryChanges: true, MaxBinaryFiles: 5},
+			Matches: false,
+			Reason:  `pull request changes 2 binary files, exceeding the testlist limit of 0: "assets/logo.png"`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			pc := &pulltest.MockPullContext{ChangedFilesValue: files}
+
+			matches, reason, err := test.Signals.Matches(ctx, pc, "testlist")
+			require.NoError(t, err)
+			assert.Equal(t, test.Matches, matches)
+			assert.Equal(t, test.Reason, reason)
+		})
+	}
+}
+
+func TestSignalsMatchesDirectories(t *testing.T) {
+	ctx := context.Background()
+
+	files := []*pull.File{
+		{Filename: "README.md"},
+		{Filename: "bulldozer/signals.go"},
+		{Filename: "bulldozer/signals_test.go"},
+		{Filename: "pull/pulltest/mock_context.go"},
+		{Filename: "pull/context.go"},
+	}
+
+	tests := map[string]struct {
+		Signals Signals
+		Matches bool
+		Reason  string
+	}{
+		"withinBounds": {
+			Signals: Signals{Match: MatchAll, MinDirectories: 2, MaxDirectories: 3},
+			Matches: true,
+			Reason:  `pull request matches all testlist signals: pull request changes 3 directories, within the testlist bounds`,
+		},
+		"tooMany": {
+			Signals: Signals{Match: MatchAll, MaxDirectories: 2},
+			Matches: false,
+			Reason:  `pull request changes 3 directories, more than the testlist maximum of 2: ., bulldozer, pull`,
+		},
+		"tooFew": {
+			Signals: Signals{Match: MatchAll, MinDirectories: 4},
+			Matches: false,
+			Reason:  `pull request changes 3 directories, fewer than the testlist minimum of 4: ., bulldozer, pull`,
+		},
+		"deeperGrouping": {
+			Signals: Signals{Match: MatchAll, MaxDirectories: 3, DirectoryDepth: 2},
+			Matches: false,
+			Reason:  `pull request changes 4 directories, more than the testlist maximum of 3: ., bulldozer, pull, pull/pulltest`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			pc := &pulltest.MockPullContext{ChangedFilesValue: files}
+
+			matches, reason, err := test.Signals.Matches(ctx, pc, "testlist")
+			require.NoError(t, err)
+			assert.Equal(t, test.Matches, matches)
+			assert.Equal(t, test.Reason, reason)
+		})
+	}
+}
+
+func TestSignalsMatchesReportAllReasons(t *testing.T) {
+	ctx := context.Background()
+
+	signals := Signals{
+		Match:            MatchAll,
+		ReportAllReasons: true,
+		Branches:         SubSignal{Values: []string{"develop"}},
+		Labels:           SubSignal{Values: []string{"merge"}},
+		Comments:         SubSignal{Values: []string{"/merge"}},
+	}
+
+	t.Run("multipleFailures", func(t *testing.T) {
+		pc := &pulltest.MockPullContext{
+			BranchBase: "develop",
+		}
+
+		result, err := signals.Evaluate(ctx, pc, "testlist")
+		require.NoError(t, err)
+		assert.False(t, result.Matches)
+		assert.Equal(t, []string{
+			`pull request does not have a testlist label: "merge"`,
+			`pull request has an empty body and does not have a testlist comment: "/merge"`,
+		}, result.FailedReasons)
+		assert.Equal(t, `pull request does not match 2 testlist signals: pull request does not have a testlist label: "merge"; pull request has an empty body and does not have a testlist comment: "/merge"`, result.Reason)
+	})
+
+	t.Run("singleFailure", func(t *testing.T) {
+		pc := &pulltest.MockPullContext{
+			BranchBase:   "develop",
+			LabelValue:   []string{"merge"},
+			CommentValue: []string{"please wait"},
+		}
+
+		result, err := signals.Evaluate(ctx, pc, "testlist")
+		require.NoError(t, err)
+		assert.False(t, result.Matches)
+		assert.Equal(t, `pull request has an empty body and does not have a testlist comment: "/merge"`, result.Reason)
+	})
+
+	t.Run("firstFailureOnly", func(t *testing.T) {
+		signals := signals
+		signals.ReportAllReasons = false
+		pc := &pulltest.MockPullContext{
+			BranchBase: "develop",
+		}
+
+		result, err := signals.Evaluate(ctx, pc, "testlist")
+		require.NoError(t, err)
+		assert.False(t, result.Matches)
+		assert.Equal(t, []string{`pull request does not have a testlist label: "merge"`}, result.FailedReasons)
+		assert.Equal(t, `pull request does not have a testlist label: "merge"`, result.Reason)
+	})
+
+	t.Run("allMatch", func(t *testing.T) {
+		pc := &pulltest.MockPullContext{
+			BranchBase:   "develop",
+			LabelValue:   []string{"merge"},
+			CommentValue: []string{"/merge"},
+		}
+
+		result, err := signals.Evaluate(ctx, pc, "testlist")
+		require.NoError(t, err)
+		assert.True(t, result.Matches)
+		assert.Empty(t, result.FailedReasons)
+	})
+}
+
+func TestSignalsMatchesQueueFront(t *testing.T) {
+	signals := Signals{
+		Match:             MatchAll,
+		RequireQueueFront: true,
+	}
+
+	ctx := context.Background()
+
+	tests := map[string]struct {
+		Labels  []string
+		Matches bool
+		Reason  string
+	}{
+		"front": {
+			Labels:  []string{"merge", "queue-position:1"},
+			Matches: true,
+			Reason:  `pull request matches all testlist signals: pull request is at the front of the testlist queue`,
+		},
+		"notFront": {
+			Labels:  []string{"queue-position:3"},
+			Matches: false,
+			Reason:  `pull request is at position 3 in the testlist queue, not the front`,
+		},
+		"lowestPositionWins": {
+			Labels:  []string{"queue-position:2", "Queue-Position:1"},
+			Matches: true,
+			Reason:  `pull request matches all testlist signals: pull request is at the front of the testlist queue`,
+		},
+		"absent": {
+			Labels:  []string{"merge"},
+			Matches: false,
+			Reason:  `pull request is not in the testlist queue`,
+		},
+		"invalidPosition": {
+			Labels:  []string{"queue-position:first", "queue-position:0"},
+			Matches: false,
+			Reason:  `pull request is not in the testlist queue`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			pc := &pulltest.MockPullContext{LabelValue: test.Labels}
+
+			matches, reason, err := signals.Matches(ctx, pc, "testlist")
+			require.NoError(t, err)
+			assert.Equal(t, test.Matches, matches)
+			assert.Equal(t, test.Reason, reason)
+		})
+	}
+
+	t.Run("customPrefix", func(t *testing.T) {
+		signals := Signals{RequireQueueFront: true, QueueLabelPrefix: "train/"}
+		pc := &pulltest.MockPullContext{LabelValue: []string{"train/1"}}
+
+		matches, _, err := signals.Matches(ctx, pc, "testlist")
+		require.NoError(t, err)
+		assert.True(t, matches)
+	})
+}
+
+func TestSignalsMatchesUnresponsiveReviewers(t *testing.T) {
+	ctx := context.Background()
+
+	requested := &pull.RequestedReviewers{
+		Users: []string{"alice", "Bob", "carol"},
+		Teams: []string{"platform"},
+	}
+
+	tests := map[string]struct {
+		Signals Signals
+		Reviews []*pull.Review
+		Matches bool
+		Reason  string
+	}{
+		"withinLimit": {
+			Signals: Signals{Match: MatchAll, MaxUnresponsiveReviewers: 2},
+			Reviews: []*pull.Review{
+				{Author: "alice", State: "APPROVED"},
+				{Author: "bob", State: "COMMENTED"},
+			},
+			Matches: true,
+			Reason:  `pull request matches all testlist signals: pull request has 2 requested reviewers without a review, within the testlist limit of 2`,
+		},
+		"aboveLimit": {
+			Signals: Signals{Match: MatchAll, MaxUnresponsiveReviewers: 2},
+			Reviews: []*pull.Review{
+				{Author: "alice", State: "CHANGES_REQUESTED"},
+				{Author: "dave", State: "APPROVED"},
+			},
+			Matches: false,
+			Reason:  `pull request has 3 requested reviewers without a review, more than the testlist limit of 2: Bob, carol, team:platform`,
+		},
+		"requireResponded": {
+			Signals: Signals{Match: MatchAll, RequireReviewersResponded: true},
+			Reviews: []*pull.Review{
+				{Author: "alice", State: "APPROVED"},
+				{Author: "bob", State: "APPROVED"},
+				{Author: "carol", State: "APPROVED"},
+			},
+			Matches: false,
+			Reason:  `pull request has 1 requested reviewers without a review, more than the testlist limit of 0: team:platform`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			pc := &pulltest.MockPullContext{
+				RequestedReviewersValue: requested,
+				ReviewsValue:            test.Reviews,
+			}
+
+			matches, reason, err := test.Signals.Matches(ctx, pc, "testlist")
+			require.NoError(t, err)
+			assert.Equal(t, test.Matches, matches)
+			assert.Equal(t, test.Reason, reason)
+		})
+	}
+}
+
+func TestSignalsMatchesLabelCount(t *testing.T) {
+	ctx := context.Background()
+
+	tests := map[string]struct {
+		Signals Signals
+		Labels  []string
+		Matches bool
+		Reason  string
+	}{
+		"withinBounds": {
+			Signals: Signals{Match: MatchAll, MinLabels: 1, MaxLabels: 2},
+			Labels:  []string{"bug", "merge"},
+			Matches: true,
+			Reason:  `pull request matches all testlist signals: pull request has 2 labels, within the testlist bounds`,
+		},
+		"tooFew": {
+			Signals: Signals{Match: MatchAll, MinLabels: 1},
+			Matches: false,
+			Reason:  `pull request has 0 labels, fewer than the testlist minimum of 1`,
+		},
+		"tooMany": {
+			Signals: Signals{Match: MatchAll, MaxLabels: 2},
+			Labels:  []string{"bug", "merge", "docs"},
+			Matches: false,
+			Reason:  `pull request has 3 labels, more than the testlist maximum of 2`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			pc := &pulltest.MockPullContext{LabelValue: test.Labels}
+
+			matches, reason, err := test.Signals.Matches(ctx, pc, "testlist")
+			require.NoError(t, err)
+			assert.Equal(t, test.Matches, matches)
+			assert.Equal(t, test.Reason, reason)
+		})
+	}
+}
+
+func TestSignalsMatchesDependsOn(t *testing.T) {
+	signals := Signals{
+		Match:            MatchAll,
+		RespectDependsOn: true,
+		Branches:         SubSignal{Values: []string{"develop"}},
+	}
+
+	ctx := context.Background()
+
+	states := map[int]*pull.PullRequestState{
+		10: {State: "open"},
+		11: {State: "closed"},
+		12: {State: "closed", Merged: true},
+		13: {State: "closed", Merged: true},
+	}
+
+	tests := map[string]struct {
+		Body    string
+		Matches bool
+		Reason  string
+	}{
+		"open": {
+			Body:    "Some change\n\ndepends-on: #12\nDepends on #10",
+			Matches: false,
+			Reason:  `pull request depends on #10, which is open and not merged`,
+		},
+		"closedUnmerged": {
+			Body:    "depends-on: #11",
+			Matches: false,
+			Reason:  `pull request depends on #11, which is closed and not merged`,
+		},
+		"merged": {
+			Body:    "depends-on: #12, #13\ndepends-on: #12",
+			Matches: true,
+			Reason:  `pull request matches all testlist signals: pull request target is a testlist branch: "develop"; pull request dependencies are merged: #12, #13`,
+		},
+		"noDependencies": {
+			Body:    "Fixes #10",
+			Matches: true,
+			Reason:  `pull request matches all testlist signals: pull request target is a testlist branch: "develop"`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			pc := &pulltest.MockPullContext{
+				BodyValue:             test.Body,
+				BranchBase:            "develop",
+				PullRequestStateValue: states,
+			}
+
+			matches, reason, err := signals.Matches(ctx, pc, "testlist")
+			require.NoError(t, err)
+			assert.Equal(t, test.Matches, matches)
+			assert.Equal(t, test.Reason, reason)
+		})
+	}
+
+	t.Run("unknownDependency", func(t *testing.T) {
+		pc := &pulltest.MockPullContext{
+			BodyValue:             "depends-on: #99",
+			BranchBase:            "develop",
+			PullRequestStateValue: states,
+		}
+
+		_, _, err := signals.Matches(ctx, pc, "testlist")
+		assert.Error(t, err)
+	})
+}
+
+func TestSignalsMatchesOnlyMergeStackBottom(t *testing.T) {
+	ctx := context.Background()
+
+	tests := map[string]struct {
+		Base    string
+		Heads   map[string][]int
+		Matches bool
+		Reason  string
+	}{
+		"normalBase": {
+			Base:    "develop",
+			Heads:   map[string][]int{"feature/part-1": {11}},
+			Matches: true,
+			Reason:  `pull request target branch "develop" is not the head branch of an open pull request`,
+		},
+		"stacked": {
+			Base:    "feature/part-1",
+			Heads:   map[string][]int{"feature/part-1": {11}},
+			Matches: false,
+			Reason:  `pull request is stacked on #11, which is open with head branch "feature/part-1"`,
+		},
+		"ignoresSelf": {
+			Base:    "feature/part-1",
+			Heads:   map[string][]int{"feature/part-1": {12}},
+			Matches: true,
+			Reason:  `pull request target branch "feature/part-1" is not the head branch of an open pull request`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			signals := Signals{Match: MatchAll, OnlyMergeStackBottom: true}
+			pc := &pulltest.MockPullContext{
+				NumberValue:                   12,
+				BranchBase:                    test.Base,
+				OpenPullRequestsWithHeadValue: test.Heads,
+			}
+
+			matches, reason, err := signals.Matches(ctx, pc, "testlist")
+			require.NoError(t, err)
+			assert.Equal(t, test.Matches, matches)
+			if test.Matches {
+				assert.Equal(t, "pull request matches all testlist signals: "+test.Reason, reason)
+			} else {
+				assert.Equal(t, test.Reason, reason)
+			}
+		})
+	}
+}
+
+func TestSignalsMatchesClosedIssues(t *testing.T) {
+	signals := Signals{
+		Match:                  MatchAll,
+		ClosesIssuesWithLabels: []string{"customer-impact", "security"},
+	}
+
+	ctx := context.Background()
+
+	issues := map[int]*pull.Issue{
+		20: {State: "open", Labels: []string{"bug"}},
+		21: {State: "open", Labels: []string{"Customer-Impact", "bug"}},
+		22: {State: "closed"},
+		23: {State: "open", Labels: []string{"security"}},
+	}
+
+	tests := map[string]struct {
+		Body    string
+		Matches bool
+		Reason  string
+	}{
+		"labeledIssue": {
+			Body:    "Fixes #20\nCloses: #21",
+			Matches: true,
+			Reason:  `pull request matches all testlist signals: pull request closes issue #21, which has a testlist label: "customer-impact"`,
+		},
+		"secondLabel": {
+			Body:    "resolved #23",
+			Matches: true,
+			Reason:  `pull request matches all testlist signals: pull request closes issue #23, which has a testlist label: "security"`,
+		},
+		"unlabeledIssues": {
+			Body:    "fix #20, close #22, fixes #20",
+			Matches: false,
+			Reason:  `pull request does not close an issue with a testlist label: #20, #22`,
+		},
+		"noIssues": {
+			Body:    "Related to #21\n\ndepends-on: #23",
+			Matches: false,
+			Reason:  `pull request does not close any issues`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			pc := &pulltest.MockPullContext{
+				BodyValue:   test.Body,
+				IssuesValue: issues,
+			}
+
+			matches, reason, err := signals.Matches(ctx, pc, "testlist")
+			require.NoError(t, err)
+			assert.Equal(t, test.Matches, matches)
+			assert.Equal(t, test.Reason, reason)
+		})
+	}
+
+	t.Run("missingIssue", func(t *testing.T) {
+		pc := &pulltest.MockPullContext{
+			BodyValue:   "Fixes #99",
+			IssuesValue: issues,
+		}
+
+		matches, reason, err := signals.Matches(ctx, pc, "testlist")
+		require.NoError(t, err)
+		assert.False(t, matches)
+		assert.Equal(t, `pull request does not close an issue with a testlist label: #99`, reason)
+	})
+
+	t.Run("issueError", func(t *testing.T) {
+		pc := &pulltest.MockPullContext{
+			BodyValue:     "Fixes #21",
+			IssuesValue:   issues,
+			IssueErrValue: errors.New("failure"),
+		}
+
+		_, _, err := signals.Matches(ctx, pc, "testlist")
+		assert.Error(t, err)
+	})
+}
+
+func TestSignalsMatchesRebaseable(t *testing.T) {
+	signals := Signals{
+		Match:             MatchAll,
+		RequireRebaseable: true,
+	}
+
+	ctx := context.Background()
+
+	rebaseable, notRebaseable := true, false
+	linear := []*pull.Commit{
+		{SHA: "c1", Parents: []string{"base"}},
+		{SHA: "c2", Parents: []string{"c1"}},
+	}
+
+	tests := map[string]struct {
+		Settings   pull.MergeSettings
+		Commits    []*pull.Commit
+		Rebaseable *bool
+		Matches    bool
+		Reason     string
+	}{
+		"rebaseable": {
+			Settings:   pull.MergeSettings{AllowRebase: true},
+			Commits:    linear,
+			Rebaseable: &rebaseable,
+			Matches:    true,
+			Reason:     `pull request matches all testlist signals: pull request can be rebased onto the testlist branch "develop"`,
+		},
+		"rebaseNotAllowed": {
+			Settings:   pull.MergeSettings{AllowMergeCommit: true, AllowSquash: true},
+			Commits:    linear,
+			Rebaseable: &rebaseable,
+			Matches:    false,
+			Reason:     `pull request cannot be rebased because the repository does not allow rebase merges`,
+		},
+		"mergeCommit": {
+			Settings: pull.MergeSettings{AllowRebase: true},
+			Commits: []*pull.Commit{
+				{SHA: "c1", Parents: []string{"base"}},
+				{SHA: "c2", Parents: []string{"c1", "develop"}},
+			},
+			Rebaseable: &rebaseable,
+			Matches:    false,
+			Reason:     `pull request cannot be rebased because commit c2 is a merge commit`,
+		},
+		"conflicts": {
+			Settings:   pull.MergeSettings{AllowRebase: true},
+			Commits:    linear,
+			Rebaseable: &notRebaseable,
+			Matches:    false,
+			Reason:     `pull request cannot be rebased onto "develop", possibly because of conflicts`,
+		},
+		"unknown": {
+			Settings: pull.MergeSettings{AllowRebase: true},
+			Commits:  linear,
+			Matches:  false,
+			Reason:   `pull request rebase status is not known yet`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			settings := test.Settings
+			pc := &pulltest.MockPullContext{
+				BranchBase:         "develop",
+				MergeSettingsValue: &settings,
+				CommitsValue:       test.Commits,
+				MergeStateValue:    &pull.MergeState{Rebaseable: test.Rebaseable},
+			}
+
+			matches, reason, err := signals.Matches(ctx, pc, "testlist")
+			require.NoError(t, err)
+			assert.Equal(t, test.Matches, matches)
+			assert.Equal(t, test.Reason, reason)
+		})
+	}
+}
+
+func TestSignalsMatchesMergeMethodCompatible(t *testing.T) {
+	signals := Signals{
+		Match:                        MatchAll,
+		RequireMergeMethodCompatible: true,
+	}
+
+	ctx := context.Background()
+
+	yes, no := true, false
+	linear := []*pull.Commit{
+		{SHA: "c1", Parents: []string{"base"}},
+		{SHA: "c2", Parents: []string{"c1"}},
+	}
+	merged := []*pull.Commit{
+		{SHA: "c1", Parents: []string{"base"}},
+		{SHA: "c2", Parents: []string{"c1", "develop"}},
+	}
+
+	tests := map[string]struct {
+		Settings   pull.MergeSettings
+		Commits    []*pull.Commit
+		Mergeable  *bool
+		Rebaseable *bool
+		Matches    bool
+		Reason     string
+	}{
+		"squashOnlyMergeable": {
+			Settings:  pull.MergeSettings{AllowSquash: true},
+			Commits:   merged,
+			Mergeable: &yes,
+			Matches:   true,
+			Reason:    `pull request matches all testlist signals: pull request can be merged with the "squash" method allowed by the testlist repository`,
+		},
+		"squashOnlyConflicts": {
+			Settings:   pull.MergeSettings{AllowSquash: true},
+			Commits:    linear,
+			Mergeable:  &no,
+			Rebaseable: &yes,
+			Matches:    false,
+			Reason:     `pull request cannot be merged with "squash", the only method allowed by the repository, because it conflicts with "develop"`,
+		},
+		"squashOnlyUnknown": {
+			Settings: pull.MergeSettings{AllowSquash: true},
+			Commits:  linear,
+			Matches:  false,
+			Reason:   `pull request cannot be merged with "squash", the only method allowed by the repository, because its merge status is not known yet`,
+		},
+		"rebaseOnlyRebaseable": {
+			Settings:   pull.MergeSettings{AllowRebase: true},
+			Commits:    linear,
+			Mergeable:  &yes,
+			Rebaseable: &yes,
+			Matches:    true,
+			Reason:     `pull request matches all testlist signals: pull request can be merged with the "rebase" method allowed by the testlist repository`,
+		},
+		"rebaseOnlyNotRebaseable": {
+			Settings:   pull.MergeSettings{AllowRebase: true},
+			Commits:    linear,
+			Mergeable:  &yes,
+			Rebaseable: &no,
+			Matches:    false,
+			Reason:     `pull request cannot be merged with "rebase", the only method allowed by the repository, because it cannot be rebased onto "develop", possibly because of conflicts`,
+		},
+		"rebaseOnlyMergeCommit": {
+			Settings:   pull.MergeSettings{AllowRebase: true},
+			Commits:    merged,
+			Mergeable:  &yes,
+			Rebaseable: &yes,
+			Matches:    false,
+			Reason:     `pull request cannot be merged with "rebase", the only method allowed by the repository, because commit c2 is a merge commit`,
+		},
+		"fallbackMethod": {
+			Settings:   pull.MergeSettings{AllowSquash: true, AllowRebase: true},
+			Commits:    linear,
+			Mergeable:  &no,
+			Rebaseable: &yes,
+			Matches:    true,
+			Reason:     `pull request matches all testlist signals: pull request can be merged with the "rebase" method allowed by the testlist repository`,
+		},
+		"noCompatibleMethod": {
+			Settings:   pull.MergeSettings{AllowMergeCommit: true, AllowRebase: true},
+			Commits:    merged,
+			Mergeable:  &no,
+			Rebaseable: &no,
+			Matches:    false,
+			Reason:     `pull request cannot be merged with any method allowed by the repository: "merge": it conflicts with "develop"; "rebase": commit c2 is a merge commit`,
+		},
+		"noAllowedMethod": {
+			Commits:   linear,
+			Mergeable: &yes,
+			Matches:   false,
+			Reason:    `pull request cannot be merged because the repository does not allow any merge method`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			settings := test.Settings
+			pc := &pulltest.MockPullContext{
+				BranchBase:         "develop",
+				MergeSettingsValue: &settings,
+				CommitsValue:       test.Commits,
+				MergeStateValue:    &pull.MergeState{Mergeable: test.Mergeable, Rebaseable: test.Rebaseable},
+			}
+
+			matches, reason, err := signals.Matches(ctx, pc, "testlist")
+			require.NoError(t, err)
+			assert.Equal(t, test.Matches, matches)
+			assert.Equal(t, test.Reason, reason)
+		})
+	}
+}
+
+func TestSignalsMatchesRequiredApprovals(t *testing.T) {
+	signals := Signals{
+		Match:                    MatchAll,
+		RespectRequiredApprovals: true,
+		Branches:                 SubSignal{Values: []string{"develop"}},
+	}
+
+	ctx := context.Background()
+	now := time.Now()
+
+	reviews := []*pull.Review{
+		{Author: "alice", State: "APPROVED", SubmittedAt: now.Add(-3 * time.Hour)},
+		{Author: "bob", State: "CHANGES_REQUESTED", SubmittedAt: now.Add(-2 * time.Hour)},
+		{Author: "bob", State: "APPROVED", SubmittedAt: now.Add(-1 * time.Hour)},
+		{Author: "carol", State: "APPROVED", SubmittedAt: now.Add(-2 * time.Hour)},
+		{Author: "carol", State: "DISMISSED", SubmittedAt: now.Add(-1 * time.Hour)},
+		{Author: "dave", State: "COMMENTED", SubmittedAt: now},
+	}
+
+	tests := map[string]struct {
+		Required int
+		Matches  bool
+		Reason   string
+	}{
+		"met": {
+			Required: 2,
+			Matches:  true,
+			Reason:   `pull request matches all testlist signals: pull request target is a testlist branch: "develop"; pull request has 2 of 2 required approvals`,
+		},
+		"notMet": {
+			Required: 3,
+			Matches:  false,
+			Reason:   `pull request has 2 of 3 required approvals`,
+		},
+		"notRequired": {
+			Required: 0,
+			Matches:  true,
+			Reason:   `pull request matches all testlist signals: pull request target is a testlist branch: "develop"`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			pc := &pulltest.MockPullContext{
+				BranchBase:             "develop",
+				RequiredApprovalsValue: test.Required,
+				ReviewsValue:           reviews,
+			}
+
+			matches, reason, err := signals.Matches(ctx, pc, "testlist")
+			require.NoError(t, err)
+			assert.Equal(t, test.Matches, matches)
+			assert.Equal(t, test.Reason, reason)
+		})
+	}
+}
+
+func TestSignalsMatchesConversationResolution(t *testing.T) {
+	signals := Signals{
+		Match:                         MatchAll,
+		RespectConversationResolution: true,
+		Branches:                      SubSignal{Values: []string{"develop"}},
+	}
+
+	ctx := context.Background()
+
+	resolved := []*pull.ReviewThread{
+		{Path: "main.go", Line: 10, Resolved: true},
+		{Path: "main.go", Line: 20, Resolved: true},
+	}
+	unresolved := []*pull.ReviewThread{
+		{Path: "main.go", Line: 10, Resolved: true},
+		{Path: "main.go", Line: 20},
+		{Path: "README.md"},
+	}
+
+	tests := map[string]struct {
+		Required bool
+		Threads  []*pull.ReviewThread
+		Matches  bool
+		Reason   string
+	}{
+		"requiredAndResolved": {
+			Required: true,
+			Threads:  resolved,
+			Matches:  true,
+			Reason:   `pull request matches all testlist signals: pull request target is a testlist branch: "develop"; pull request has all 2 review threads resolved, as the target branch requires`,
+		},
+		"requiredAndUnresolved": {
+			Required: true,
+			Threads:  unresolved,
+			Matches:  false,
+			Reason:   `pull request has 2 of 3 review threads unresolved, but the target branch requires conversation resolution`,
+		},
+		"notRequired": {
+			Required: false,
+			Threads:  unresolved,
+			Matches:  true,
+			Reason:   `pull request matches all testlist signals: pull request target is a testlist branch: "develop"`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			pc := &pulltest.MockPullContext{
+				BranchBase:                          "develop",
+				RequiresConversationResolutionValue: test.Required,
+				ReviewThreadsValue:                  test.Threads,
+			}
+
+			matches, reason, err := signals.Matches(ctx, pc, "testlist")
+			require.NoError(t, err)
+			assert.Equal(t, test.Matches, matches)
+			assert.Equal(t, test.Reason, reason)
+		})
+	}
+}
+
+func TestSignalsMatchesReactions(t *testing.T) {
+	ctx := context.Background()
+
+	reactions := []*pull.Reaction{
+		{Content: "+1", User: "alice"},
+		{Content: "+1", User: "Alice"},
+		{Content: "+1", User: "bob"},
+		{Content: "heart", User: "carol"},
+	}
+	comments := []*pull.Comment{
+		{Author: "alice", Body: "Vote to merge: release 1.0", Reactions: map[string]int{"+1": 2, "heart": 1}},
+		{Author: "bob", Body: "unrelated", Reactions: map[string]int{"+1": 5}},
+		{Author: "carol", Body: "Another Vote to merge", Reactions: map[string]int{"+1": 1}},
+	}
+
+	tests := map[string]struct {
+		Signals Signals
+		Matches bool
+		Reason  string
+	}{
+		"bodyMeetsMinimum": {
+			Signals: Signals{Match: MatchAll, MinReactions: 2},
+			Matches: true,
+			Reason:  `pull request matches all testlist signals: pull request has 2 "+1" reactions on the pull request body, meeting the testlist minimum of 2`,
+		},
+		"bodyBelowMinimum": {
+			Signals: Signals{Match: MatchAll, MinReactions: 2, ReactionType: "heart"},
+			Matches: false,
+			Reason:  `pull request has 1 "heart" reactions on the pull request body, fewer than the testlist minimum of 2`,
+		},
+		"comments": {
+			Signals: Signals{Match: MatchAll, MinReactions: 3, ReactionComments: []string{"Vote to merge"}},
+			Matches: true,
+			Reason:  `pull request matches all testlist signals: pull request has 3 "+1" reactions on 2 testlist reaction comments, meeting the testlist minimum of 3`,
+		},
+		"noMatchingComments": {
+			Signals: Signals{Match: MatchAll, MinReactions: 1, ReactionComments: []string{"ship it"}},
+			Matches: false,
+			Reason:  `pull request has no testlist reaction comments`,
+		},
+		"authorizedUsersMeetMinimum": {
+			Signals: Signals{Match: MatchAll, MinReactions: 2, ReactionUsers: []string{"ALICE", "bob"}},
+			Matches: true,
+			Reason:  `pull request matches all testlist signals: pull request has 2 "+1" reactions on the pull request body from testlist reaction users, meeting the testlist minimum of 2: alice, bob`,
+		},
+		"unauthorizedUsersIgnored": {
+			Signals: Signals{Match: MatchAll, MinReactions: 2, ReactionUsers: []string{"alice", "dave"}},
+			Matches: false,
+			Reason:  `pull request has 1 "+1" reactions on the pull request body from testlist reaction users, fewer than the testlist minimum of 2: alice`,
+		},
+		"noAuthorizedUsers": {
+			Signals: Signals{Match: MatchAll, MinReactions: 1, ReactionType: "heart", ReactionUsers: []string{"alice"}},
+			Matches: false,
+			Reason:  `pull request has 0 "heart" reactions on the pull request body from testlist reaction users, fewer than the testlist minimum of 1`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			pc := &pulltest.MockPullContext{
+				ReactionsValue:       reactions,
+				AuthoredCommentValue: comments,
+			}
+
+			matches, reason, err := test.Signals.Matches(ctx, pc, "testlist")
+			require.NoError(t, err)
+			assert.Equal(t, test.Matches, matches)
+			assert.Equal(t, test.Reason, reason)
+		})
+	}
+
+	t.Run("usersWithComments", func(t *testing.T) {
+		signals := Signals{MinReactions: 1, ReactionUsers: []string{"alice"}, ReactionComments: []string{"Vote to merge"}}
+		pc := &pulltest.MockPullContext{ReactionsValue: reactions, AuthoredCommentValue: comments}
+
+		_, _, err := signals.Matches(ctx, pc, "testlist")
+		assert.Error(t, err)
+	})
+}
+
+func TestSignalsMatchesDefaultBaseBranch(t *testing.T) {
+	signals := Signals{
+		Match:                    MatchAll,
+		RequireDefaultBaseBranch: true,
+	}
+
+	ctx := context.Background()
+
+	tests := map[string]struct {
+		Base    string
+		Matches bool
+		Reason  string
+	}{
+		"defaultBranch": {
+			Base:    "main",
+			Matches: true,
+			Reason:  `pull request matches all testlist signals: pull request target branch ("main") is the testlist default branch ("main")`,
+		},
+		"otherBranch": {
+			Base:    "release/1.0",
+			Matches: false,
+			Reason:  `pull request target branch ("release/1.0") is not the testlist default branch ("main")`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			pc := &pulltest.MockPullContext{
+				BranchBase:         test.Base,
+				DefaultBranchValue: "main",
+			}
+
+			matches, reason, err := signals.Matches(ctx, pc, "testlist")
+			require.NoError(t, err)
+			assert.Equal(t, test.Matches, matches)
+			assert.Equal(t, test.Reason, reason)
+		})
+	}
+}
+
+func TestSignalsMatchesEmptyBody(t *testing.T) {
+	ctx := context.Background()
+
+	tests := map[string]struct {
+		Signals Signals
+		Reason  string
+	}{
+		"bodySubstrings": {
+			Signals: Signals{Match: MatchAll, PRBodySubstrings: SubSignal{Values: []string{"==MERGE=="}}},
+			Reason:  `pull request body is empty and does not match a testlist substring: "==MERGE=="`,
+		},
+		"comments": {
+			Signals: Signals{Match: MatchAll, Comments: SubSignal{Values: []string{"/merge"}}},
+			Reason:  `pull request has an empty body and does not have a testlist comment: "/merge"`,
+		},
+		"commentSubstrings": {
+			Signals: Signals{Match: MatchAll, CommentSubstrings: SubSignal{Values: []string{"==MERGE=="}}},
+			Reason:  `pull request body is empty and comments do not match a testlist substring: "==MERGE=="`,
+		},
+		"commentPatterns": {
+			Signals: Signals{Match: MatchAll, CommentPatterns: SubSignal{Values: []string{`^/merge\b`}}},
+			Reason:  `pull request body is empty and comments do not match a testlist comment pattern: "^/merge\\b"`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			for _, body := range []string{"", " \n\t"} {
+				pc := &pulltest.MockPullContext{
+					BodyValue:    body,
+					CommentValue: []string{"looks good"},
+				}
+
+				matches, reason, err := test.Signals.Matches(ctx, pc, "testlist")
+				require.NoError(t, err)
+				assert.False(t, matches)
+				assert.Equal(t, test.Reason, reason)
+			}
+		})
+	}
+
+	t.Run("nonEmptyBody", func(t *testing.T) {
+		signals := Signals{Match: MatchAll, PRBodySubstrings: SubSignal{Values: []string{"==MERGE=="}}}
+		pc := &pulltest.MockPullContext{BodyValue: "Some change"}
+
+		matches, reason, err := signals.Matches(ctx, pc, "testlist")
+		require.NoError(t, err)
+		assert.False(t, matches)
+		assert.Equal(t, `pull request body does not match a testlist substring: "==MERGE=="`, reason)
+	})
+}
+
+func TestSignalsMatchesApprovalsExcludingAuthor(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+
+	tests := map[string]struct {
+		Signals Signals
+		Reviews []*pull.Review
+		Matches bool
+		Reason  string
+	}{
+		"onlyAuthorApproved": {
+			Signals: Signals{Match: MatchAll, ApprovalsExcludingAuthor: 1},
+			Reviews: []*pull.Review{
+				{Author: "Alice", State: "APPROVED", SubmittedAt: now},
+			},
+			Matches: false,
+			Reason:  `pull request has 0 approvals from users other than its authors, fewer than the testlist minimum of 1`,
+		},
+		"independentApproval": {
+			Signals: Signals{Match: MatchAll, ApprovalsExcludingAuthor: 1},
+			Reviews: []*pull.Review{
+				{Author: "alice", State: "APPROVED", SubmittedAt: now},
+				{Author: "bob", State: "APPROVED", SubmittedAt: now},
+			},
+			Matches: true,
+			Reason:  `pull request matches all testlist signals: pull request has 1 approvals from users other than its authors, meeting the testlist minimum of 1`,
+		},
+		"dismissedApproval": {
+			Signals: Signals{Match: MatchAll, ApprovalsExcludingAuthor: 1},
+			Reviews: []*pull.Review{
+				{Author: "bob", State: "APPROVED", SubmittedAt: now.Add(-time.Hour)},
+				{Author: "bob", State: "DISMISSED", SubmittedAt: now},
+			},
+			Matches: false,
+			Reason:  `pull request has 0 approvals from users other than its authors, fewer than the testlist minimum of 1`,
+		},
+		"coAuthorCounted": {
+			Signals: Signals{Match: MatchAll, ApprovalsExcludingAuthor: 2},
+			Reviews: []*pull.Review{
+				{Author: "bob", State: "APPROVED", SubmittedAt: now},
+				{Author: "carol", State: "APPROVED", SubmittedAt: now},
+			},
+			Matches: true,
+			Reason:  `pull request matches all testlist signals: pull request has 2 approvals from users other than its authors, meeting the testlist minimum of 2`,
+		},
+		"coAuthorExcluded": {
+			Signals: Signals{Match: MatchAll, ApprovalsExcludingAuthor: 2, ExcludeCoAuthorApprovals: true},
+			Reviews: []*pull.Review{
+				{Author: "bob", State: "APPROVED", SubmittedAt: now},
+				{Author: "carol", State: "APPROVED", SubmittedAt: now},
+			},
+			Matches: false,
+			Reason:  `pull request has 1 approvals from users other than its authors, fewer than the testlist minimum of 2`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			pc := &pulltest.MockPullContext{
+				CreatorValue: "alice",
+				ReviewsValue: test.Reviews,
+				CommitsValue: []*pull.Commit{
+					{SHA: "c1", Author: pull.CommitIdentity{Login: "alice"}},
+					{SHA: "c2", Author: pull.CommitIdentity{Login: "Carol"}},
+				},
+			}
+
+			matches, reason, err := test.Signals.Matches(ctx, pc, "testlist")
+			require.NoError(t, err)
+			assert.Equal(t, test.Matches, matches)
+			assert.Equal(t, test.Reason, reason)
+		})
+	}
+}
+
+func TestSignalsMatchesPrefixedLabels(t *testing.T) {
+	signals := Signals{
+		Match:             MatchAll,
+		LabelPrefix:       "area/",
+		MinPrefixedLabels: 2,
+	}
+
+	ctx := context.Background()
+
+	tests := map[string]struct {
+		Labels  []string
+		Matches bool
+		Reason  string
+	}{
+		"under": {
+			Labels:  []string{"area/frontend", "bug", "subarea/docs"},
+			Matches: false,
+			Reason:  `pull request has 1 labels with prefix "area/", fewer than the testlist minimum of 2`,
+		},
+		"at": {
+			Labels:  []string{"area/frontend", "Area/Backend", "bug"},
+			Matches: true,
+			Reason:  `pull request matches all testlist signals: pull request has 2 labels with prefix "area/", meeting the testlist minimum of 2`,
+		},
+		"over": {
+			Labels:  []string{"area/frontend", "area/backend", "area/docs"},
+			Matches: true,
+			Reason:  `pull request matches all testlist signals: pull request has 3 labels with prefix "area/", meeting the testlist minimum of 2`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			pc := &pulltest.MockPullContext{
+				LabelValue: test.Labels,
+			}
+
+			matches, reason, err := signals.Matches(ctx, pc, "testlist")
+			require.NoError(t, err)
+			assert.Equal(t, test.Matches, matches)
+			assert.Equal(t, test.Reason, reason)
+		})
+	}
+}
+
+func TestSignalsMatchesCleanWithoutAdmin(t *testing.T) {
+	signals := Signals{
+		Match:                    MatchAll,
+		RequireCleanWithoutAdmin: true,
+	}
+
+	ctx := context.Background()
+	now := time.Now()
+
+	tests := map[string]struct {
+		RequiredStatuses  []string
+		SuccessStatuses   []string
+		RequiredApprovals int
+		Reviews           []*pull.Review
+		Matches           bool
+		Reason            string
+	}{
+		"clean": {
+			RequiredStatuses:  []string{"build", "test"},
+			SuccessStatuses:   []string{"build", "test", "lint"},
+			RequiredApprovals: 1,
+			Reviews: []*pull.Review{
+				{Author: "bob", State: "APPROVED", SubmittedAt: now},
+			},
+			Matches: true,
+			Reason:  `pull request matches all testlist signals: pull request meets the branch protection requirements of the testlist branch without an administrator override`,
+		},
+		"unprotected": {
+			Matches: true,
+			Reason:  `pull request matches all testlist signals: pull request meets the branch protection requirements of the testlist branch without an administrator override`,
+		},
+		"failingChecks": {
+			RequiredStatuses: []string{"build", "test"},
+			SuccessStatuses:  []string{"build"},
+			Matches:          false,
+			Reason:           `merging the pull request would require an administrator to bypass branch protection: required status checks are not successful: test`,
+		},
+		"missingApprovals": {
+			RequiredStatuses:  []string{"build"},
+			SuccessStatuses:   []string{"build"},
+			RequiredApprovals: 2,
+			Reviews: []*pull.Review{
+				{Author: "bob", State: "APPROVED", SubmittedAt: now},
+				{Author: "carol", State: "CHANGES_REQUESTED", SubmittedAt: now},
+			},
+			Matches: false,
+			Reason:  `merging the pull request would require an administrator to bypass branch protection: 1 of 2 required approvals; changes requested by carol`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			pc := &pulltest.MockPullContext{
+				RequiredStatusesValue:  test.RequiredStatuses,
+				SuccessStatusesValue:   test.SuccessStatuses,
+				RequiredApprovalsValue: test.RequiredApprovals,
+				ReviewsValue:           test.Reviews,
+			}
+
+			matches, reason, err := signals.Matches(ctx, pc, "testlist")
+			require.NoError(t, err)
+			assert.Equal(t, test.Matches, matches)
+			assert.Equal(t, test.Reason, reason)
+		})
+	}
+}
+
+func TestSignalsMatchesStatusContextsPresent(t *testing.T) {
+	signals := Signals{
+		Match:                         MatchAll,
+		RequiredStatusContextsPresent: []string{"security/scanner", "build"},
+	}
+
+	ctx := context.Background()
+
+	tests := map[string]struct {
+		Statuses []*pull.Status
+		Matches  bool
+		Reason   string
+	}{
+		"presentButPending": {
+			Statuses: []*pull.Status{
+				{Context: "security/scanner", State: "pending"},
+				{Context: "build", State: "failure"},
+			},
+			Matches: true,
+			Reason:  `pull request matches all testlist signals: pull request has all 2 testlist status contexts`,
+		},
+		"absent": {
+			Statuses: []*pull.Status{
+				{Context: "build", State: "success"},
+				{Context: "security/scanner-lite", State: "success"},
+			},
+			Matches: false,
+			Reason:  `pull request is missing testlist status contexts: "security/scanner"`,
+		},
+		"noStatuses": {
+			Matches: false,
+			Reason:  `pull request is missing testlist status contexts: "security/scanner", "build"`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			pc := &pulltest.MockPullContext{
+				StatusesValue: test.Statuses,
+			}
+
+			matches, reason, err := signals.Matches(ctx, pc, "testlist")
+			require.NoError(t, err)
+			assert.Equal(t, test.Matches, matches)
+			assert.Equal(t, test.Reason, reason)
+		})
+	}
+}
+
+func TestSignalsEvaluateExhaustive(t *testing.T) {
+	ctx := context.Background()
+
+	pc := &pulltest.MockPullContext{
+		BranchBase:   "develop",
+		LabelValue:   []string{"merge"},
+		CommentValue: []string{"please wait"},
+	}
+
+	t.Run("matchOne", func(t *testing.T) {
+		signals := Signals{
+			Exhaustive: true,
+			Branches:   SubSignal{Values: []string{"develop"}},
+			Labels:     SubSignal{Values: []string{"merge"}},
+			Comments:   SubSignal{Values: []string{"/merge"}},
+		}
+
+		result, err := signals.Evaluate(ctx, pc, "testlist")
+		require.NoError(t, err)
+		assert.True(t, result.Matches)
+		assert.Equal(t, `pull request target is a testlist branch: "develop"`, result.Reason)
+		assert.Equal(t, []MatchResult{
+			{Signal: "branches", Matches: true, Reason: `pull request target is a testlist branch: "develop"`, MatchedIndex: 1},
+			{Signal: "labels", Matches: true, Reason: `pull request has a testlist label: "merge"`, MatchedIndex: 1},
+			{Signal: "comments", Matches: false, Reason: `pull request does not match any testlist comments`},
+		}, result.Details)
+
+		signals.Exhaustive = false
+		shortCircuit, err := signals.Evaluate(ctx, pc, "testlist")
+		require.NoError(t, err)
+		assert.Equal(t, result.Matches, shortCircuit.Matches)
+		assert.Equal(t, result.Reason, shortCircuit.Reason)
+		assert.Empty(t, shortCircuit.Details)
+	})
+
+	t.Run("matchAll", func(t *testing.T) {
+		signals := Signals{
+			Match:      MatchAll,
+			Exhaustive: true,
+			Branches:   SubSignal{Values: []string{"master"}},
+			Labels:     SubSignal{Values: []string{"merge"}},
+			Comments:   SubSignal{Values: []string{"/merge"}},
+		}
+
+		result, err := signals.Evaluate(ctx, pc, "testlist")
+		require.NoError(t, err)
+		assert.False(t, result.Matches)
+		assert.Equal(t, `pull request target branch ("develop") is not a testlist branch: "master"`, result.Reason)
+		assert.Equal(t, []string{`pull request target branch ("develop") is not a testlist branch: "master"`}, result.FailedReasons)
+		assert.Len(t, result.Details, 3)
+		assert.Equal(t, []string{"branches", "labels", "comments"}, []string{result.Details[0].Signal, result.Details[1].Signal, result.Details[2].Signal})
+
+		signals.Exhaustive = false
+		shortCircuit, err := signals.Evaluate(ctx, pc, "testlist")
+		require.NoError(t, err)
+		assert.Equal(t, result.Matches, shortCircuit.Matches)
+		assert.Equal(t, result.Reason, shortCircuit.Reason)
+		assert.Equal(t, result.FailedReasons, shortCircuit.FailedReasons)
+	})
+}
+
+func TestSignalsMatchesApprovalAfterLastCommit(t *testing.T) {
+	signals := Signals{
+		Match:                          MatchAll,
+		RequireApprovalAfterLastCommit: true,
+	}
+
+	ctx := context.Background()
+	now := time.Now()
+
+	commits := []*pull.Commit{
+		{SHA: "c1", CommittedAt: now.Add(-3 * time.Hour)},
+		{SHA: "c2", CommittedAt: now.Add(-1 * time.Hour)},
+	}
+
+	tests := map[string]struct {
+		Reviews []*pull.Review
+		Matches bool
+		Reason  string
+	}{
+		"approveThenPush": {
+			Reviews: []*pull.Review{
+				{Author: "bob", State: "APPROVED", SubmittedAt: now.Add(-2 * time.Hour), CommitID: "c1"},
+			},
+			Matches: false,
+			Reason:  `approval is stale (before last commit c2): approved by bob`,
+		},
+		"pushThenApprove": {
+			Reviews: []*pull.Review{
+				{Author: "bob", State: "APPROVED", SubmittedAt: now.Add(-2 * time.Hour), CommitID: "c1"},
+				{Author: "carol", State: "APPROVED", SubmittedAt: now, CommitID: "c2"},
+			},
+			Matches: true,
+			Reason:  `pull request matches all testlist signals: pull request was approved by carol after the last commit c2`,
+		},
+		"unknownCommitUsesTime": {
+			Reviews: []*pull.Review{
+				{Author: "bob", State: "APPROVED", SubmittedAt: now.Add(-2 * time.Hour)},
+				{Author: "carol", State: "APPROVED", SubmittedAt: now.Add(-30 * time.Minute)},
+			},
+			Matches: true,
+			Reason:  `pull request matches all testlist signals: pull request was approved by carol after the last commit c2`,
+		},
+		"dismissedAfterPush": {
+			Reviews: []*pull.Review{
+				{Author: "bob", State: "APPROVED", SubmittedAt: now.Add(-30 * time.Minute), CommitID: "c2"},
+				{Author: "bob", State: "DISMISSED", SubmittedAt: now},
+			},
+			Matches: false,
+			Reason:  `pull request has no approvals`,
+		},
+		"noApprovals": {
+			Reviews: []*pull.Review{
+				{Author: "bob", State: "COMMENTED", SubmittedAt: now, CommitID: "c2"},
+			},
+			Matches: false,
+			Reason:  `pull request has no approvals`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			pc := &pulltest.MockPullContext{
+				CommitsValue: commits,
+				ReviewsValue: test.Reviews,
+			}
+
+			matches, reason, err := signals.Matches(ctx, pc, "testlist")
+			require.NoError(t, err)
+			assert.Equal(t, test.Matches, matches)
+			assert.Equal(t, test.Reason, reason)
+		})
+	}
+}
+
+func TestSignalsMatchesBranchIssueConvention(t *testing.T) {
+	ctx := context.Background()
+
+	issues := map[int]*pull.Issue{
+		1234: {State: "open", Assignees: []string{"alice"}},
+		1235: {State: "closed", Assignees: []string{"alice"}},
+		1236: {State: "open"},
+	}
+
+	tests := map[string]struct {
+		Convention BranchIssueConvention
+		Branch     string
+		Matches    bool
+		Reason     string
+	}{
+		"openIssue": {
+			Branch:  "1234-fix-thing",
+			Matches: true,
+			Reason:  `pull request matches all testlist signals: head branch "1234-fix-thing" references open issue #1234`,
+		},
+		"closedIssue": {
+			Branch:  "1235-fix-thing",
+			Matches: false,
+			Reason:  `head branch "1235-fix-thing" references issue #1235, which is closed instead of open`,
+		},
+		"missingIssue": {
+			Branch:  "999-fix-thing",
+			Matches: false,
+			Reason:  `head branch "999-fix-thing" references issue #999, which does not exist`,
+		},
+		"noIssue": {
+			Branch:  "fix-thing",
+			Matches: false,
+			Reason:  `head branch "fix-thing" does not reference an issue`,
+		},
+		"unassigned": {
+			Convention: BranchIssueConvention{RequireAssignee: true},
+			Branch:     "1236-fix-thing",
+			Matches:    false,
+			Reason:     `head branch "1236-fix-thing" references issue #1236, which has no assignees`,
+		},
+		"customPattern": {
+			Convention: BranchIssueConvention{Pattern: `^[a-z]+/issue-(\d+)$`, State: "closed"},
+			Branch:     "alice/issue-1235",
+			Matches:    true,
+			Reason:     `pull request matches all testlist signals: head branch "alice/issue-1235" references closed issue #1235`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			convention := test.Convention
+			signals := Signals{
+				Match:                 MatchAll,
+				BranchIssueConvention: &convention,
+			}
+			pc := &pulltest.MockPullContext{
+				BranchName:  test.Branch,
+				IssuesValue: issues,
+			}
+
+			matches, reason, err := signals.Matches(ctx, pc, "testlist")
+			require.NoError(t, err)
+			assert.Equal(t, test.Matches, matches)
+			assert.Equal(t, test.Reason, reason)
+		})
+	}
+}
+
+func TestSignalsMatchesRFCReference(t *testing.T) {
+	ctx := context.Background()
+
+	discussionPattern := `RFC-\d+|https://github\.com/\S+/discussions/\d+`
+
+	tests := map[string]struct {
+		Reference RFCReference
+		Title     string
+		Body      string
+		Matches   bool
+		Reason    string
+	}{
+		"inTitle": {
+			Title:   "Rewrite the scheduler (RFC-42)",
+			Matches: true,
+			Reason:  `pull request references a testlist RFC: "RFC-42"`,
+		},
+		"inBody": {
+			Title:   "Rewrite the scheduler",
+			Body:    "Implements RFC-7.",
+			Matches: true,
+			Reason:  `pull request references a testlist RFC: "RFC-7"`,
+		},
+		"absent": {
+			Title:   "Rewrite the scheduler",
+			Body:    "See the RFC for details.",
+			Matches: false,
+			Reason:  `pull request title and body do not contain a testlist RFC reference matching "RFC-\\d+"`,
+		},
+		"unverifiedDiscussion": {
+			Reference: RFCReference{Pattern: discussionPattern},
+			Body:      "See https://github.com/palantir/bulldozer/discussions/404",
+			Matches:   true,
+			Reason:    `pull request references a testlist RFC: "https://github.com/palantir/bulldozer/discussions/404"`,
+		},
+		"existingDiscussion": {
+			Reference: RFCReference{Pattern: discussionPattern, VerifyDiscussions: true},
+			Body:      "See https://github.com/palantir/bulldozer/discussions/404 and https://github.com/palantir/rfcs/discussions/12",
+			Matches:   true,
+			Reason:    `pull request references an existing testlist RFC discussion: "https://github.com/palantir/rfcs/discussions/12"`,
+		},
+		"missingDiscussion": {
+			Reference: RFCReference{Pattern: discussionPattern, VerifyDiscussions: true},
+			Body:      "See https://github.com/palantir/bulldozer/discussions/404",
+			Matches:   false,
+			Reason:    `pull request references testlist RFC discussion "https://github.com/palantir/bulldozer/discussions/404", which does not exist`,
+		},
+		"verifiedIgnoresOtherReferences": {
+			Reference: RFCReference{Pattern: discussionPattern, VerifyDiscussions: true},
+			Title:     "Implement RFC-42",
+			Matches:   true,
+			Reason:    `pull request references a testlist RFC: "RFC-42"`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			reference := test.Reference
+			signals := Signals{Match: MatchAll, RequireRFCReference: &reference}
+			pc := &pulltest.MockPullContext{
+				TitleValue:       test.Title,
+				BodyValue:        test.Body,
+				DiscussionsValue: map[string]bool{"palantir/rfcs#12": true},
+			}
+
+			matches, reason, err := signals.Matches(ctx, pc, "testlist")
+			require.NoError(t, err)
+			assert.Equal(t, test.Matches, matches)
+			if test.Matches {
+				assert.Equal(t, "pull request matches all testlist signals: "+test.Reason, reason)
+			} else {
+				assert.Equal(t, test.Reason, reason)
+			}
+		})
+	}
+
+	t.Run("invalidPattern", func(t *testing.T) {
+		signals := Signals{RequireRFCReference: &RFCReference{Pattern: "RFC-("}}
+		_, _, err := signals.Matches(ctx, &pulltest.MockPullContext{}, "testlist")
+		assert.Error(t, err)
+	})
+}
+
+func TestSignalsMatchesRequired(t *testing.T) {
+	ctx := context.Background()
+
+	tests := map[string]struct {
+		Signals Signals
+		Branch  string
+		Labels  []string
+		Matches bool
+		Reason  string
+	}{
+		"requiredAndOptionalMatch": {
+			Signals: Signals{
+				Branches: SubSignal{Values: []string{"main"}, Required: true},
+				Labels:   SubSignal{Values: []string{"merge when ready", "automerge"}},
+			},
+			Branch:  "main",
+			Labels:  []string{"automerge"},
+			Matches: true,
+			Reason:  `pull request matches required testlist signals: pull request target is a testlist branch: "main"; pull request has a testlist label: "automerge" (labels 2/2)`,
+		},
+		"requiredFails": {
+			Signals: Signals{
+				Branches: SubSignal{Values: []string{"main"}, Required: true},
+				Labels:   SubSignal{Values: []string{"merge when ready", "automerge"}},
+			},
+			Branch:  "develop",
+			Labels:  []string{"automerge"},
+			Matches: false,
+			Reason:  `pull request does not match any testlist branches`,
+		},
+		"optionalFails": {
+			Signals: Signals{
+				Branches: SubSignal{Values: []string{"main"}, Required: true},
+				Labels:   SubSignal{Values: []string{"merge when ready", "automerge"}},
+			},
+			Branch:  "main",
+			Labels:  []string{"wip"},
+			Matches: false,
+			Reason:  `pull request does not match the testlist`,
+		},
+		"onlyRequired": {
+			Signals: Signals{
+				Branches: SubSignal{Values: []string{"main"}, Required: true},
+				Labels:   SubSignal{Values: []string{"automerge"}, Required: true},
+			},
+			Branch:  "main",
+			Labels:  []string{"automerge"},
+			Matches: true,
+			Reason:  `pull request matches required testlist signals: pull request target is a testlist branch: "main"; pull request has a testlist label: "automerge"`,
+		},
+		"ignoredWithMatchAll": {
+			Signals: Signals{
+				Match:    MatchAll,
+				Branches: SubSignal{Values: []string{"main"}, Required: true},
+				Labels:   SubSignal{Values: []string{"automerge"}},
+			},
+			Branch:  "main",
+			Labels:  []string{"wip"},
+			Matches: false,
+			Reason:  `pull request does not have a testlist label: "automerge"`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			pc := &pulltest.MockPullContext{
+				BranchBase: test.Branch,
+				LabelValue: test.Labels,
+			}
+
+			result, err := test.Signals.Evaluate(ctx, pc, "testlist")
+			require.NoError(t, err)
+			assert.Equal(t, test.Matches, result.Matches)
+			assert.Equal(t, test.Reason, result.Reason)
+		})
+	}
+
+	t.Run("requiredFailShortCircuits", func(t *testing.T) {
+		signals := Signals{
+			Branches: SubSignal{Values: []string{"main"}, Required: true},
+			Comments: SubSignal{Values: []string{"==MERGE=="}},
+		}
+		pc := &pulltest.MockPullContext{
+			BranchBase:      "develop",
+			CommentErrValue: errors.New("comments should not be requested"),
+		}
+
+		result, err := signals.Evaluate(ctx, pc, "testlist")
+		require.NoError(t, err)
+		assert.False(t, result.Matches)
+	})
+}
+
+func TestSignalsMatchesTitle(t *testing.T) {
+	ctx := context.Background()
+
+	tests := map[string]struct {
+		Signals Signals
+		Title   string
+		Matches bool
+		Reason  string
+		Error   bool
+	}{
+		"valid": {
+			Signals: Signals{Match: MatchAll, TitleMaxLength: 30, TitleRequiredPattern: "^[A-Z][a-z]+ "},
+			Title:   "Add title signal",
+			Matches: true,
+			Reason:  `pull request matches all testlist signals: pull request title "Add title signal" meets the testlist title rules`,
+		},
+		"tooLong": {
+			Signals: Signals{Match: MatchAll, TitleMaxLength: 10},
+			Title:   "Add title signal",
+			Matches: false,
+			Reason:  "pull request title is 16 characters long, longer than the testlist maximum of 10",
+		},
+		"countsCharacters": {
+			Signals: Signals{Match: MatchAll, TitleMaxLength: 10},
+			Title:   "Fix crème brûlée",
+			Matches: false,
+			Reason:  "pull request title is 16 characters long, longer than the testlist maximum of 10",
+		},
+		"patternMismatch": {
+			Signals: Signals{Match: MatchAll, TitleMaxLength: 30, TitleRequiredPattern: "^[A-Z][a-z]+ "},
+			Title:   "added title signal",
+			Matches: false,
+			Reason:  `pull request title "added title signal" does not match the testlist title pattern: "^[A-Z][a-z]+ "`,
+		},
+		"invalidPattern": {
+			Signals: Signals{Match: MatchAll, TitleRequiredPattern: "("},
+			Title:   "Add title signal",
+			Matches: false,
+			Reason:  `invalid testlist title pattern: "("`,
+			Error:   true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			pc := &pulltest.MockPullContext{TitleValue: test.Title}
+
+			result, err := test.Signals.Evaluate(ctx, pc, "testlist")
+			if test.Error {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, test.Matches, result.Matches)
+			assert.Equal(t, test.Reason, result.Reason)
+		})
+	}
+}
+
+func TestSignalsMatchesBodyLength(t *testing.T) {
+	ctx := context.Background()
+
+	tests := map[string]struct {
+		Signals Signals
+		Body    string
+		Matches bool
+		Reason  string
+	}{
+		"withinBounds": {
+			Signals: Signals{Match: MatchAll, MinBodyLength: 5, MaxBodyLength: 20},
+			Body:    "Fixes the build",
+			Matches: true,
+			Reason:  "pull request matches all testlist signals: pull request body is 15 characters long, within the testlist bounds",
+		},
+		"empty": {
+			Signals: Signals{Match: MatchAll, MinBodyLength: 5},
+			Body:    "",
+			Matches: false,
+			Reason:  "pull request body is 0 characters long, shorter than the testlist minimum of 5",
+		},
+		"tooLong": {
+			Signals: Signals{Match: MatchAll, MaxBodyLength: 10},
+			Body:    "Fixes the build",
+			Matches: false,
+			Reason:  "pull request body is 15 characters long, longer than the testlist maximum of 10",
+		},
+		"countsCharacters": {
+			Signals: Signals{Match: MatchAll, MaxBodyLength: 5},
+			Body:    "héllö",
+			Matches: true,
+			Reason:  "pull request matches all testlist signals: pull request body is 5 characters long, within the testlist bounds",
+		},
+		"multiByteTooShort": {
+			Signals: Signals{Match: MatchAll, MinBodyLength: 4},
+			Body:    "日本語",
+			Matches: false,
+			Reason:  "pull request body is 3 characters long, shorter than the testlist minimum of 4",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			pc := &pulltest.MockPullContext{BodyValue: test.Body}
+
+			matches, reason, err := test.Signals.Matches(ctx, pc, "testlist")
+			require.NoError(t, err)
+			assert.Equal(t, test.Matches, matches)
+			assert.Equal(t, test.Reason, reason)
+		})
+	}
+}
+
+func TestSignalsMatchesBodyHeaders(t *testing.T) {
+	ctx := context.Background()
+
+	tests := map[string]struct {
+		Body    string
+		Matches bool
+		Reason  string
+	}{
+		"present": {
+			Body:    "Risk: Low\r\nTeam: core\r\n\r\nRefactors the scheduler.",
+			Matches: true,
+			Reason:  `pull request body has the testlist header "Risk" set to "Low"`,
+		},
+		"patternValue": {
+			Body:    "\nchange-type:  docs\n\nFixes a typo.",
+			Matches: true,
+			Reason:  `pull request body has the testlist header "change-type" set to "docs"`,
+		},
+		"differentValue": {
+			Body:    "Risk: high\nChange-Type: feature\n\nRewrites the scheduler.",
+			Matches: false,
+			Reason:  `pull request body does not have any testlist header values`,
+		},
+		"partialValue": {
+			Body:    "Risk: lower than usual",
+			Matches: false,
+			Reason:  `pull request body does not have any testlist header values`,
+		},
+		"afterBlankLine": {
+			Body:    "Rewrites the scheduler.\n\nRisk: low",
+			Matches: false,
+			Reason:  `pull request body does not start with a header block`,
+		},
+		"notAHeaderBlock": {
+			Body:    "Risk: low\nThis should be safe to merge.",
+			Matches: false,
+			Reason:  `pull request body does not start with a header block`,
+		},
+		"emptyBody": {
+			Matches: false,
+			Reason:  `pull request body does not start with a header block`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			signals := Signals{
+				Match:       MatchAll,
+				BodyHeaders: map[string]string{"Risk": "low|none", "Change-Type": "docs"},
+			}
+			pc := &pulltest.MockPullContext{BodyValue: test.Body}
+
+			matches, reason, err := signals.Matches(ctx, pc, "testlist")
+			require.NoError(t, err)
+			assert.Equal(t, test.Matches, matches)
+			if test.Matches {
+				assert.Equal(t, "pull request matches all testlist signals: "+test.Reason, reason)
+			} else {
+				assert.Equal(t, test.Reason, reason)
+			}
+		})
+	}
+
+	t.Run("invalidPattern", func(t *testing.T) {
+		signals := Signals{BodyHeaders: map[string]string{"Risk": "low("}}
+		_, _, err := signals.Matches(ctx, &pulltest.MockPullContext{BodyValue: "Risk: low"}, "testlist")
+		assert.Error(t, err)
+	})
+}
+
+func TestSignalsMatchesReverts(t *testing.T) {
+	ctx := context.Background()
+
+	tests := map[string]struct {
+		Signals Signals
+		Title   string
+		Body    string
+		Matches bool
+		Reason  string
+	}{
+		"titleRevert": {
+			Signals: Signals{Match: MatchAll, MatchReverts: true},
+			Title:   `Revert "Add title signal"`,
+			Body:    "Breaks the build.",
+			Matches: true,
+			Reason:  `pull request matches all testlist signals: pull request title matches a testlist revert pattern: "^Revert \""`,
+		},
+		"bodyRevert": {
+			Signals: Signals{Match: MatchAll, MatchReverts: true},
+			Title:   `Revert "Add title signal"`,
+			Body:    "Reverts palantir/bulldozer#123\n\nThis reverts commit 9fceb02d0ae598e95dc970b74767f19372d61af8.",
+			Matches: true,
+			Reason:  "pull request matches all testlist signals: pull request body matches a testlist revert pattern, reverting commit 9fceb02d0ae598e95dc970b74767f19372d61af8",
+		},
+		"notRevert": {
+			Signals: Signals{Match: MatchAll, MatchReverts: true},
+			Title:   "Add revert signal",
+			Body:    "Reverted PRs can merge faster.",
+			Matches: false,
+			Reason:  "pull request title and body do not match a testlist revert pattern",
+		},
+		"customPattern": {
+			Signals: Signals{Match: MatchAll, MatchReverts: true, RevertPatterns: []string{`^\[revert (?P<sha>[0-9a-f]+)\]`}},
+			Title:   "[revert 9fceb02] Add title signal",
+			Matches: true,
+			Reason:  "pull request matches all testlist signals: pull request title matches a testlist revert pattern, reverting commit 9fceb02",
+		},
+		"customPatternReplacesDefaults": {
+			Signals: Signals{Match: MatchAll, MatchReverts: true, RevertPatterns: []string{`^\[revert (?P<sha>[0-9a-f]+)\]`}},
+			Title:   `Revert "Add title signal"`,
+			Matches: false,
+			Reason:  "pull request title and body do not match a testlist revert pattern",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			pc := &pulltest.MockPullContext{TitleValue: test.Title, BodyValue: test.Body}
+
+			result, err := test.Signals.Evaluate(ctx, pc, "testlist")
+			require.NoError(t, err)
+			assert.Equal(t, test.Matches, result.Matches)
+			assert.Equal(t, test.Reason, result.Reason)
+		})
+	}
+}
+
+func TestSignalsMatchesCommentsAfterMarker(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+
+	tests := map[string]struct {
+		Signals  Signals
+		Body     string
+		Comments []*pull.Comment
+		Matches  bool
+		Reason   string
+	}{
+		"commandAfterMarker": {
+			Signals: Signals{
+				Match:               MatchAll,
+				Comments:            SubSignal{Values: []string{"/merge"}},
+				CommentsAfterMarker: "Ready for commands",
+			},
+			Comments: []*pull.Comment{
+				{Author: "bot", Body: "Ready for commands", CreatedAt: now.Add(-2 * time.Hour)},
+				{Author: "dev", Body: "/merge", CreatedAt: now.Add(-time.Hour)},
+			},
+			Matches: true,
+			Reason:  `pull request matches all testlist signals: pull request has a testlist comment after marker "Ready for commands": "/merge"`,
+		},
+		"commandBeforeMarker": {
+			Signals: Signals{
+				Match:               MatchAll,
+				Comments:            SubSignal{Values: []string{"/merge"}},
+				CommentsAfterMarker: "Ready for commands",
+			},
+			Comments: []*pull.Comment{
+				{Author: "dev", Body: "/merge", CreatedAt: now.Add(-2 * time.Hour)},
+				{Author: "bot", Body: "Ready for commands", CreatedAt: now.Add(-time.Hour)},
+			},
+			Matches: false,
+			Reason:  `pull request does not have a testlist comment after marker "Ready for commands": "/merge"`,
+		},
+		"orderedByCreation": {
+			Signals: Signals{
+				Match:               MatchAll,
+				CommentSubstrings:   SubSignal{Values: []string{"==MERGE=="}},
+				CommentsAfterMarker: "Ready for commands",
+			},
+			Comments: []*pull.Comment{
+				{Author: "bot", Body: "Ready for commands", CreatedAt: now.Add(-time.Hour)},
+				{Author: "dev", Body: "==MERGE==", CreatedAt: now.Add(-2 * time.Hour)},
+			},
+			Matches: false,
+			Reason:  `pull request has no comment after marker "Ready for commands" matching a testlist substring: "==MERGE=="`,
+		},
+		"noMarker": {
+			Signals: Signals{
+				Match:               MatchAll,
+				CommentPatterns:     SubSignal{Values: []string{"^/merge"}},
+				CommentsAfterMarker: "Ready for commands",
+			},
+			Comments: []*pull.Comment{
+				{Author: "dev", Body: "/merge", CreatedAt: now},
+			},
+			Matches: false,
+			Reason:  `pull request has no comment after marker "Ready for commands" matching a testlist comment pattern: "^/merge"`,
+		},
+		"bodyIgnored": {
+			Signals: Signals{
+				Match:               MatchAll,
+				CommentSubstrings:   SubSignal{Values: []string{"==MERGE=="}},
+				CommentsAfterMarker: "Ready for commands",
+			},
+			Body: "==MERGE==",
+			Comments: []*pull.Comment{
+				{Author: "bot", Body: "Ready for commands", CreatedAt: now},
+			},
+			Matches: false,
+			Reason:  `pull request has no comment after marker "Ready for commands" matching a testlist substring: "==MERGE=="`,
+		},
+		"onlyAuthorComments": {
+			Signals: Signals{
+				Match:               MatchAll,
+				Comments:            SubSignal{Values: []string{"/merge"}},
+				OnlyAuthorComments:  true,
+				CommentsAfterMarker: "Ready for commands",
+			},
+			Comments: []*pull.Comment{
+				{Author: "bot", Body: "Ready for commands", CreatedAt: now.Add(-2 * time.Hour)},
+				{Author: "author", Body: "/merge", CreatedAt: now.Add(-time.Hour)},
+			},
+			Matches: true,
+			Reason:  `pull request matches all testlist signals: pull request author self-triggered with a testlist comment after marker "Ready for commands": "/merge"`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			pc := &pulltest.MockPullContext{
+				BodyValue:            test.Body,
+				CreatorValue:         "author",
+				AuthoredCommentValue: test.Comments,
+			}
+
+			result, err := test.Signals.Evaluate(ctx, pc, "testlist")
+			require.NoError(t, err)
+			assert.Equal(t, test.Matches, result.Matches)
+			assert.Equal(t, test.Reason, result.Reason)
+		})
+	}
+}
+
+func TestSignalsMatchesHoldStatuses(t *testing.T) {
+	ctx := context.Background()
+
+	tests := map[string]struct {
+		Statuses []*pull.Status
+		Matches  bool
+		Reason   string
+	}{
+		"failingHold": {
+			Statuses: []*pull.Status{
+				{Context: "build", State: "success"},
+				{Context: "hold", State: "failure"},
+			},
+			Matches: true,
+			Reason:  `pull request matches all testlist signals: pull request has a testlist hold status: "hold" is "failure"`,
+		},
+		"pendingHold": {
+			Statuses: []*pull.Status{
+				{Context: "do-not-merge", State: "pending"},
+			},
+			Matches: true,
+			Reason:  `pull request matches all testlist signals: pull request has a testlist hold status: "do-not-merge" is "pending"`,
+		},
+		"passingHold": {
+			Statuses: []*pull.Status{
+				{Context: "hold", State: "success"},
+			},
+			Matches: false,
+			Reason:  "pull request does not have a failing or pending testlist hold status",
+		},
+		"missingHold": {
+			Statuses: []*pull.Status{
+				{Context: "build", State: "failure"},
+			},
+			Matches: false,
+			Reason:  "pull request does not have a failing or pending testlist hold status",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			signals := Signals{
+				Match:        MatchAll,
+				HoldStatuses: []string{"hold", "do-not-merge"},
+			}
+			pc := &pulltest.MockPullContext{StatusesValue: test.Statuses}
+
+			result, err := signals.Evaluate(ctx, pc, "testlist")
+			require.NoError(t, err)
+			assert.Equal(t, test.Matches, result.Matches)
+			assert.Equal(t, test.Reason, result.Reason)
+		})
+	}
+}
+
+func TestSignalsMatchesCheckAnnotationPatterns(t *testing.T) {
+	ctx := context.Background()
+
+	tests := map[string]struct {
+		Outputs map[string][]*pull.CheckOutput
+		Matches bool
+		Reason  string
+	}{
+		"matchingAnnotation": {
+			Outputs: map[string][]*pull.CheckOutput{
+				"security-scan": {
+					{
+						Summary: "Scan passed",
+						Annotations: []*pull.CheckAnnotation{
+							{Path: "server/handler.go", Line: 12, Level: "notice", Message: "0 high-severity findings"},
+							{Path: "server/config.go", Line: 48, Level: "warning", Message: "2 high-severity findings"},
+						},
+					},
+				},
+			},
+			Matches: true,
+			Reason:  `pull request matches all testlist signals: pull request check "security-scan" has an annotation on server/config.go:48 matching a testlist pattern: "[1-9][0-9]* high-severity findings?"`,
+		},
+		"matchingSummary": {
+			Outputs: map[string][]*pull.CheckOutput{
+				"security-scan": {
+					{Summary: "Scan passed with 1 high-severity finding"},
+				},
+			},
+			Matches: true,
+			Reason:  `pull request matches all testlist signals: pull request check "security-scan" has a summary matching a testlist pattern: "[1-9][0-9]* high-severity findings?"`,
+		},
+		"nonMatchingAnnotations": {
+			Outputs: map[string][]*pull.CheckOutput{
+				"security-scan": {
+					{
+						Summary: "Scan passed",
+						Annotations: []*pull.CheckAnnotation{
+							{Path: "server/handler.go", Line: 12, Level: "notice", Message: "0 high-severity findings"},
+						},
+					},
+				},
+			},
+			Matches: false,
+			Reason:  "pull request checks have no annotations matching testlist patterns",
+		},
+		"otherCheck": {
+			Outputs: map[string][]*pull.CheckOutput{
+				"lint": {
+					{
+						Annotations: []*pull.CheckAnnotation{
+							{Path: "server/handler.go", Line: 12, Level: "failure", Message: "3 high-severity findings"},
+						},
+					},
+				},
+			},
+			Matches: false,
+			Reason:  "pull request checks have no annotations matching testlist patterns",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			signals := Signals{
+				Match: MatchAll,
+				CheckAnnotationPatterns: map[string][]string{
+					"security-scan": {"[1-9][0-9]* high-severity findings?"},
+				},
+			}
+			pc := &pulltest.MockPullContext{CheckOutputsValue: test.Outputs}
+
+			result, err := signals.Evaluate(ctx, pc, "testlist")
+			require.NoError(t, err)
+			assert.Equal(t, test.Matches, result.Matches)
+			assert.Equal(t, test.Reason, result.Reason)
+		})
+	}
+
+	t.Run("invalidPattern", func(t *testing.T) {
+		signals := Signals{
+			CheckAnnotationPatterns: map[string][]string{
+				"security-scan": {"(high"},
+			},
+		}
+		pc := &pulltest.MockPullContext{}
+
+		_, err := signals.Evaluate(ctx, pc, "testlist")
+		assert.Error(t, err)
+	})
+}
+
+func TestSignalsMatchesMinPatchCoverage(t *testing.T) {
+	ctx := context.Background()
+
+	tests := map[string]struct {
+		Statuses []*pull.Status
+		Matches  bool
+		Reason   string
+		Error    bool
+	}{
+		"aboveThreshold": {
+			Statuses: []*pull.Status{
+				{Context: "codecov/project", State: "success", Description: "60.00% (+0.10%) compared to 1a2b3c4"},
+				{Context: "codecov/patch", State: "success", Description: "85.50% of diff hit (target 80.00%)"},
+			},
+			Matches: true,
+			Reason:  `pull request matches all testlist signals: pull request status "codecov/patch" reports 85.5% coverage, meeting the testlist minimum of 80.25%`,
+		},
+		"belowThreshold": {
+			Statuses: []*pull.Status{
+				{Context: "codecov/patch", State: "failure", Description: "71.428571% of diff hit (target 80.00%)"},
+			},
+			Matches: false,
+			Reason:  `pull request status "codecov/patch" reports 71.43% coverage, below the testlist minimum of 80.25%`,
+		},
+		"unparseable": {
+			Statuses: []*pull.Status{
+				{Context: "codecov/patch", State: "pending", Description: "Waiting for CI to complete"},
+			},
+			Matches: false,
+			Reason:  `unable to parse coverage from status "codecov/patch": "Waiting for CI to complete"`,
+			Error:   true,
+		},
+		"missingStatus": {
+			Statuses: []*pull.Status{
+				{Context: "build", State: "success"},
+			},
+			Matches: false,
+			Reason:  `pull request does not have a testlist coverage status: "codecov/patch"`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			signals := Signals{
+				Match:            MatchAll,
+				MinPatchCoverage: 80.25,
+			}
+			pc := &pulltest.MockPullContext{StatusesValue: test.Statuses}
+
+			result, err := signals.Evaluate(ctx, pc, "testlist")
+			if test.Error {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, test.Matches, result.Matches)
+			assert.Equal(t, test.Reason, result.Reason)
+		})
+	}
+}
+
+func TestSignalsMatchesTicketTrailer(t *testing.T) {
+	ctx := context.Background()
+
+	SetTicketValidator(func(ctx context.Context, ticketID string) (bool, error) {
+		switch ticketID {
+		case "ABC-1", "ABC-2":
+			return true, nil
+		case "ABC-500":
+			return false, errors.New("tracker unavailable")
+		}
+		return false, nil
+	})
+	defer SetTicketValidator(nil)
+
+	tests := map[string]struct {
+		Messages []string
+		Matches  bool
+		Reason   string
+		Error    bool
+	}{
+		"openTickets": {
+			Messages: []string{
+				"Add ticket signal\n\nTicket: ABC-1",
+				"Fix tests\n\nSigned-off-by: Jane Doe <jane@example.com>\nticket: ABC-2",
+				"Fix more tests\n\nTicket: ABC-1",
+			},
+			Matches: true,
+			Reason:  `pull request matches all testlist signals: pull request commits reference open tickets in testlist "Ticket" trailers: ABC-1, ABC-2`,
+		},
+		"missingTrailer": {
+			Messages: []string{
+				"Add ticket signal\n\nThis is for Ticket: ABC-1 in the tracker.",
+				"Ticket: ABC-2",
+			},
+			Matches: false,
+			Reason:  `pull request commits do not have a testlist "Ticket" trailer`,
+		},
+		"closedTicket": {
+			Messages: []string{
+				"Add ticket signal\n\nTicket: ABC-1",
+				"Fix tests\n\nTicket: ABC-3",
+			},
+			Matches: false,
+			Reason:  `pull request commits reference ticket "ABC-3", which is missing or closed`,
+		},
+		"validatorError": {
+			Messages: []string{"Add ticket signal\n\nTicket: ABC-500"},
+			Matches:  false,
+			Reason:   `unable to check ticket "ABC-500"`,
+			Error:    true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			signals := Signals{
+				Match:         MatchAll,
+				TicketTrailer: &TicketTrailer{},
+			}
+
+			var commits []*pull.Commit
+			for i, m := range test.Messages {
+				commits = append(commits, &pull.Commit{SHA: fmt.Sprintf("%040d", i), Message: m})
+			}
+			pc := &pulltest.MockPullContext{CommitsValue: commits}
+
+			matches, reason, err := signals.Matches(ctx, pc, "testlist")
+			if test.Error {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, test.Matches, matches)
+			assert.Equal(t, test.Reason, reason)
+		})
+	}
+
+	t.Run("noValidator", func(t *testing.T) {
+		SetTicketValidator(nil)
+
+		signals := Signals{TicketTrailer: &TicketTrailer{Key: "Jira"}}
+		pc := &pulltest.MockPullContext{}
+
+		_, reason, err := signals.Matches(ctx, pc, "testlist")
+		assert.Error(t, err)
+		assert.Equal(t, `no ticket validator is configured for the testlist "Jira" trailer`, reason)
+	})
+}
+
+func TestSignalsMatchesMinCommitAuthors(t *testing.T) {
+	ctx := context.Background()
+
+	alice := pull.CommitIdentity{Login: "alice", Email: "alice@example.com"}
+	bob := pull.CommitIdentity{Email: "bob@example.com"}
+
+	tests := map[string]struct {
+		Commits []*pull.Commit
+		Matches bool
+		Reason  string
+	}{
+		"singleAuthor": {
+			Commits: []*pull.Commit{
+				{SHA: "a1", Author: alice, Message: "Add feature"},
+				{SHA: "b2", Author: alice, Message: "Fix tests"},
+			},
+			Matches: false,
+			Reason:  `pull request commits have 1 distinct author, fewer than the testlist minimum of 2: alice`,
+		},
+		"distinctAuthors": {
+			Commits: []*pull.Commit{
+				{SHA: "a1", Author: alice, Message: "Add feature"},
+				{SHA: "b2", Author: bob, Message: "Fix tests"},
+			},
+			Matches: true,
+			Reason:  `pull request commits have 2 distinct authors, meeting the testlist minimum of 2: alice, bob@example.com`,
+		},
+		"coAuthored": {
+			Commits: []*pull.Commit{
+				{SHA: "a1", Author: alice, Message: "Add feature\n\nCo-authored-by: Bob <bob@example.com>\nCo-authored-by: Carol <Carol@Example.com>"},
+			},
+			Matches: true,
+			Reason:  `pull request commits have 3 distinct authors, meeting the testlist minimum of 2: alice, bob@example.com, Carol@Example.com`,
+		},
+		"coAuthorIsCommitAuthor": {
+			Commits: []*pull.Commit{
+				{SHA: "a1", Author: alice, Message: "Add feature\n\nco-authored-by: Alice <ALICE@example.com>"},
+				{SHA: "b2", Author: alice, Message: "Fix tests\n\nCo-authored-by: Alice Again <alice@example.com>"},
+			},
+			Matches: false,
+			Reason:  `pull request commits have 1 distinct author, fewer than the testlist minimum of 2: alice`,
+		},
+		"trailerNotAtEnd": {
+			Commits: []*pull.Commit{
+				{SHA: "a1", Author: alice, Message: "Add feature\n\nCo-authored-by: Bob <bob@example.com>\n\nMore details"},
+				{SHA: "b2", Author: alice, Message: "Fix tests\n\nCo-authored-by: Bob"},
+			},
+			Matches: false,
+			Reason:  `pull request commits have 1 distinct author, fewer than the testlist minimum of 2: alice`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			signals := Signals{Match: MatchAll, MinCommitAuthors: 2}
+			pc := &pulltest.MockPullContext{CommitsValue: test.Commits}
+
+			matches, reason, err := signals.Matches(ctx, pc, "testlist")
+			require.NoError(t, err)
+			assert.Equal(t, test.Matches, matches)
+			if test.Matches {
+				assert.Equal(t, "pull request matches all testlist signals: "+test.Reason, reason)
+			} else {
+				assert.Equal(t, test.Reason, reason)
+			}
+		})
+	}
+}
+
+func TestSignalsMatchesCodeOwnersRequested(t *testing.T) {
+	ctx := context.Background()
+
+	codeOwners := `
+*            @palantir/devtools
+/pull/       @mhaypenny
+/vendor/
+`
+
+	tests := map[string]struct {
+		Files     []string
+		Requested *pull.RequestedReviewers
+		Reviews   []*pull.Review
+		Members   map[string][]string
+		Matches   bool
+		Reason    string
+	}{
+		"allRequested": {
+			Files:     []string{"README.md", "pull/context.go"},
+			Requested: &pull.RequestedReviewers{Users: []string{"MHaypenny"}, Teams: []string{"devtools"}},
+			Matches:   true,
+			Reason:    "pull request matches all testlist signals: pull request requests reviews from all 2 code owners of the changed files",
+		},
+		"userNotRequested": {
+			Files:     []string{"README.md", "pull/context.go"},
+			Requested: &pull.RequestedReviewers{Teams: []string{"devtools"}},
+			Matches:   false,
+			Reason:    `pull request code owner "@mhaypenny" of "pull/context.go" is not requested for review`,
+		},
+		"teamNotRequested": {
+			Files:     []string{"README.md"},
+			Requested: &pull.RequestedReviewers{Users: []string{"mhaypenny"}},
+			Members:   map[string][]string{"devtools": {"bluekeyes"}},
+			Matches:   false,
+			Reason:    `pull request code owner "@palantir/devtools" of "README.md" is not requested for review`,
+		},
+		"alreadyReviewed": {
+			Files:     []string{"README.md", "pull/context.go"},
+			Requested: &pull.RequestedReviewers{},
+			Reviews: []*pull.Review{
+				{Author: "mhaypenny", State: "APPROVED"},
+				{Author: "bluekeyes", State: "COMMENTED"},
+			},
+			Members: map[string][]string{"devtools": {"bluekeyes"}},
+			Matches: true,
+			Reason:  "pull request matches all testlist signals: pull request requests reviews from all 2 code owners of the changed files",
+		},
+		"noOwners": {
+			Files:   []string{"vendor/github.com/pkg/errors/errors.go"},
+			Matches: true,
+			Reason:  "pull request matches all testlist signals: pull request does not change any files with code owners",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			signals := Signals{
+				Match:                      MatchAll,
+				RequireCodeOwnersRequested: true,
+			}
+
+			var files []*pull.File
+			for _, f := range test.Files {
+				files = append(files, &pull.File{Filename: f})
+			}
+			pc := &pulltest.MockPullContext{
+				OwnerValue:              "palantir",
+				CodeOwnersValue:         codeOwners,
+				ChangedFilesValue:       files,
+				RequestedReviewersValue: test.Requested,
+				ReviewsValue:            test.Reviews,
+				TeamMembersValue:        test.Members,
+			}
+
+			matches, reason, err := signals.Matches(ctx, pc, "testlist")
+			require.NoError(t, err)
+			assert.Equal(t, test.Matches, matches)
+			assert.Equal(t, test.Reason, reason)
+		})
+	}
+}
+
+func TestSignalsMatchesGroups(t *testing.T) {
+	ctx := context.Background()
+
+	signals := Signals{
+		Match: MatchAll,
+		Groups: map[string]Signals{
+			"dependabot_main": {
+				Match:    MatchAll,
+				Creators: SubSignal{Values: []string{"dependabot[bot]"}},
+				Branches: SubSignal{Values: []string{"main"}},
+			},
+			"renovate_develop": {
+				Match:    MatchAll,
+				Creators: SubSignal{Values: []string{"renovate[bot]"}},
+				Branches: SubSignal{Values: []string{"develop"}},
+			},
+		},
+	}
+
+	tests := map[string]struct {
+		Creator string
+		Base    string
+		Matches bool
+		Reason  string
+	}{
+		"creatorAndBranch": {
+			Creator: "dependabot[bot]",
+			Base:    "main",
+			Matches: true,
+			Reason:  `pull request matches all testlist signals: pull request matches the testlist group "dependabot_main": pull request matches all testlist signals: pull request target is a testlist branch: "main"; pull request author "dependabot[bot]" is a testlist creator`,
+		},
+		"otherGroup": {
+			Creator: "Renovate[bot]",
+			Base:    "develop",
+			Matches: true,
+			Reason:  `pull request matches all testlist signals: pull request matches the testlist group "renovate_develop": pull request matches all testlist signals: pull request target is a testlist branch: "develop"; pull request author "Renovate[bot]" is a testlist creator`,
+		},
+		"creatorOnly": {
+			Creator: "dependabot[bot]",
+			Base:    "develop",
+			Matches: false,
+			Reason:  `pull request does not match any testlist group: group "dependabot_main": pull request target branch ("develop") is not a testlist branch: "main"; group "renovate_develop": pull request author "dependabot[bot]" is not a testlist creator`,
+		},
+		"branchOnly": {
+			Creator: "mhaypenny",
+			Base:    "main",
+			Matches: false,
+			Reason:  `pull request does not match any testlist group: group "dependabot_main": pull request author "mhaypenny" is not a testlist creator; group "renovate_develop": pull request target branch ("main") is not a testlist branch: "develop"`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			pc := &pulltest.MockPullContext{
+				CreatorValue: test.Creator,
+				BranchBase:   test.Base,
+				BranchName:   "dependencies",
+				LabelValue:   []string{},
+			}
+
+			matches, reason, err := signals.Matches(ctx, pc, "testlist")
+			require.NoError(t, err)
+			assert.Equal(t, test.Matches, matches)
+			assert.Equal(t, test.Reason, reason)
+		})
+	}
+}
+
+func TestSignalsMatchesRequiredWorkflows(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+
+	tests := map[string]struct {
+		Runs    []*pull.WorkflowRun
+		Matches bool
+		Reason  string
+	}{
+		"successful": {
+			Runs: []*pull.WorkflowRun{
+				{Name: "CI", Status: "completed", Conclusion: "failure", CreatedAt: now.Add(-time.Hour)},
+				{Name: "CI", Status: "completed", Conclusion: "success", CreatedAt: now},
+				{Name: "Release Checks", Status: "completed", Conclusion: "success", CreatedAt: now},
+			},
+			Matches: true,
+			Reason:  "pull request matches all testlist signals: pull request has successful runs of all 2 testlist workflows",
+		},
+		"noRun": {
+			Runs: []*pull.WorkflowRun{
+				{Name: "CI", Status: "completed", Conclusion: "success", CreatedAt: now},
+				{Name: "Lint", Status: "completed", Conclusion: "success", CreatedAt: now},
+			},
+			Matches: false,
+			Reason:  `pull request head commit has no run of the testlist workflow "Release Checks"`,
+		},
+		"failedRun": {
+			Runs: []*pull.WorkflowRun{
+				{Name: "CI", Status: "completed", Conclusion: "success", CreatedAt: now.Add(-time.Hour)},
+				{Name: "CI", Status: "completed", Conclusion: "failure", CreatedAt: now},
+				{Name: "Release Checks", Status: "completed", Conclusion: "success", CreatedAt: now},
+			},
+			Matches: false,
+			Reason:  `pull request testlist workflow "CI" did not succeed: latest run concluded "failure"`,
+		},
+		"pendingRun": {
+			Runs: []*pull.WorkflowRun{
+				{Name: "CI", Status: "completed", Conclusion: "success", CreatedAt: now.Add(-time.Hour)},
+				{Name: "CI", Status: "in_progress", CreatedAt: now},
+			},
+			Matches: false,
+			Reason:  `pull request testlist workflow "CI" has not completed: latest run is "in_progress"`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			signals := Signals{
+				Match:             MatchAll,
+				RequiredWorkflows: []string{"CI", "Release Checks"},
+			}
+			pc := &pulltest.MockPullContext{WorkflowRunsValue: test.Runs}
+
+			matches, reason, err := signals.Matches(ctx, pc, "testlist")
+			require.NoError(t, err)
+			assert.Equal(t, test.Matches, matches)
+			assert.Equal(t, test.Reason, reason)
+		})
+	}
+}
+
+func TestSignalsMatchesPathFileCounts(t *testing.T) {
+	ctx := context.Background()
+
+	files := []*pull.File{
+		{Filename: "migrations/001_users.sql"},
+		{Filename: "migrations/002_teams.sql"},
+		{Filename: "migrations/003_roles.sql"},
+		{Filename: "go.sum"},
+		{Filename: "yarn.lock"},
+		{Filename: "web/package.lock"},
+	}
+
+	tests := map[string]struct {
+		Counts  map[string]int
+		Matches bool
+		Reason  string
+	}{
+		"withinLimits": {
+			Counts:  map[string]int{"migrations/": 3, "docs/": 0},
+			Matches: true,
+			Reason:  "pull request matches all testlist signals: pull request changes no more files than the testlist limits allow in 2 paths",
+		},
+		"prefixExceeded": {
+			Counts:  map[string]int{"migrations/": 2, "web/": 1},
+			Matches: false,
+			Reason:  `pull request changes more files than the testlist limits allow: 3 files in "migrations/" (maximum 2)`,
+		},
+		"globExceeded": {
+			Counts:  map[string]int{"*.lock": 0, "*/*.lock": 1},
+			Matches: false,
+			Reason:  `pull request changes more files than the testlist limits allow: 1 files in "*.lock" (maximum 0)`,
+		},
+		"multipleExceeded": {
+			Counts:  map[string]int{"migrations/": 1, "go.sum": 0, "web/": 5},
+			Matches: false,
+			Reason:  `pull request changes more files than the testlist limits allow: 1 files in "go.sum" (maximum 0), 3 files in "migrations/" (maximum 1)`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			signals := Signals{Match: MatchAll, PathFileCounts: test.Counts}
+			pc := &pulltest.MockPullContext{ChangedFilesValue: files}
+
+			result, err := signals.Evaluate(ctx, pc, "testlist")
+			require.NoError(t, err)
+			assert.Equal(t, test.Matches, result.Matches)
+			assert.Equal(t, test.Reason, result.Reason)
+		})
+	}
+}
+
+func TestSignalsMatchesByScore(t *testing.T) {
+	ctx := context.Background()
+
+	pc := &pulltest.MockPullContext{
+		BranchBase:   "develop",
+		LabelValue:   []string{"reviewed"},
+		CommentValue: []string{"lgtm"},
+	}
+
+	tests := map[string]struct {
+		Weights   map[string]int
+		Threshold int
+		Matches   bool
+		Reason    string
+	}{
+		"defaultWeights": {
+			Threshold: 2,
+			Matches:   true,
+			Reason:    "pull request scored 2, meeting the testlist threshold of 2: branches (1), labels (1)",
+		},
+		"belowThreshold": {
+			Weights:   map[string]int{"branches": 1, "labels": 1, "comments": 1},
+			Threshold: 4,
+			Matches:   false,
+			Reason:    "pull request scored 2, less than the testlist threshold of 4: branches (1), labels (1)",
+		},
+		"weighted": {
+			Weights:   map[string]int{"branches": 0, "labels": 3},
+			Threshold: 3,
+			Matches:   true,
+			Reason:    "pull request scored 3, meeting the testlist threshold of 3: branches (0), labels (3)",
+		},
+		"negativeWeight": {
+			Weights:   map[string]int{"branches": 2, "labels": -2},
+			Threshold: 1,
+			Matches:   false,
+			Reason:    "pull request scored 0, less than the testlist threshold of 1: branches (2), labels (-2)",
+		},
+		"defaultThreshold": {
+			Weights: map[string]int{"branches": 0, "labels": 0},
+			Matches: false,
+			Reason:  "pull request scored 0, less than the testlist threshold of 1: branches (0), labels (0)",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			signals := Signals{
+				Match:     MatchScore,
+				Weights:   test.Weights,
+				Threshold: test.Threshold,
+				Branches:  SubSignal{Values: []string{"develop"}},
+				Labels:    SubSignal{Values: []string{"reviewed", "approved"}},
+				Comments:  SubSignal{Values: []string{"/merge"}},
+			}
+
+			result, err := signals.Evaluate(ctx, pc, "testlist")
+			require.NoError(t, err)
+			assert.Equal(t, test.Matches, result.Matches)
+			assert.Equal(t, test.Reason, result.Reason)
+		})
+	}
+
+	t.Run("stopsAtThreshold", func(t *testing.T) {
+		signals := Signals{
+			Match:    MatchScore,
+			Branches: SubSignal{Values: []string{"develop"}},
+			Comments: SubSignal{Values: []string{"/merge"}},
+		}
+		pc := &pulltest.MockPullContext{
+			BranchBase:      "develop",
+			CommentErrValue: errors.New("comments should not be requested"),
+		}
+
+		result, err := signals.Evaluate(ctx, pc, "testlist")
+		require.NoError(t, err)
+		assert.True(t, result.Matches)
+		assert.Equal(t, "pull request scored 1, meeting the testlist threshold of 1: branches (1)", result.Reason)
+	})
+}
+
+func TestSignalsMatchesNativeAutoMerge(t *testing.T) {
+	ctx := context.Background()
+
+	tests := map[string]struct {
+		AutoMerge    *pull.AutoMerge
+		AutoMergeErr error
+		Matches      bool
+		Reason       string
+		Error        bool
+	}{
+		"enabled": {
+			AutoMerge: &pull.AutoMerge{EnabledBy: "octocat", MergeMethod: "squash"},
+			Matches:   true,
+			Reason:    `pull request matches all testlist signals: pull request has native auto-merge enabled by "octocat" with the "squash" method`,
+		},
+		"disabled": {
+			Matches: false,
+			Reason:  "pull request does not have native auto-merge enabled",
+		},
+		"error": {
+			AutoMergeErr: errors.New("failure"),
+			Matches:      false,
+			Reason:       "unable to determine if native auto-merge is enabled",
+			Error:        true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			signals := Signals{Match: MatchAll, DeferToNativeAutoMerge: true}
+			pc := &pulltest.MockPullContext{
+				AutoMergeValue:    test.AutoMerge,
+				AutoMergeErrValue: test.AutoMergeErr,
+			}
+
+			result, err := signals.Evaluate(ctx, pc, "testlist")
+			if test.Error {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, test.Matches, result.Matches)
+			assert.Equal(t, test.Reason, result.Reason)
+		})
+	}
+}
+
+func TestSignalsMatchesSelfConfigChanges(t *testing.T) {
+	ctx := context.Background()
+
+	tests := map[string]struct {
+		Path    string
+		Files   []*pull.File
+		Matches bool
+		Reason  string
+	}{
+		"defaultPathChanged": {
+			Files:   []*pull.File{{Filename: "main.go"}, {Filename: ".bulldozer.yml"}},
+			Matches: true,
+			Reason:  `pull request matches all testlist signals: pull request changes the bulldozer configuration file ".bulldozer.yml"`,
+		},
+		"defaultPathUnchanged": {
+			Files:   []*pull.File{{Filename: "main.go"}, {Filename: "config/.bulldozer.yml"}},
+			Matches: false,
+			Reason:  `pull request does not change the bulldozer configuration file ".bulldozer.yml"`,
+		},
+		"customPathChanged": {
+			Path:    "/.github/bulldozer.yml",
+			Files:   []*pull.File{{Filename: ".github/bulldozer.yml"}},
+			Matches: true,
+			Reason:  `pull request matches all testlist signals: pull request changes the bulldozer configuration file ".github/bulldozer.yml"`,
+		},
+		"customPathUnchanged": {
+			Path:    ".github/bulldozer.yml",
+			Files:   []*pull.File{{Filename: ".bulldozer.yml"}},
+			Matches: false,
+			Reason:  `pull request does not change the bulldozer configuration file ".github/bulldozer.yml"`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			signals := Signals{Match: MatchAll, BlockSelfConfigChanges: true, SelfConfigPath: test.Path}
+			pc := &pulltest.MockPullContext{ChangedFilesValue: test.Files}
+
+			result, err := signals.Evaluate(ctx, pc, "testlist")
+			require.NoError(t, err)
+			assert.Equal(t, test.Matches, result.Matches)
+			assert.Equal(t, test.Reason, result.Reason)
+		})
+	}
+}
+
+func TestSignalsMatchesRepoMetadata(t *testing.T) {
+	ctx := context.Background()
+
+	pc := &pulltest.MockPullContext{
+		TopicsValue: []string{"go", "auto-merge-ok"},
+		RepositoryPropertiesValue: map[string][]string{
+			"merge-policy": {"bulldozer"},
+			"teams":        {"infra", "security"},
+			"owner":        {"Ｐｌａｔｆｏｒｍ"},
+		},
+	}
+
+	tests := map[string]struct {
+		Signals Signals
+		Matches bool
+		Reason  string
+	}{
+		"topic": {
+			Signals: Signals{Match: MatchAll, RepoTopics: []string{"Auto-Merge-OK"}},
+			Matches: true,
+			Reason:  `pull request matches all testlist signals: repository has a testlist topic: "auto-merge-ok"`,
+		},
+		"missingTopic": {
+			Signals: Signals{Match: MatchAll, RepoTopics: []string{"experimental"}},
+			Matches: false,
+			Reason:  "repository does not have any testlist topics",
+		},
+		"property": {
+			Signals: Signals{Match: MatchAll, RepoProperties: map[string]string{"merge-policy": "bulldozer"}},
+			Matches: true,
+			Reason:  `pull request matches all testlist signals: repository has the testlist property "merge-policy" set to "bulldozer"`,
+		},
+		"multiValueProperty": {
+			Signals: Signals{Match: MatchAll, RepoProperties: map[string]string{"merge-policy": "manual", "teams": "security"}},
+			Matches: true,
+			Reason:  `pull request matches all testlist signals: repository has the testlist property "teams" set to "security"`,
+		},
+		"missingProperty": {
+			Signals: Signals{Match: MatchAll, RepoProperties: map[string]string{"merge-policy": "manual", "owner": "infra"}},
+			Matches: false,
+			Reason:  "repository does not have any testlist property values",
+		},
+		"caseSensitiveProperty": {
+			Signals: Signals{Match: MatchAll, RepoProperties: map[string]string{"merge-policy": "Bulldozer"}},
+			Matches: false,
+			Reason:  "repository does not have any testlist property values",
+		},
+		"foldedCaseProperty": {
+			Signals: Signals{Match: MatchAll, RepoProperties: map[string]string{"merge-policy": "Bulldozer"}, FoldCase: true},
+			Matches: true,
+			Reason:  `pull request matches all testlist signals: repository has the testlist property "merge-policy" set to "bulldozer"`,
+		},
+		"normalizedProperty": {
+			Signals: Signals{Match: MatchAll, RepoProperties: map[string]string{"owner": "platform"}, Normalize: true, FoldCase: true},
+			Matches: true,
+			Reason:  `pull request matches all testlist signals: repository has the testlist property "owner" set to "Ｐｌａｔｆｏｒｍ"`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			result, err := test.Signals.Evaluate(ctx, pc, "testlist")
+			require.NoError(t, err)
+			assert.Equal(t, test.Matches, result.Matches)
+			assert.Equal(t, test.Reason, result.Reason)
+		})
+	}
+}
+
+func TestSignalsMatchesChecklist(t *testing.T) {
+	ctx := context.Background()
+
+	tests := map[string]struct {
+		Body    string
+		Matches bool
+		Reason  string
+	}{
+		"allChecked": {
+			Body:    "## Checklist\n- [x] Tests added\n* [X] Docs updated\n1. [x] Changelog",
+			Matches: true,
+			Reason:  "pull request matches all testlist signals: pull request body has all 3 checklist items checked",
+		},
+		"mixed": {
+			Body:    "- [x] Tests added\n- [ ] Docs updated\r\n  - [ ] Screenshots",
+			Matches: false,
+			Reason:  "pull request body has 2 unchecked checklist items out of 3, but the testlist requires a completed checklist",
+		},
+		"codeFenced": {
+			Body:    "- [x] Tests added\n\n```markdown\n- [ ] example item\n```\n~~~\n- [ ] another example\n~~~",
+			Matches: true,
+			Reason:  "pull request matches all testlist signals: pull request body has all 1 checklist items checked",
+		},
+		"uncheckedAfterFence": {
+			Body:    "```\n- [x] example\n```\n- [ ] Tests added",
+			Matches: false,
+			Reason:  "pull request body has 1 unchecked checklist items out of 1, but the testlist requires a completed checklist",
+		},
+		"noChecklist": {
+			Body:    "Fixes a typo. [ ] is not a task outside a list.",
+			Matches: true,
+			Reason:  "pull request matches all testlist signals: pull request body does not have a checklist",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			signals := Signals{Match: MatchAll, RequireCompletedChecklist: true}
+			pc := &pulltest.MockPullContext{BodyValue: test.Body}
+
+			result, err := signals.Evaluate(ctx, pc, "testlist")
+			require.NoError(t, err)
+			assert.Equal(t, test.Matches, result.Matches)
+			assert.Equal(t, test.Reason, result.Reason)
+		})
+	}
+}
+
+func TestSignalsMatchesInvert(t *testing.T) {
+	ctx := context.Background()
+
+	tests := map[string]struct {
+		Match   MatchType
+		Branch  string
+		Labels  []string
+		Matches bool
+		Reason  string
+	}{
+		"oneMatchedInverted": {
+			Match:   MatchOne,
+			Branch:  "develop",
+			Matches: false,
+			Reason:  `pull request matches the inverted testlist: pull request target is a testlist branch: "develop"`,
+		},
+		"oneNotMatchedInverted": {
+			Match:   MatchOne,
+			Branch:  "main",
+			Labels:  []string{"wip"},
+			Matches: true,
+			Reason:  "pull request does not match the inverted testlist: pull request does not match the testlist",
+		},
+		"allMatchedInverted": {
+			Match:   MatchAll,
+			Branch:  "develop",
+			Labels:  []string{"reviewed"},
+			Matches: false,
+			Reason:  `pull request matches the inverted testlist: pull request matches all testlist signals: pull request target is a testlist branch: "develop"; pull request has a testlist label: "reviewed"`,
+		},
+		"allNotMatchedInverted": {
+			Match:   MatchAll,
+			Branch:  "develop",
+			Labels:  []string{"wip"},
+			Matches: true,
+			Reason:  `pull request does not match the inverted testlist: pull request does not have a testlist label: "reviewed"`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			signals := Signals{
+				Match:    test.Match,
+				Invert:   true,
+				Labels:   SubSignal{Values: []string{"reviewed"}},
+				Branches: SubSignal{Values: []string{"develop"}},
+			}
+			pc := &pulltest.MockPullContext{BranchBase: test.Branch, LabelValue: test.Labels}
+
+			matches, reason, err := signals.Matches(ctx, pc, "testlist")
+			require.NoError(t, err)
+			assert.Equal(t, test.Matches, matches)
+			assert.Equal(t, test.Reason, reason)
+
+			signals.Invert = false
+			matches, _, err = signals.Matches(ctx, pc, "testlist")
+			require.NoError(t, err)
+			assert.Equal(t, !test.Matches, matches)
+		})
+	}
+}
+
+func TestSignalsMatchesOpenDuration(t *testing.T) {
+	ctx := context.Background()
+
+	current := time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC)
+	defer func(original func() time.Time) { now = original }(now)
+	now = func() time.Time { return current }
+
+	tests := map[string]struct {
+		CreatedAt time.Time
+		Matches   bool
+		Reason    string
+	}{
+		"tooNew": {
+			CreatedAt: current.Add(-12 * time.Minute),
+			Matches:   false,
+			Reason:    "pull request has been open for 12m0s, less than the testlist minimum of 30m0s",
+		},
+		"oldEnough": {
+			CreatedAt: current.Add(-45 * time.Minute),
+			Matches:   true,
+			Reason:    "pull request matches all testlist signals: pull request has been open for 45m0s, at least the testlist minimum of 30m0s",
+		},
+		"exactlyMinimum": {
+			CreatedAt: current.Add(-30 * time.Minute),
+			Matches:   true,
+			Reason:    "pull request matches all testlist signals: pull request has been open for 30m0s, at least the testlist minimum of 30m0s",
+		},
+		"unknown": {
+			Matches: false,
+			Reason:  "unable to determine when the pull request was opened",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			signals := Signals{Match: MatchAll, MinOpenDuration: 30 * time.Minute}
+			pc := &pulltest.MockPullContext{CreatedAtValue: test.CreatedAt}
+
+			result, err := signals.Evaluate(ctx, pc, "testlist")
+			require.NoError(t, err)
+			assert.Equal(t, test.Matches, result.Matches)
+			assert.Equal(t, test.Reason, result.Reason)
+		})
+	}
+}
+
+func TestSignalsMatchesMaxCheckAge(t *testing.T) {
+	ctx := context.Background()
+
+	current := time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC)
+	defer func(original func() time.Time) { now = original }(now)
+	now = func() time.Time { return current }
+
+	tests := map[string]struct {
+		Required []string
+		Statuses []*pull.Status
+		Matches  bool
+		Reason   string
+	}{
+		"fresh": {
+			Required: []string{"ci/build", "ci/test"},
+			Statuses: []*pull.Status{
+				{Context: "ci/build", State: "success", CompletedAt: current.Add(-2 * time.Hour)},
+				{Context: "ci/test", State: "success", CompletedAt: current.Add(-30 * time.Minute)},
+				{Context: "lint", State: "success", CompletedAt: current.Add(-96 * time.Hour)},
+			},
+			Matches: true,
+			Reason:  "pull request matches all testlist signals: pull request status checks last succeeded at most 2h0m0s ago, within the testlist maximum of 24h0m0s",
+		},
+		"stale": {
+			Required: []string{"ci/build", "ci/test"},
+			Statuses: []*pull.Status{
+				{Context: "ci/build", State: "success", CompletedAt: current.Add(-2 * time.Hour)},
+				{Context: "ci/test", State: "success", CompletedAt: current.Add(-50 * time.Hour)},
+			},
+			Matches: false,
+			Reason:  `pull request status check "ci/test" last succeeded 50h0m0s ago, more than the testlist maximum of 24h0m0s`,
+		},
+		"newestSuccessCounts": {
+			Required: []string{"ci/test"},
+			Statuses: []*pull.Status{
+				{Context: "ci/test", State: "success", CompletedAt: current.Add(-50 * time.Hour)},
+				{Context: "ci/test", State: "success", CompletedAt: current.Add(-time.Hour)},
+				{Context: "ci/test", State: "failure", CompletedAt: current.Add(-time.Minute)},
+			},
+			Matches: true,
+			Reason:  "pull request matches all testlist signals: pull request status checks last succeeded at most 1h0m0s ago, within the testlist maximum of 24h0m0s",
+		},
+		"requiredNotSucceeded": {
+			Required: []string{"ci/build", "ci/test"},
+			Statuses: []*pull.Status{
+				{Context: "ci/build", State: "success", CompletedAt: current.Add(-time.Hour)},
+				{Context: "ci/test", State: "in_progress"},
+			},
+			Matches: false,
+			Reason:  `pull request required status check "ci/test" has not succeeded`,
+		},
+		"allChecksWithoutRequired": {
+			Statuses: []*pull.Status{
+				{Context: "ci/build", State: "success", CompletedAt: current.Add(-time.Hour)},
+				{Context: "lint", State: "success", CompletedAt: current.Add(-30 * time.Hour)},
+			},
+			Matches: false,
+			Reason:  `pull request status check "lint" last succeeded 30h0m0s ago, more than the testlist maximum of 24h0m0s`,
+		},
+		"noChecks": {
+			Matches: false,
+			Reason:  "pull request has no successful status checks",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			signals := Signals{Match: MatchAll, MaxCheckAge: 24 * time.Hour}
+			pc := &pulltest.MockPullContext{
+				RequiredStatusesValue: test.Required,
+				StatusesValue:         test.Statuses,
+			}
+
+			matches, reason, err := signals.Matches(ctx, pc, "testlist")
+			require.NoError(t, err)
+			assert.Equal(t, test.Matches, matches)
+			assert.Equal(t, test.Reason, reason)
+		})
+	}
+}
+
+func TestSignalsMatchesNewDependencies(t *testing.T) {
+	ctx := context.Background()
+
+	tests := map[string]struct {
+		Signals Signals
+		Diff    string
+		Matches bool
+		Reason  string
+	}{
+		"addition": {
+			Signals: Signals{BlockNewDependencies: true},
+			Diff: `diff --git a/go.mod b/go.mod
+--- a/go.mod
++++ b/go.mod
+@@ -3,4 +3,5 @@ go 1.13
+ require (
+ 	github.com/pkg/errors v0.9.1
++	github.com/rs/zerolog v1.18.0
+ )
+diff --git a/web/package.json b/web/package.json
+--- a/web/package.json
++++ b/web/package.json
+@@ -5,3 +5,4 @@
+   "dependencies": {
++    "left-pad": "^1.3.0",
+     "react": "^16.13.1"
+`,
+			Matches: true,
+			Reason:  `pull request adds testlist dependencies: "github.com/rs/zerolog" in "go.mod", "left-pad" in "web/package.json"`,
+		},
+		"removal": {
+			Signals: Signals{BlockNewDependencies: true},
+			Diff: `diff --git a/go.mod b/go.mod
+--- a/go.mod
++++ b/go.mod
+@@ -3,5 +3,4 @@ go 1.13
+ require (
+ 	github.com/pkg/errors v0.9.1
+-	github.com/rs/zerolog v1.18.0
+ )
+`,
+			Matches: false,
+			Reason:  "pull request does not match the testlist",
+		},
+		"versionBump": {
+			Signals: Signals{BlockNewDependencies: true},
+			Diff: `diff --git a/go.mod b/go.mod
+--- a/go.mod
++++ b/go.mod
+@@ -3,4 +3,4 @@ go 1.13
+ require (
+-	github.com/pkg/errors v0.8.1
++	github.com/pkg/errors v0.9.1
+ )
+`,
+			Matches: false,
+			Reason:  "pull request does not match the testlist",
+		},
+		"allowed": {
+			Signals: Signals{BlockNewDependencies: true, AllowedDependencies: []string{"github.com/rs/*"}},
+			Diff: `diff --git a/go.mod b/go.mod
+--- a/go.mod
++++ b/go.mod
+@@ -3,4 +3,5 @@ go 1.13
+ require (
+ 	github.com/pkg/errors v0.9.1
++	github.com/rs/zerolog v1.18.0
+ )
+`,
+			Matches: false,
+			Reason:  "pull request does not match the testlist",
+		},
+		"otherManifests": {
+			Signals: Signals{BlockNewDependencies: true, DependencyManifests: []string{"tools/go.mod"}},
+			Diff: `diff --git a/go.mod b/go.mod
+--- a/go.mod
++++ b/go.mod
+@@ -3,4 +3,5 @@ go 1.13
+ require (
+ 	github.com/pkg/errors v0.9.1
++	github.com/rs/zerolog v1.18.0
+ )
+`,
+			Matches: false,
+			Reason:  "pull request does not match the testlist",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			pc := &pulltest.MockPullContext{DiffValue: test.Diff}
+
+			matches, reason, err := test.Signals.Matches(ctx, pc, "testlist")
+			require.NoError(t, err)
+			assert.Equal(t, test.Matches, matches)
+			assert.Equal(t, test.Reason, reason)
+		})
+	}
+}
+
+func TestSignalsMatchesConflictMarkers(t *testing.T) {
+	ctx := context.Background()
+
+	conflictDiff := `diff --git a/main.go b/main.go
+--- a/main.go
++++ b/main.go
+@@ -1,1 +1,5 @@
++<<<<<<< HEAD
++x := 1
++=======
++x := 2
++>>>>>>> feature
+`
+
+	tests := map[string]struct {
+		Diff    string
+		Matches bool
+		Reason  string
+	}{
+		"markers": {
+			Diff:    conflictDiff,
+			Matches: true,
+			Reason:  `pull request matches all testlist signals: pull request adds merge conflict markers to "main.go"`,
+		},
+		"noMarkers": {
+			Diff:    testDiff,
+			Matches: false,
+			Reason:  "pull request does not add merge conflict markers",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			signals := Signals{Match: MatchAll, DetectConflictMarkers: true}
+			pc := &pulltest.MockPullContext{DiffValue: test.Diff}
+
+			result, err := signals.Evaluate(ctx, pc, "testlist")
+			require.NoError(t, err)
+			assert.Equal(t, test.Matches, result.Matches)
+			assert.Equal(t, test.Reason, result.Reason)
+		})
+	}
+}
+
+func TestSignalsMatchesDisabled(t *testing.T) {
+	ctx := context.Background()
+	disabled := false
+
+	pc := &pulltest.MockPullContext{
+		BranchBase: "develop",
+		LabelValue: []string{"wip"},
+		BodyValue:  "- [ ] update the changelog",
+	}
+
+	tests := map[string]struct {
+		Signals Signals
+		Matches bool
+		Reason  string
+	}{
+		"oneDisabledMatch": {
+			Signals: Signals{
+				Branches: SubSignal{Values: []string{"develop"}, Enabled: &disabled},
+				Labels:   SubSignal{Values: []string{"merge when ready"}},
+			},
+			Matches: false,
+			Reason:  "pull request does not match the testlist",
+		},
+		"allDisabledFailure": {
+			Signals: Signals{
+				Match:    MatchAll,
+				Branches: SubSignal{Values: []string{"develop"}},
+				Labels:   SubSignal{Values: []string{"merge when ready"}, Enabled: &disabled},
+			},
+			Matches: true,
+			Reason:  `pull request matches all testlist signals: pull request target is a testlist branch: "develop"`,
+		},
+		"disabledRequired": {
+			Signals: Signals{
+				Branches: SubSignal{Values: []string{"main"}, Required: true, Enabled: &disabled},
+				Labels:   SubSignal{Values: []string{"wip"}},
+			},
+			Matches: true,
+			Reason:  `pull request has a testlist label: "wip"`,
+		},
+		"disabledSignalType": {
+			Signals: Signals{
+				Match:                     MatchAll,
+				Branches:                  SubSignal{Values: []string{"develop"}},
+				RequireCompletedChecklist: true,
+				Disabled:                  []string{"require_completed_checklist"},
+			},
+			Matches: true,
+			Reason:  `pull request matches all testlist signals: pull request target is a testlist branch: "develop"`,
+		},
+		"disabledRequiredSignalType": {
+			Signals: Signals{
+				Branches: SubSignal{Values: []string{"main"}, Required: true},
+				Labels:   SubSignal{Values: []string{"wip"}},
+				Disabled: []string{"branches"},
+			},
+			Matches: true,
+			Reason:  `pull request has a testlist label: "wip"`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			result, err := test.Signals.Evaluate(ctx, pc, "testlist")
+			require.NoError(t, err)
+			assert.Equal(t, test.Matches, result.Matches)
+			assert.Equal(t, test.Reason, result.Reason)
+		})
+	}
+
+	t.Run("allDisabled", func(t *testing.T) {
+		signals := Signals{Labels: SubSignal{Values: []string{"wip"}, Enabled: &disabled}}
+		assert.False(t, signals.Enabled())
+	})
+
+	t.Run("allSignalTypesDisabled", func(t *testing.T) {
+		signals := Signals{MinOpenDuration: time.Hour, Disabled: []string{"min_open_duration"}}
+		assert.False(t, signals.Enabled())
+	})
+
+	t.Run("unknownSignalType", func(t *testing.T) {
+		signals := Signals{Labels: SubSignal{Values: []string{"wip"}}, Disabled: []string{"label"}}
+		result, err := signals.Evaluate(ctx, pc, "testlist")
+		require.EqualError(t, err, `unknown signal type "label"`)
+		assert.False(t, result.Matches)
+		assert.Equal(t, `invalid testlist disabled signal: "label"`, result.Reason)
+	})
+}
+
+func TestSignalsMatchesProtectedPaths(t *testing.T) {
+	ctx := context.Background()
+
+	signals := Signals{
+		Match:          MatchAll,
+		ProtectedPaths: []string{"infra/", "terraform/*.tf"},
+		ApproverTeams:  []string{"infra", "sre"},
+	}
+
+	teams := map[string][]string{
+		"infra": {"Alice", "bob"},
+		"sre":   {"carol"},
+	}
+
+	tests := map[string]struct {
+		Files   []*pull.File
+		Reviews []*pull.Review
+		Matches bool
+		Reason  string
+	}{
+		"untouched": {
+			Files:   []*pull.File{{Filename: "app/main.go"}},
+			Matches: false,
+			Reason:  "pull request does not change any testlist protected paths",
+		},
+		"touchedWithoutApproval": {
+			Files: []*pull.File{{Filename: "app/main.go"}, {Filename: "infra/main.tf"}},
+			Reviews: []*pull.Review{
+				{Author: "dave", State: "APPROVED", SubmittedAt: time.Unix(1, 0)},
+				{Author: "alice", State: "CHANGES_REQUESTED", SubmittedAt: time.Unix(2, 0)},
+			},
+			Matches: true,
+			Reason:  `pull request matches all testlist signals: pull request changes testlist protected path "infra/" (infra/main.tf) without an approval from a member of team infra or sre`,
+		},
+		"touchedWithApproval": {
+			Files: []*pull.File{{Filename: "terraform/network.tf"}},
+			Reviews: []*pull.Review{
+				{Author: "carol", State: "APPROVED", SubmittedAt: time.Unix(1, 0)},
+			},
+			Matches: false,
+			Reason:  `pull request changes testlist protected path "terraform/*.tf" and was approved by carol, a member of team "sre"`,
+		},
+		"staleApproval": {
+			Files: []*pull.File{{Filename: "infra/dns.yml"}},
+			Reviews: []*pull.Review{
+				{Author: "alice", State: "APPROVED", SubmittedAt: time.Unix(1, 0)},
+				{Author: "alice", State: "DISMISSED", SubmittedAt: time.Unix(2, 0)},
+			},
+			Matches: true,
+			Reason:  `pull request matches all testlist signals: pull request changes testlist protected path "infra/" (infra/dns.yml) without an approval from a member of team infra or sre`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			pc := &pulltest.MockPullContext{
+				ChangedFilesValue: test.Files,
+				ReviewsValue:      test.Reviews,
+				TeamMembersValue:  teams,
+			}
+
+			matches, reason, err := signals.Matches(ctx, pc, "testlist")
+			require.NoError(t, err)
+			assert.Equal(t, test.Matches, matches)
+			assert.Equal(t, test.Reason, reason)
+		})
+	}
+}
+
+func TestSignalsMatchesCreatorType(t *testing.T) {
+	ctx := context.Background()
+	bot, human := true, false
+
+	tests := map[string]struct {
+		CreatorIsBot *bool
+		Creator      string
+		CreatorType  string
+		Matches      bool
+		Reason       string
+	}{
+		"botAuthor": {
+			CreatorIsBot: &bot,
+			Creator:      "dependabot[bot]",
+			CreatorType:  "Bot",
+			Matches:      true,
+			Reason:       `pull request matches all testlist signals: pull request author "dependabot[bot]" is a bot (account type "Bot")`,
+		},
+		"humanAuthor": {
+			CreatorIsBot: &bot,
+			Creator:      "alice",
+			CreatorType:  "User",
+			Matches:      false,
+			Reason:       `pull request author "alice" is not a bot (account type "User")`,
+		},
+		"requireHuman": {
+			CreatorIsBot: &human,
+			Creator:      "alice",
+			CreatorType:  "User",
+			Matches:      true,
+			Reason:       `pull request matches all testlist signals: pull request author "alice" is a human (account type "User")`,
+		},
+		"requireHumanBotAuthor": {
+			CreatorIsBot: &human,
+			Creator:      "renovate[bot]",
+			CreatorType:  "Bot",
+			Matches:      false,
+			Reason:       `pull request author "renovate[bot]" is not a human (account type "Bot")`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			signals := Signals{Match: MatchAll, CreatorIsBot: test.CreatorIsBot}
+			pc := &pulltest.MockPullContext{
+				CreatorValue:     test.Creator,
+				CreatorTypeValue: test.CreatorType,
+			}
+
+			matches, reason, err := signals.Matches(ctx, pc, "testlist")
+			require.NoError(t, err)
+			assert.Equal(t, test.Matches, matches)
+			assert.Equal(t, test.Reason, reason)
+		})
+	}
+}
+
+func TestSignalsMatchesAllowedExtensions(t *testing.T) {
+	ctx := context.Background()
+
+	tests := map[string]struct {
+		Signals Signals
+		Files   []string
+		Matches bool
+		Reason  string
+	}{
+		"allAllowed": {
+			Signals: Signals{Match: MatchAll, AllowedExtensions: []string{"md", ".txt", "JSON"}},
+			Files:   []string{"README.md", "docs/notes.TXT", "config/app.json"},
+			Matches: true,
+			Reason:  "pull request matches all testlist signals: all 3 files changed by the pull request have testlist extensions",
+		},
+		"mixedExtensions": {
+			Signals: Signals{Match: MatchAll, AllowedExtensions: []string{"md", "txt", "json"}},
+			Files:   []string{"README.md", "main.go", "app.json"},
+			Matches: false,
+			Reason:  `pull request changes "main.go", which does not have a testlist extension`,
+		},
+		"extensionlessDisallowed": {
+			Signals: Signals{Match: MatchAll, AllowedExtensions: []string{"md"}},
+			Files:   []string{"docs/README.md", ".gitignore"},
+			Matches: false,
+			Reason:  `pull request changes ".gitignore", which does not have an extension`,
+		},
+		"extensionlessAllowed": {
+			Signals: Signals{Match: MatchAll, AllowedExtensions: []string{"md"}, AllowExtensionlessFiles: true},
+			Files:   []string{"docs/README.md", "LICENSE", "docs.d/.keep"},
+			Matches: true,
+			Reason:  "pull request matches all testlist signals: all 3 files changed by the pull request have testlist extensions",
+		},
+		"extensionInDirectory": {
+			Signals: Signals{Match: MatchAll, AllowedExtensions: []string{"md"}},
+			Files:   []string{"docs.md/Makefile"},
+			Matches: false,
+			Reason:  `pull request changes "docs.md/Makefile", which does not have an extension`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var files []*pull.File
+			for _, f := range test.Files {
+				files = append(files, &pull.File{Filename: f})
+			}
+			pc := &pulltest.MockPullContext{ChangedFilesValue: files}
+
+			matches, reason, err := test.Signals.Matches(ctx, pc, "testlist")
+			require.NoError(t, err)
+			assert.Equal(t, test.Matches, matches)
+			assert.Equal(t, test.Reason, reason)
+		})
+	}
+}
+
+func TestSignalsMatchesResolvedThreads(t *testing.T) {
+	ctx := context.Background()
+
+	signals := Signals{
+		Match:                                MatchAll,
+		RequireResolvedThreadsOnChangedLines: true,
+	}
+
+	tests := map[string]struct {
+		Threads []*pull.ReviewThread
+		Matches bool
+		Reason  string
+	}{
+		"noThreads": {
+			Matches: true,
+			Reason:  "pull request matches all testlist signals: pull request has no unresolved review threads",
+		},
+		"unresolvedOnAddedLine": {
+			Threads: []*pull.ReviewThread{
+				{Path: "main.go", Line: 1, Side: "RIGHT"},
+				{Path: "main.go", Line: 3, Side: "RIGHT"},
+			},
+			Matches: false,
+			Reason:  "pull request has an unresolved review thread on changed line main.go:3",
+		},
+		"unresolvedOnRemovedLine": {
+			Threads: []*pull.ReviewThread{
+				{Path: "old.txt", Line: 1, Side: "LEFT"},
+			},
+			Matches: false,
+			Reason:  "pull request has an unresolved review thread on changed line old.txt:1",
+		},
+		"resolvedOnChangedLine": {
+			Threads: []*pull.ReviewThread{
+				{Path: "new.txt", Line: 1, Side: "RIGHT", Resolved: true},
+			},
+			Matches: true,
+			Reason:  "pull request matches all testlist signals: pull request has no unresolved review threads",
+		},
+		"unresolvedOnUnchangedLines": {
+			Threads: []*pull.ReviewThread{
+				{Path: "main.go", Line: 1, Side: "RIGHT"},
+				{Path: "main.go", Line: 2, Side: "LEFT"},
+				{Path: "new.txt", Line: 2, Side: "RIGHT"},
+				{Path: "main.go", Side: "RIGHT"},
+			},
+			Matches: true,
+			Reason:  "pull request matches all testlist signals: pull request has no unresolved review threads on changed lines (3 on unchanged lines)",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			pc := &pulltest.MockPullContext{
+				ReviewThreadsValue: test.Threads,
+				DiffValue:          testDiff,
+			}
+
+			matches, reason, err := signals.Matches(ctx, pc, "testlist")
+			require.NoError(t, err)
+			assert.Equal(t, test.Matches, matches)
+			assert.Equal(t, test.Reason, reason)
+		})
+	}
+}
+
+func TestSignalsMatchesParticipants(t *testing.T) {
+	ctx := context.Background()
+
+	pc := &pulltest.MockPullContext{
+		AuthoredCommentValue: []*pull.Comment{
+			{Author: "alice", Body: "looks good"},
+			{Author: "dependabot[bot]", Body: "rebased"},
+			{Author: "Bob", Body: "one question"},
+			{Author: "alice", Body: "answered"},
+		},
+		ReviewsValue: []*pull.Review{
+			{Author: "bob", State: "APPROVED"},
+			{Author: "carol", State: "COMMENTED"},
+			{Author: "alice", State: "COMMENTED"},
+		},
+	}
+
+	tests := map[string]struct {
+		Signals Signals
+		Matches bool
+		Reason  string
+	}{
+		"distinctParticipants": {
+			Signals: Signals{Match: MatchAll, MinParticipants: 4},
+			Matches: true,
+			Reason:  "pull request matches all testlist signals: pull request has 4 participants, at least the testlist minimum of 4",
+		},
+		"tooFewParticipants": {
+			Signals: Signals{Match: MatchAll, MinParticipants: 5},
+			Matches: false,
+			Reason:  "pull request has 4 participants, fewer than the testlist minimum of 5",
+		},
+		"excludeBots": {
+			Signals: Signals{Match: MatchAll, MinParticipants: 4, ExcludeBotParticipants: true},
+			Matches: false,
+			Reason:  "pull request has 3 participants, excluding bots, fewer than the testlist minimum of 4",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			matches, reason, err := test.Signals.Matches(ctx, pc, "testlist")
+			require.NoError(t, err)
+			assert.Equal(t, test.Matches, matches)
+			assert.Equal(t, test.Reason, reason)
+		})
+	}
+}
+
+func TestSignalsMatchesProtectedHeadBranches(t *testing.T) {
+	ctx := context.Background()
+
+	signals := Signals{
+		Match:                       MatchAll,
+		ProtectedHeadBranches:       []string{"main"},
+		ProtectedHeadBranchPatterns: []string{`^(develop|release/.*)$`},
+	}
+
+	tests := map[string]struct {
+		HeadBranch string
+		Matches    bool
+		Reason     string
+	}{
+		"namedBranch": {
+			HeadBranch: "main",
+			Matches:    true,
+			Reason:     `pull request matches all testlist signals: pull request head branch ("main") is a testlist protected head branch`,
+		},
+		"patternBranch": {
+			HeadBranch: "release/1.2",
+			Matches:    true,
+			Reason:     `pull request matches all testlist signals: pull request head branch ("release/1.2") matches a testlist protected head branch pattern: "^(develop|release/.*)$"`,
+		},
+		"featureBranch": {
+			HeadBranch: "feature/develop",
+			Matches:    false,
+			Reason:     `pull request head branch ("feature/develop") is not a testlist protected head branch`,
+		},
+		"forkBranch": {
+			HeadBranch: "contributor:develop",
+			Matches:    false,
+			Reason:     `pull request head branch ("contributor:develop") is in a fork`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			pc := &pulltest.MockPullContext{
+				BranchBase: "main",
+				BranchName: test.HeadBranch,
+			}
+
+			matches, reason, err := signals.Matches(ctx, pc, "testlist")
+			require.NoError(t, err)
+			assert.Equal(t, test.Matches, matches)
+			assert.Equal(t, test.Reason, reason)
+		})
+	}
+
+	t.Run("invalidPattern", func(t *testing.T) {
+		signals := Signals{ProtectedHeadBranchPatterns: []string{"release/("}}
+		pc := &pulltest.MockPullContext{BranchName: "release/1.2"}
+
+		_, _, err := signals.Matches(ctx, pc, "testlist")
+		assert.Error(t, err)
+	})
+}
+
+func TestSignalsMatchesMergeCommits(t *testing.T) {
+	ctx := context.Background()
+
+	linear := []*pull.Commit{
+		{SHA: "a1", Parents: []string{"base"}},
+		{SHA: "b2", Parents: []string{"a1"}},
+	}
+	merged := []*pull.Commit{
+		{SHA: "a1", Parents: []string{"base"}},
+		{SHA: "m1", Parents: []string{"a1", "main1"}},
+		{SHA: "b2", Parents: []string{"m1"}},
+		{SHA: "m2", Parents: []string{"b2", "main2"}},
+	}
+
+	tests := map[string]struct {
+		Signals Signals
+		Commits []*pull.Commit
+		Matches bool
+		Reason  string
+	}{
+		"linearHistory": {
+			Signals: Signals{Match: MatchAll, DisallowMergeCommits: true},
+			Commits: linear,
+			Matches: true,
+			Reason:  "pull request matches all testlist signals: pull request contains 0 merge commits, within the testlist limit of 0",
+		},
+		"mergeCommitsDisallowed": {
+			Signals: Signals{Match: MatchAll, DisallowMergeCommits: true},
+			Commits: merged,
+			Matches: false,
+			Reason:  "pull request contains 2 merge commits, exceeding the testlist limit of 0: m1",
+		},
+		"withinLimit": {
+			Signals: Signals{Match: MatchAll, MaxMergeCommits: 2},
+			Commits: merged,
+			Matches: true,
+			Reason:  "pull request matches all testlist signals: pull request contains 2 merge commits, within the testlist limit of 2",
+		},
+		"disallowOverridesLimit": {
+			Signals: Signals{Match: MatchAll, DisallowMergeCommits: true, MaxMergeCommits: 2},
+			Commits: merged,
+			Matches: false,
+			Reason:  "pull request contains 2 merge commits, exceeding the testlist limit of 0: m1",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			pc := &pulltest.MockPullContext{CommitsValue: test.Commits}
+
+			matches, reason, err := test.Signals.Matches(ctx, pc, "testlist")
+			require.NoError(t, err)
+			assert.Equal(t, test.Matches, matches)
+			assert.Equal(t, test.Reason, reason)
+		})
+	}
+}
+
+func TestSignalsMatchesCLA(t *testing.T) {
+	ctx := context.Background()
+
+	tests := map[string]struct {
+		CLA      *CLACheck
+		Statuses []*pull.Status
+		Labels   []string
+		Matches  bool
+		Reason   string
+	}{
+		"statusSuccess": {
+			CLA:      &CLACheck{Status: "cla/check"},
+			Statuses: []*pull.Status{{Context: "ci", State: "failure"}, {Context: "cla/check", State: "success"}},
+			Matches:  true,
+			Reason:   `pull request matches all testlist signals: pull request has a successful testlist CLA status: "cla/check"`,
+		},
+		"statusFailure": {
+			CLA:      &CLACheck{Status: "cla/check"},
+			Statuses: []*pull.Status{{Context: "cla/check", State: "failure"}},
+			Matches:  false,
+			Reason:   `pull request author has not signed the testlist CLA: status "cla/check" is "failure"`,
+		},
+		"statusMissing": {
+			CLA:      &CLACheck{Status: "cla/check"},
+			Statuses: []*pull.Status{{Context: "ci", State: "success"}},
+			Matches:  false,
+			Reason:   `pull request author has not signed the testlist CLA: status "cla/check" is missing`,
+		},
+		"label": {
+			CLA:     &CLACheck{Label: "cla: yes"},
+			Labels:  []string{"CLA: Yes"},
+			Matches: true,
+			Reason:  `pull request matches all testlist signals: pull request has the testlist CLA label: "cla: yes"`,
+		},
+		"labelMissing": {
+			CLA:     &CLACheck{Label: "cla: yes"},
+			Labels:  []string{"cla: no"},
+			Matches: false,
+			Reason:  `pull request author has not signed the testlist CLA: label "cla: yes" is missing`,
+		},
+		"eitherIndicator": {
+			CLA:      &CLACheck{Status: "cla/check", Label: "cla: yes"},
+			Statuses: []*pull.Status{{Context: "cla/check", State: "pending"}},
+			Labels:   []string{"cla: yes"},
+			Matches:  true,
+			Reason:   `pull request matches all testlist signals: pull request has the testlist CLA label: "cla: yes"`,
+		},
+		"neitherIndicator": {
+			CLA:      &CLACheck{Status: "cla/check", Label: "cla: yes"},
+			Statuses: []*pull.Status{{Context: "cla/check", State: "pending"}},
+			Matches:  false,
+			Reason:   `pull request author has not signed the testlist CLA: status "cla/check" is "pending" and label "cla: yes" is missing`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			signals := Signals{Match: MatchAll, RequireCLA: test.CLA}
+			pc := &pulltest.MockPullContext{
+				StatusesValue: test.Statuses,
+				LabelValue:    test.Labels,
+			}
+
+			matches, reason, err := signals.Matches(ctx, pc, "testlist")
+			require.NoError(t, err)
+			assert.Equal(t, test.Matches, matches)
+			assert.Equal(t, test.Reason, reason)
+		})
+	}
+}
+
+func TestSignalsMatchesTestChanges(t *testing.T) {
+	ctx := context.Background()
+
+	signals := Signals{
+		Match: MatchAll,
+		RequireTestChanges: &TestChanges{
+			TestPaths:   []string{"integration/", "*_test.go"},
+			ExemptPaths: []string{"docs/", "*.md"},
+		},
+	}
+
+	tests := map[string]struct {
+		Files   []string
+		Matches bool
+		Reason  string
+	}{
+		"codeOnly": {
+			Files:   []string{"server/handler.go", "README.md", "server/config.go"},
+			Matches: false,
+			Reason:  `pull request changes 2 code files, like "server/handler.go", but no testlist test files`,
+		},
+		"testOnly": {
+			Files:   []string{"server/handler_test.go", "integration/merge.sh"},
+			Matches: true,
+			Reason:  "pull request matches all testlist signals: pull request does not change any code outside of testlist test and exempt paths",
+		},
+		"mixed": {
+			Files:   []string{"server/handler.go", "server/handler_test.go", "integration/merge.sh"},
+			Matches: true,
+			Reason:  "pull request matches all testlist signals: pull request changes 1 code files and 2 testlist test files",
+		},
+		"docsOnly": {
+			Files:   []string{"docs/usage.txt", "CHANGELOG.md"},
+			Matches: true,
+			Reason:  "pull request matches all testlist signals: pull request does not change any code outside of testlist test and exempt paths",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var files []*pull.File
+			for _, f := range test.Files {
+				files = append(files, &pull.File{Filename: f})
+			}
+			pc := &pulltest.MockPullContext{ChangedFilesValue: files}
+
+			matches, reason, err := signals.Matches(ctx, pc, "testlist")
+			require.NoError(t, err)
+			assert.Equal(t, test.Matches, matches)
+			assert.Equal(t, test.Reason, reason)
+		})
+	}
+}
+
+func TestSignalsMatchesBlockTestDeletion(t *testing.T) {
+	ctx := context.Background()
+
+	tests := map[string]struct {
+		Files   []*pull.File
+		Labels  []string
+		Matches bool
+		Reason  string
+	}{
+		"removedTest": {
+			Files: []*pull.File{
+				{Filename: "server/handler.go", Status: "modified"},
+				{Filename: "server/handler_test.go", Status: "removed"},
+			},
+			Matches: true,
+			Reason:  `pull request removes 1 testlist test files but adds 0: "server/handler_test.go"`,
+		},
+		"replacedTest": {
+			Files: []*pull.File{
+				{Filename: "server/handler_test.go", Status: "removed"},
+				{Filename: "server/routes_test.go", Status: "added"},
+			},
+			Matches: false,
+			Reason:  `pull request does not remove more testlist test files than it adds`,
+		},
+		"removedCode": {
+			Files: []*pull.File{
+				{Filename: "server/legacy.go", Status: "removed"},
+			},
+			Matches: false,
+			Reason:  `pull request does not remove more testlist test files than it adds`,
+		},
+		"renamedTest": {
+			Files: []*pull.File{
+				{Filename: "server/routes_test.go", PreviousFilename: "server/handler_test.go", Status: "renamed"},
+			},
+			Matches: false,
+			Reason:  `pull request does not remove more testlist test files than it adds`,
+		},
+		"renamedAwayFromTest": {
+			Files: []*pull.File{
+				{Filename: "server/fixtures.go", PreviousFilename: "server/handler_test.go", Status: "renamed"},
+			},
+			Matches: true,
+			Reason:  `pull request removes 1 testlist test files but adds 0: "server/handler_test.go"`,
+		},
+		"exemptLabel": {
+			Files: []*pull.File{
+				{Filename: "server/handler_test.go", Status: "removed"},
+			},
+			Labels:  []string{"Remove-Tests"},
+			Matches: false,
+			Reason:  `pull request removes testlist test files but has an exemption label: "remove-tests"`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			signals := Signals{
+				Match:                    MatchAll,
+				BlockTestDeletion:        true,
+				TestDeletionExemptLabels: []string{"remove-tests"},
+			}
+			pc := &pulltest.MockPullContext{
+				ChangedFilesValue: test.Files,
+				LabelValue:        test.Labels,
+			}
+
+			matches, reason, err := signals.Matches(ctx, pc, "testlist")
+			require.NoError(t, err)
+			assert.Equal(t, test.Matches, matches)
+			if test.Matches {
+				assert.Equal(t, "pull request matches all testlist signals: "+test.Reason, reason)
+			} else {
+				assert.Equal(t, test.Reason, reason)
+			}
+		})
+	}
+}
+
+func TestSignalsMatchesForcePushSinceApproval(t *testing.T) {
+	ctx := context.Background()
+
+	signals := Signals{
+		Match:                       MatchAll,
+		BlockForcePushSinceApproval: true,
+	}
+
+	approvedAt := time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC)
+	reviews := []*pull.Review{
+		{Author: "alice", State: "APPROVED", SubmittedAt: approvedAt.Add(-time.Hour)},
+		{Author: "bob", State: "APPROVED", SubmittedAt: approvedAt},
+		{Author: "carol", State: "COMMENTED", SubmittedAt: approvedAt.Add(2 * time.Hour)},
+	}
+
+	tests := map[string]struct {
+		Reviews  []*pull.Review
+		Timeline []*pull.TimelineEvent
+		Matches  bool
+		Reason   string
+	}{
+		"forcePushBeforeApproval": {
+			Reviews: reviews,
+			Timeline: []*pull.TimelineEvent{
+				{Event: "head_ref_force_pushed", Actor: "mhaypenny", CreatedAt: approvedAt.Add(-30 * time.Minute)},
+				{Event: "labeled", Actor: "mhaypenny", CreatedAt: approvedAt.Add(time.Hour)},
+			},
+			Matches: false,
+			Reason:  "pull request was not force-pushed after the latest approval (approved 2020-06-01T12:00:00Z)",
+		},
+		"forcePushAfterApproval": {
+			Reviews: reviews,
+			Timeline: []*pull.TimelineEvent{
+				{Event: "head_ref_force_pushed", Actor: "mhaypenny", CreatedAt: approvedAt.Add(-30 * time.Minute)},
+				{Event: "head_ref_force_pushed", Actor: "mhaypenny", CreatedAt: approvedAt.Add(time.Hour)},
+				{Event: "head_ref_force_pushed", Actor: "ttaylorr", CreatedAt: approvedAt.Add(3 * time.Hour)},
+			},
+			Matches: true,
+			Reason:  `pull request matches all testlist signals: pull request was force-pushed by "mhaypenny" at 2020-06-01T13:00:00Z, after the latest approval (approved 2020-06-01T12:00:00Z)`,
+		},
+		"noApprovals": {
+			Reviews: []*pull.Review{
+				{Author: "alice", State: "CHANGES_REQUESTED", SubmittedAt: approvedAt},
+			},
+			Timeline: []*pull.TimelineEvent{
+				{Event: "head_ref_force_pushed", Actor: "mhaypenny", CreatedAt: approvedAt.Add(time.Hour)},
+			},
+			Matches: false,
+			Reason:  "pull request has no approvals",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			pc := &pulltest.MockPullContext{
+				ReviewsValue:  test.Reviews,
+				TimelineValue: test.Timeline,
+			}
+
+			matches, reason, err := signals.Matches(ctx, pc, "testlist")
+			require.NoError(t, err)
+			assert.Equal(t, test.Matches, matches)
+			assert.Equal(t, test.Reason, reason)
+		})
+	}
+}
+
+func TestSignalsMatchesMaxReviewRounds(t *testing.T) {
+	ctx := context.Background()
+
+	signals := Signals{
+		Match:           MatchAll,
+		MaxReviewRounds: 1,
+	}
+
+	start := time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC)
+	history := func(states ...string) []*pull.Review {
+		reviews := make([]*pull.Review, len(states))
+		for i, state := range states {
+			reviews[i] = &pull.Review{Author: "alice", State: state, SubmittedAt: start.Add(time.Duration(i) * time.Hour)}
+		}
+		return reviews
+	}
+
+	tests := map[string]struct {
+		Reviews []*pull.Review
+		Rounds  int
+		Matches bool
+		Reason  string
+	}{
+		"noReviews": {
+			Rounds:  0,
+			Matches: true,
+			Reason:  "pull request matches all testlist signals: pull request has 0 review rounds, within the testlist limit of 1",
+		},
+		"approvedWithoutChanges": {
+			Reviews: history("COMMENTED", "APPROVED", "APPROVED"),
+			Rounds:  0,
+			Matches: true,
+			Reason:  "pull request matches all testlist signals: pull request has 0 review rounds, within the testlist limit of 1",
+		},
+		"changesNotApproved": {
+			Reviews: history("CHANGES_REQUESTED", "COMMENTED"),
+			Rounds:  0,
+			Matches: true,
+			Reason:  "pull request matches all testlist signals: pull request has 0 review rounds, within the testlist limit of 1",
+		},
+		"oneRound": {
+			Reviews: history("CHANGES_REQUESTED", "CHANGES_REQUESTED", "COMMENTED", "APPROVED"),
+			Rounds:  1,
+			Matches: true,
+			Reason:  "pull request matches all testlist signals: pull request has 1 review rounds, within the testlist limit of 1",
+		},
+		"twoRounds": {
+			Reviews: history("CHANGES_REQUESTED", "APPROVED", "CHANGES_REQUESTED", "APPROVED"),
+			Rounds:  2,
+			Matches: false,
+			Reason:  "pull request has 2 review rounds, exceeding the testlist limit of 1",
+		},
+		"secondRoundPending": {
+			Reviews: history("CHANGES_REQUESTED", "APPROVED", "APPROVED", "CHANGES_REQUESTED"),
+			Rounds:  1,
+			Matches: true,
+			Reason:  "pull request matches all testlist signals: pull request has 1 review rounds, within the testlist limit of 1",
+		},
+		"unordered": {
+			Reviews: []*pull.Review{
+				{Author: "bob", State: "APPROVED", SubmittedAt: start.Add(3 * time.Hour)},
+				{Author: "alice", State: "CHANGES_REQUESTED", SubmittedAt: start.Add(2 * time.Hour)},
+				{Author: "bob", State: "APPROVED", SubmittedAt: start.Add(time.Hour)},
+				{Author: "alice", State: "CHANGES_REQUESTED", SubmittedAt: start},
+			},
+			Rounds:  2,
+			Matches: false,
+			Reason:  "pull request has 2 review rounds, exceeding the testlist limit of 1",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.Rounds, reviewRounds(test.Reviews))
+
+			pc := &pulltest.MockPullContext{ReviewsValue: test.Reviews}
+
+			matches, reason, err := signals.Matches(ctx, pc, "testlist")
+			require.NoError(t, err)
+			assert.Equal(t, test.Matches, matches)
+			assert.Equal(t, test.Reason, reason)
+		})
+	}
+}
+
+func TestSignalsMatchesFixupCommits(t *testing.T) {
+	ctx := context.Background()
+
+	signals := Signals{
+		Match:                MatchAll,
+		DisallowFixupCommits: true,
+	}
+
+	tests := map[string]struct {
+		Method  MergeMethod
+		Commits []*pull.Commit
+		Matches bool
+		Reason  string
+	}{
+		"noFixupCommits": {
+			Commits: []*pull.Commit{
+				{SHA: "c1", Message: "Add fixup signal"},
+				{SHA: "c2", Message: "Document fixup! prefixes\n\nfixup! is a prefix"},
+			},
+			Matches: true,
+			Reason:  "pull request matches all testlist signals: pull request has no fixup or squash commits among its 2 commits",
+		},
+		"fixupCommit": {
+			Method: RebaseAndMerge,
+			Commits: []*pull.Commit{
+				{SHA: "c1", Message: "Add fixup signal"},
+				{SHA: "c2", Message: "fixup! Add fixup signal"},
+			},
+			Matches: false,
+			Reason:  `pull request commit c2 is a "fixup!" commit that must be squashed before merging: "fixup! Add fixup signal"`,
+		},
+		"squashCommit": {
+			Method: MergeCommit,
+			Commits: []*pull.Commit{
+				{SHA: "c1", Message: "Add fixup signal"},
+				{SHA: "c2", Message: "squash! Add fixup signal\n\nAlso handle squash commits"},
+			},
+			Matches: false,
+			Reason:  `pull request commit c2 is a "squash!" commit that must be squashed before merging: "squash! Add fixup signal"`,
+		},
+		"squashMerge": {
+			Method: SquashAndMerge,
+			Commits: []*pull.Commit{
+				{SHA: "c1", Message: "Add fixup signal"},
+				{SHA: "c2", Message: "fixup! Add fixup signal"},
+			},
+			Matches: true,
+			Reason:  `pull request matches all testlist signals: pull request will be merged with the "squash" method, which combines fixup commits`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			pc := &pulltest.MockPullContext{CommitsValue: test.Commits}
+
+			ctx := ctx
+			if test.Method != "" {
+				ctx = withMergeMethod(ctx, test.Method)
+			}
+
+			matches, reason, err := signals.Matches(ctx, pc, "testlist")
+			require.NoError(t, err)
+			assert.Equal(t, test.Matches, matches)
+			assert.Equal(t, test.Reason, reason)
+		})
+	}
+}
+
+func TestSignalsMatchesAddedFileSize(t *testing.T) {
+	ctx := context.Background()
+
+	files := []*pull.File{
+		{Filename: "src/main.go", Status: "modified", Additions: 5000},
+		{Filename: "data/fixture.json", Status: "added", Additions: 100},
+		{Filename: "assets/video.mp4", Status: "added", Binary: true},
+	}
+
+	tests := map[string]struct {
+		Signals   Signals
+		FileSizes map[string]int64
+		Matches   bool
+		Reason    string
+	}{
+		"withinLimit": {
+			Signals:   Signals{Match: MatchAll, MaxAddedFileBytes: 10000},
+			FileSizes: map[string]int64{"src/main.go": 200000, "data/fixture.json": 9000, "assets/video.mp4": 10000},
+			Matches:   true,
+			Reason:    `pull request matches all testlist signals: pull request adds no files larger than the testlist limit of 10000 bytes`,
+		},
+		"exceedsLimit": {
+			Signals:   Signals{Match: MatchAll, MaxAddedFileBytes: 10000},
+			FileSizes: map[string]int64{"data/fixture.json": 9000, "assets/video.mp4": 50000000},
+			Matches:   false,
+			Reason:    `pull request adds file "assets/video.mp4" with 50000000 bytes, exceeding the testlist limit of 10000 bytes`,
+		},
+		"estimatedWhenMissing": {
+			Signals:   Signals{Match: MatchAll, MaxAddedFileBytes: 4000},
+			FileSizes: map[string]int64{"assets/video.mp4": 3000},
+			Matches:   false,
+			Reason:    `pull request adds file "data/fixture.json" with an estimated 8000 bytes (100 lines), exceeding the testlist limit of 4000 bytes`,
+		},
+		"estimatedOnly": {
+			Signals:   Signals{Match: MatchAll, MaxAddedFileBytes: 10000, EstimateFileSizes: true},
+			FileSizes: map[string]int64{"assets/video.mp4": 50000000},
+			Matches:   true,
+			Reason:    `pull request matches all testlist signals: pull request adds no files larger than the testlist limit of 10000 bytes`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			pc := &pulltest.MockPullContext{
+				ChangedFilesValue: files,
+				FileSizesValue:    test.FileSizes,
+			}
+
+			matches, reason, err := test.Signals.Matches(ctx, pc, "testlist")
+			require.NoError(t, err)
+			assert.Equal(t, test.Matches, matches)
+			assert.Equal(t, test.Reason, reason)
+		})
+	}
+}
+
+func TestSignalsMatchesReapproval(t *testing.T) {
+	signals := Signals{
+		Match:                 MatchAll,
+		RequireReapprovalFrom: []string{"alice", "Bob"},
+	}
+
+	ctx := context.Background()
+	start := time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	review := func(author, state string, minutes int) *pull.Review {
+		return &pull.Review{Author: author, State: state, SubmittedAt: start.Add(time.Duration(minutes) * time.Minute)}
+	}
+
+	tests := map[string]struct {
+		Reviews []*pull.Review
+		Matches bool
+		Reason  string
+	}{
+		"neverRequestedChanges": {
+			Reviews: []*pull.Review{
+				review("alice", "APPROVED", 1),
+				review("carol", "CHANGES_REQUESTED", 2),
+			},
+			Matches: true,
+			Reason:  `pull request matches all testlist signals: pull request has no unresolved changes requested by testlist reviewers`,
+		},
+		"stillRequestingChanges": {
+			Reviews: []*pull.Review{
+				review("bob", "CHANGES_REQUESTED", 1),
+				review("bob", "COMMENTED", 2),
+			},
+			Matches: false,
+			Reason:  `pull request has not been approved by Bob since they requested changes`,
+		},
+		"approveRequestApprove": {
+			Reviews: []*pull.Review{
+				review("alice", "APPROVED", 1),
+				review("alice", "CHANGES_REQUESTED", 2),
+				review("alice", "APPROVED", 3),
+				review("alice", "COMMENTED", 4),
+			},
+			Matches: true,
+			Reason:  `pull request matches all testlist signals: pull request has no unresolved changes requested by testlist reviewers`,
+		},
+		"approveThenRequest": {
+			Reviews: []*pull.Review{
+				review("alice", "CHANGES_REQUESTED", 1),
+				review("alice", "APPROVED", 2),
+				review("alice", "CHANGES_REQUESTED", 3),
+			},
+			Matches: false,
+			Reason:  `pull request has not been approved by alice since they requested changes`,
+		},
+		"outOfOrder": {
+			Reviews: []*pull.Review{
+				review("alice", "CHANGES_REQUESTED", 3),
+				review("alice", "APPROVED", 2),
+			},
+			Matches: false,
+			Reason:  `pull request has not been approved by alice since they requested changes`,
+		},
+		"dismissed": {
+			Reviews: []*pull.Review{
+				review("bob", "CHANGES_REQUESTED", 1),
+				review("bob", "DISMISSED", 2),
+			},
+			Matches: true,
+			Reason:  `pull request matches all testlist signals: pull request has no unresolved changes requested by testlist reviewers`,
+		},
+		"approveRequestDismissed": {
+			Reviews: []*pull.Review{
+				review("alice", "APPROVED", 1),
+				review("alice", "CHANGES_REQUESTED", 2),
+				review("alice", "DISMISSED", 3),
+			},
+			Matches: true,
+			Reason:  `pull request matches all testlist signals: pull request has no unresolved changes requested by testlist reviewers`,
+		},
+		"dismissedThenRequest": {
+			Reviews: []*pull.Review{
+				review("alice", "CHANGES_REQUESTED", 1),
+				review("alice", "DISMISSED", 2),
+				review("alice", "CHANGES_REQUESTED", 3),
+			},
+			Matches: false,
+			Reason:  `pull request has not been approved by alice since they requested changes`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			pc := &pulltest.MockPullContext{ReviewsValue: test.Reviews}
+
+			matches, reason, err := signals.Matches(ctx, pc, "testlist")
+			require.NoError(t, err)
+			assert.Equal(t, test.Matches, matches)
+			assert.Equal(t, test.Reason, reason)
+		})
+	}
+}
@@ -66,7 +66,7 @@ func generateUpdateTestCase(ignorable bool, ignored bool, triggerable bool, trig
 	pullCtx := pulltest.MockPullContext{}
 
 	if ignorable {
-		updateConfig.Ignore.Labels = append(updateConfig.Ignore.Labels, "ignore")
+		updateConfig.Ignore.Labels.Values = append(updateConfig.Ignore.Labels.Values, "ignore")
 	}
 
 	if ignored {
@@ -74,7 +74,7 @@ func generateUpdateTestCase(ignorable bool, ignored bool, triggerable bool, trig
 	}
 
 	if triggerable {
-		updateConfig.Trigger.Labels = append(updateConfig.Trigger.Labels, "trigger")
+		updateConfig.Trigger.Labels.Values = append(updateConfig.Trigger.Labels.Values, "trigger")
 	}
 
 	if triggered {
@@ -17,5 +17,6 @@ require (
 	github.com/stretchr/testify v1.4.0
 	goji.io v2.0.2+incompatible
 	golang.org/x/crypto v0.0.0-20200109152110-61a87790db17 // indirect
+	golang.org/x/text v0.3.3
 	gopkg.in/yaml.v2 v2.2.8
 )
@@ -16,6 +16,8 @@ package pull
 
 import (
 	"context"
+	"strings"
+	"time"
 )
 
 // Context is the context for a pull request. It defines methods to get
@@ -44,19 +46,62 @@ type Context interface {
 	// Body returns the pull request body.
 	Body() string
 
+	// Creator returns the login of the user who opened the pull request.
+	Creator() string
+
+	// CreatorType returns the account type of the user who opened the pull
+	// request, like "User" or "Bot", as reported by GitHub.
+	CreatorType() string
+
 	// HeadSHA returns the SHA hash of the latest commit in the pull request.
 	HeadSHA() string
 
+	// CreatedAt returns the time the pull request was opened.
+	CreatedAt() time.Time
+
 	// Branches returns the base (also known as target) and head branch names
 	// of this pull request. Branches in this repository have no prefix, while
 	// branches in forks are prefixed with the owner of the fork and a colon.
 	// The base branch will always be unprefixed.
 	Branches() (base string, head string)
 
+	// AuthorAssociation returns the association of the pull request author
+	// with the repository, like "FIRST_TIME_CONTRIBUTOR", "CONTRIBUTOR", or
+	// "MEMBER", as reported by GitHub.
+	AuthorAssociation() string
+
+	// AuthorContributions returns the number of pull requests opened by the
+	// author of the pull request that were merged into the repository.
+	AuthorContributions(ctx context.Context) (int, error)
+
+	// AuthorCreatedAt returns the time the account of the pull request author
+	// was created.
+	AuthorCreatedAt(ctx context.Context) (time.Time, error)
+
 	// MergeState returns the current mergability of the pull request. It
 	// always returns the most up-to-date state possible.
 	MergeState(ctx context.Context) (*MergeState, error)
 
+	// AutoMerge returns the GitHub auto-merge settings of the pull request,
+	// or nil if auto-merge is not enabled. Like MergeState, it always
+	// returns the most up-to-date state possible.
+	AutoMerge(ctx context.Context) (*AutoMerge, error)
+
+	// MergeSettings returns the merge methods allowed by the repository.
+	MergeSettings(ctx context.Context) (*MergeSettings, error)
+
+	// DefaultBranch returns the name of the default branch of the
+	// repository.
+	DefaultBranch(ctx context.Context) (string, error)
+
+	// Topics returns the topics of the repository.
+	Topics(ctx context.Context) ([]string, error)
+
+	// RepositoryProperties returns the custom properties of the repository,
+	// keyed by property name. Properties with a single value have one
+	// element and properties without a value are omitted.
+	RepositoryProperties(ctx context.Context) (map[string][]string, error)
+
 	// RequiredStatuses returns the names of the required status
 	// checks for the pull request.
 	RequiredStatuses(ctx context.Context) ([]string, error)
@@ -65,30 +110,419 @@ type Context interface {
 	// restricts the users or teams that have push access.
 	PushRestrictions(ctx context.Context) (bool, error)
 
+	// RequiredApprovals returns the number of approving reviews required by
+	// the branch protection of the target branch of the pull request. It
+	// returns 0 if the branch is not protected or does not require reviews.
+	RequiredApprovals(ctx context.Context) (int, error)
+
+	// RequiresConversationResolution returns true if the branch protection
+	// of the target branch of the pull request requires all conversations
+	// to be resolved before merging. It returns false if the branch is not
+	// protected.
+	RequiresConversationResolution(ctx context.Context) (bool, error)
+
+	// IsBranchProtected returns true if the named branch in the pull request
+	// repository has branch protection enabled. It returns an error if the
+	// protection status cannot be read.
+	IsBranchProtected(ctx context.Context, branch string) (bool, error)
+
+	// BranchExists returns true if the named branch exists in the pull
+	// request repository and false if it was deleted. It returns an error,
+	// not false, if the branch cannot be read for another reason.
+	BranchExists(ctx context.Context, branch string) (bool, error)
+
 	// CurrentSuccessStatuses returns the names of all currently
 	// successful status checks for the pull request.
 	CurrentSuccessStatuses(ctx context.Context) ([]string, error)
 
+	// Statuses returns all commit statuses and check runs reported for the
+	// head commit of the pull request, regardless of their state.
+	Statuses(ctx context.Context) ([]*Status, error)
+
+	// CheckOutputs returns the output, including annotations, of the check
+	// runs with the given name reported for the head commit of the pull
+	// request. It returns no outputs if there is no such check run.
+	CheckOutputs(ctx context.Context, check string) ([]*CheckOutput, error)
+
+	// Deployments lists all deployments of the head commit of the pull
+	// request, with the state of the latest status of each deployment.
+	Deployments(ctx context.Context) ([]*Deployment, error)
+
+	// WorkflowRuns lists all GitHub Actions workflow runs for the head commit
+	// of the pull request, including runs that were re-run or superseded.
+	WorkflowRuns(ctx context.Context) ([]*WorkflowRun, error)
+
+	// LabelEvents lists the events that added labels to the pull request,
+	// ordered from oldest to newest. Labels that were later removed are
+	// included.
+	LabelEvents(ctx context.Context) ([]*LabelEvent, error)
+
+	// Timeline lists the events on the timeline of the pull request, like
+	// "head_ref_force_pushed", ordered from oldest to newest.
+	Timeline(ctx context.Context) ([]*TimelineEvent, error)
+
+	// RequestedReviewers returns the users and teams whose review is
+	// currently requested on the pull request. GitHub removes a user from
+	// this list when they submit a review, unless their review is requested
+	// again.
+	RequestedReviewers(ctx context.Context) (*RequestedReviewers, error)
+
+	// Reviews lists all submitted reviews on the pull request, ordered from
+	// oldest to newest.
+	Reviews(ctx context.Context) ([]*Review, error)
+
+	// Participants lists the distinct users who commented on or reviewed the
+	// pull request, including review comments, in the order they first
+	// participated.
+	Participants(ctx context.Context) ([]*Participant, error)
+
+	// ReviewThreads lists the threads of review comments on the lines of
+	// the pull request.
+	ReviewThreads(ctx context.Context) ([]*ReviewThread, error)
+
+	// TeamMembers returns the logins of the members of a team, identified by
+	// slug, in the organization that owns the repository.
+	TeamMembers(ctx context.Context, team string) ([]string, error)
+
+	// PullRequestState returns the state of another pull request in the
+	// same repository.
+	PullRequestState(ctx context.Context, number int) (*PullRequestState, error)
+
+	// OpenPullRequestsWithHead returns the numbers of the open pull requests
+	// in the same repository whose head is the given branch of the
+	// repository.
+	OpenPullRequestsWithHead(ctx context.Context, branch string) ([]int, error)
+
+	// Issue returns an issue in the same repository, or nil if the issue
+	// does not exist.
+	Issue(ctx context.Context, number int) (*Issue, error)
+
+	// DiscussionExists returns true if a discussion exists in a repository,
+	// which may be different from the repository of the pull request.
+	DiscussionExists(ctx context.Context, owner, repo string, number int) (bool, error)
+
 	// Comments lists all comments on the pull request.
 	Comments(ctx context.Context) ([]string, error)
 
-	// Commits lists all commits on the pull request.
+	// AuthoredComments lists all comments on the pull request with the
+	// login of the user who wrote each comment, in the same order as
+	// Comments.
+	AuthoredComments(ctx context.Context) ([]*Comment, error)
+
+	// Reactions lists the reactions to the body of the pull request.
+	// Reactions to comments are counted by the Reactions field of Comment.
+	Reactions(ctx context.Context) ([]*Reaction, error)
+
+	// Commits lists all commits on the pull request, ordered from oldest to
+	// newest.
 	Commits(ctx context.Context) ([]*Commit, error)
 
+	// ChangedFiles lists the files changed by the pull request.
+	ChangedFiles(ctx context.Context) ([]*File, error)
+
+	// FileSizes returns the size in bytes of files in the head commit of the
+	// pull request, keyed by path. Sizes are read with a single request, so
+	// files may be missing if the repository has too many files for GitHub
+	// to list at once.
+	FileSizes(ctx context.Context) (map[string]int64, error)
+
+	// Diff returns the unified diff of the pull request.
+	Diff(ctx context.Context) (string, error)
+
+	// CodeOwners returns the contents of the CODEOWNERS file on the base
+	// branch, from the first location GitHub supports that has one: the
+	// ".github" directory, the root, or the "docs" directory. It returns an
+	// empty string if the repository does not have a CODEOWNERS file.
+	CodeOwners(ctx context.Context) (string, error)
+
 	// Labels lists all labels on the pull request.
 	Labels(ctx context.Context) ([]string, error)
 
 	// IsTargeted returns true if the head branch of this pull request is the
 	// target branch of other open PRs on the repository.
 	IsTargeted(ctx context.Context) (bool, error)
+
+	// MergeAttempts returns the number of consecutive failed merge attempts
+	// recorded for the head commit of the pull request, or zero if no
+	// attempts are recorded.
+	MergeAttempts(ctx context.Context) (int, error)
+
+	// SetMergeAttempts records the number of consecutive failed merge
+	// attempts for the head commit of the pull request.
+	SetMergeAttempts(ctx context.Context, attempts int) error
 }
 
 type MergeState struct {
 	Closed    bool
 	Mergeable *bool
+
+	// Rebaseable is true if the pull request can be rebased onto the target
+	// branch. Like Mergeable, it is nil if GitHub has not computed it yet.
+	Rebaseable *bool
+}
+
+// AutoMerge describes GitHub's native auto-merge for a pull request, which
+// merges the pull request when its requirements are met.
+type AutoMerge struct {
+	// EnabledBy is the login of the user who enabled auto-merge.
+	EnabledBy string
+
+	// MergeMethod is the method GitHub uses to merge, like "squash".
+	MergeMethod string
+}
+
+// MergeSettings are the merge methods allowed by the repository of a pull
+// request.
+type MergeSettings struct {
+	AllowMergeCommit bool
+	AllowSquash      bool
+	AllowRebase      bool
+}
+
+// Status is a commit status or check run reported for the head commit of a
+// pull request.
+type Status struct {
+	// Context is the context of a commit status or the name of a check run.
+	Context string
+
+	// State is the state of a commit status or the conclusion of a completed
+	// check run. For check runs that are not completed, it is the status of
+	// the check run, like "queued" or "in_progress".
+	State string
+
+	// App is the slug of the GitHub App that created a check run. It is empty
+	// for commit statuses.
+	App string
+
+	// Description is the description of a commit status or the title of
+	// the output of a check run, like "85.00% of diff hit (target 80.00%)".
+	Description string
+
+	// CompletedAt is the time a commit status was last set or a check run
+	// completed. It is zero for check runs that are not completed.
+	CompletedAt time.Time
+}
+
+// CheckOutput is the output of a check run.
+type CheckOutput struct {
+	Title       string
+	Summary     string
+	Annotations []*CheckAnnotation
+}
+
+// CheckAnnotation is an annotation of a check run on a line of a file.
+type CheckAnnotation struct {
+	Path string
+	Line int
+
+	// Level is the severity of the annotation: "notice", "warning", or
+	// "failure".
+	Level   string
+	Title   string
+	Message string
+}
+
+// WorkflowRun is a GitHub Actions workflow run for the head commit of a pull
+// request.
+type WorkflowRun struct {
+	// Name is the name of the workflow.
+	Name string
+
+	// Status is the status of the run, like "queued" or "completed", and
+	// Conclusion is the result of a completed run, like "success".
+	Status     string
+	Conclusion string
+
+	CreatedAt time.Time
+}
+
+// Deployment is a deployment of the head commit of a pull request.
+type Deployment struct {
+	Environment string
+	CreatedAt   time.Time
+
+	// State is the state of the latest status of the deployment, like
+	// "success" or "failure". It is empty if the deployment has no statuses.
+	State string
+}
+
+// File is a file changed by a pull request.
+type File struct {
+	Filename string
+
+	// Status is the change made to the file, like "added", "modified",
+	// "removed", or "renamed".
+	Status string
+
+	// PreviousFilename is the name of a renamed file before the pull
+	// request. It is empty for files that were not renamed.
+	PreviousFilename string
+
+	Additions int
+	Deletions int
+
+	// Binary is true if the file is a binary file. GitHub does not provide a
+	// patch for binary files, so this is true for files without a patch and
+	// without added or removed lines that were not only renamed. It is
+	// unrelated to whether the file is generated.
+	Binary bool
+}
+
+// PullRequestState is the state of a pull request.
+type PullRequestState struct {
+	// State is "open" or "closed".
+	State  string
+	Merged bool
+}
+
+// Issue is an issue in the repository of a pull request.
+type Issue struct {
+	// State is "open" or "closed".
+	State     string
+	Labels    []string
+	Assignees []string
+}
+
+// RequestedReviewers are the users and teams requested to review a pull
+// request. Users are identified by login and teams by slug.
+type RequestedReviewers struct {
+	Users []string
+	Teams []string
+}
+
+// Review is a review submitted on a pull request.
+type Review struct {
+	Author string
+
+	// State is the state of the review, like "APPROVED", "CHANGES_REQUESTED",
+	// or "COMMENTED".
+	State string
+
+	SubmittedAt time.Time
+
+	// CommitID is the SHA of the head commit of the pull request when the
+	// review was submitted.
+	CommitID string
+}
+
+// Participant is a user who commented on or reviewed a pull request.
+type Participant struct {
+	Login string
+
+	// Bot is true if the user is a GitHub App, which have logins ending in
+	// "[bot]".
+	Bot bool
+}
+
+// ParticipantsOf returns the distinct authors of the comments and reviews,
+// ignoring case, in the order they first appear. Comments are considered
+// before reviews.
+func ParticipantsOf(comments []*Comment, reviews []*Review) []*Participant {
+	var logins []string
+	for _, c := range comments {
+		logins = append(logins, c.Author)
+	}
+	for _, r := range reviews {
+		logins = append(logins, r.Author)
+	}
+
+	seen := make(map[string]bool)
+	participants := []*Participant{}
+	for _, login := range logins {
+		key := strings.ToLower(login)
+		if login == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		participants = append(participants, &Participant{
+			Login: login,
+			Bot:   strings.HasSuffix(key, "[bot]"),
+		})
+	}
+	return participants
+}
+
+// ReviewThread is a thread of review comments on the lines of a pull request.
+type ReviewThread struct {
+	Path string
+
+	// Line is the line of the file the thread is attached to, or zero if the
+	// thread is outdated because the line changed after the thread started.
+	Line int
+
+	// Side is "RIGHT" if Line is a line in the head version of the file and
+	// "LEFT" if it is a line in the base version, like a removed line.
+	Side string
+
+	Resolved bool
+}
+
+// LabelEvent records a label being added to a pull request.
+type LabelEvent struct {
+	Label     string
+	Actor     string
+	CreatedAt time.Time
+}
+
+// TimelineEvent is an event on the timeline of a pull request.
+type TimelineEvent struct {
+	// Event is the type of the event, like "head_ref_force_pushed" or
+	// "labeled".
+	Event     string
+	Actor     string
+	CreatedAt time.Time
+}
+
+// Comment is a comment on a pull request.
+type Comment struct {
+	Author    string
+	Body      string
+	CreatedAt time.Time
+
+	// Edited is true if the body of the comment changed after it was
+	// created. OriginalBody is the body when the comment was created, or
+	// empty if the comment was not edited or GitHub does not report it.
+	Edited       bool
+	OriginalBody string
+
+	// Reactions counts the reactions on the comment by content, like "+1"
+	// or "heart".
+	Reactions map[string]int
+}
+
+// Reaction is a reaction to the body of a pull request.
+type Reaction struct {
+	// Content is the type of the reaction, like "+1" or "heart".
+	Content string
+	User    string
 }
 
 type Commit struct {
 	SHA     string
 	Message string
+
+	// Parents are the SHAs of the parents of the commit. Merge commits have
+	// more than one parent.
+	Parents []string
+
+	// Author and Committer identify the author and committer of the commit.
+	Author    CommitIdentity
+	Committer CommitIdentity
+
+	// Verified is true if GitHub verified the signature of the commit.
+	Verified bool
+
+	// VerificationReason is the reason code GitHub gives for the verification
+	// status of the commit, such as "valid", "unsigned", or "unknown_key".
+	VerificationReason string
+
+	// CommittedAt is the committer date of the commit.
+	CommittedAt time.Time
+}
+
+// CommitIdentity is the author or committer of a commit. Login is empty if
+// the email is not associated with a GitHub account.
+type CommitIdentity struct {
+	Login string
+	Email string
 }
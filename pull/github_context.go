@@ -16,8 +16,15 @@ package pull
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/google/go-github/v32/github"
 	"github.com/pkg/errors"
@@ -26,7 +33,8 @@ import (
 // GithubContext is a Context implementation that gets information from GitHub.
 // A new instance must be created for each request.
 type GithubContext struct {
-	client *github.Client
+	client   *github.Client
+	appLogin AppLoginFunc
 
 	owner  string
 	repo   string
@@ -34,15 +42,52 @@ type GithubContext struct {
 	pr     *github.PullRequest
 
 	// cached fields
-	comments         []string
-	commits          []*Commit
-	branchProtection *github.Protection
-	successStatuses  []string
+	comments          []*Comment
+	commits           []*Commit
+	diff              *string
+	codeOwners        *string
+	files             []*File
+	fileSizes         map[string]int64
+	branchProtection  *github.Protection
+	resolutionPolicy  *bool
+	protectedBranches map[string]bool
+	existingBranches  map[string]bool
+	successStatuses   []string
+	statuses          []*Status
+	checkOutputs      map[string][]*CheckOutput
+	deployments       []*Deployment
+	workflowRuns      []*WorkflowRun
+	labelEvents       []*LabelEvent
+	timeline          []*TimelineEvent
+	reviewers         *RequestedReviewers
+	reviews           []*Review
+	reviewThreads     []*ReviewThread
+	teamMembers       map[string][]string
+	repositoryDetails *github.Repository
+	properties        map[string][]string
+	reactions         []*Reaction
+	pullRequestStates map[int]*PullRequestState
+	pullRequestHeads  map[string][]int
+	issues            map[int]*Issue
+	discussions       map[string]bool
+	contributions     *int
+	authorCreatedAt   *time.Time
+
+	mergeAttemptsLoaded  bool
+	mergeAttempts        int
+	mergeAttemptsComment *github.IssueComment
 }
 
-func NewGithubContext(client *github.Client, pr *github.PullRequest) Context {
+// AppLoginFunc returns the login of the bot user that bulldozer acts as, like
+// "bulldozer[bot]".
+type AppLoginFunc func(ctx context.Context) (string, error)
+
+// NewGithubContext creates a Context for the pull request. The appLogin
+// function is only called to track merge attempts, which fail if it is nil.
+func NewGithubContext(client *github.Client, pr *github.PullRequest, appLogin AppLoginFunc) Context {
 	return &GithubContext{
-		client: client,
+		client:   client,
+		appLogin: appLogin,
 
 		pr:     pr,
 		owner:  pr.GetBase().GetRepo().GetOwner().GetLogin(),
@@ -75,10 +120,22 @@ func (ghc *GithubContext) Body() string {
 	return ghc.pr.GetBody()
 }
 
+func (ghc *GithubContext) Creator() string {
+	return ghc.pr.GetUser().GetLogin()
+}
+
+func (ghc *GithubContext) CreatorType() string {
+	return ghc.pr.GetUser().GetType()
+}
+
 func (ghc *GithubContext) HeadSHA() string {
 	return ghc.pr.GetHead().GetSHA()
 }
 
+func (ghc *GithubContext) CreatedAt() time.Time {
+	return ghc.pr.GetCreatedAt()
+}
+
 func (ghc *GithubContext) MergeState(ctx context.Context) (*MergeState, error) {
 	pr, _, err := ghc.client.PullRequests.Get(ctx, ghc.owner, ghc.repo, ghc.number)
 	if err != nil {
@@ -86,13 +143,143 @@ func (ghc *GithubContext) MergeState(ctx context.Context) (*MergeState, error) {
 	}
 
 	return &MergeState{
-		Closed:    pr.GetState() == "closed",
-		Mergeable: pr.Mergeable,
+		Closed:     pr.GetState() == "closed",
+		Mergeable:  pr.Mergeable,
+		Rebaseable: pr.Rebaseable,
+	}, nil
+}
+
+func (ghc *GithubContext) AutoMerge(ctx context.Context) (*AutoMerge, error) {
+	// the version of go-github used here does not expose the auto_merge
+	// field, so decode it from the raw pull request
+	u := fmt.Sprintf("repos/%s/%s/pulls/%d", ghc.owner, ghc.repo, ghc.number)
+	req, err := ghc.client.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create pull request request")
+	}
+
+	var pr struct {
+		AutoMerge *struct {
+			EnabledBy   *github.User `json:"enabled_by"`
+			MergeMethod string       `json:"merge_method"`
+		} `json:"auto_merge"`
+	}
+	if _, err := ghc.client.Do(ctx, req, &pr); err != nil {
+		return nil, errors.Wrap(err, "failed to get pull request auto-merge state")
+	}
+
+	if pr.AutoMerge == nil {
+		return nil, nil
+	}
+	return &AutoMerge{
+		EnabledBy:   pr.AutoMerge.EnabledBy.GetLogin(),
+		MergeMethod: pr.AutoMerge.MergeMethod,
+	}, nil
+}
+
+func (ghc *GithubContext) MergeSettings(ctx context.Context) (*MergeSettings, error) {
+	repo, err := ghc.repository(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &MergeSettings{
+		AllowMergeCommit: repo.GetAllowMergeCommit(),
+		AllowSquash:      repo.GetAllowSquashMerge(),
+		AllowRebase:      repo.GetAllowRebaseMerge(),
 	}, nil
 }
 
+func (ghc *GithubContext) DefaultBranch(ctx context.Context) (string, error) {
+	if branch := ghc.pr.GetBase().GetRepo().GetDefaultBranch(); branch != "" {
+		return branch, nil
+	}
+
+	repo, err := ghc.repository(ctx)
+	if err != nil {
+		return "", err
+	}
+	return repo.GetDefaultBranch(), nil
+}
+
+// repository returns the full repository of the pull request, which includes
+// settings that are not present in pull request payloads.
+func (ghc *GithubContext) Topics(ctx context.Context) ([]string, error) {
+	repo, err := ghc.repository(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return repo.Topics, nil
+}
+
+func (ghc *GithubContext) RepositoryProperties(ctx context.Context) (map[string][]string, error) {
+	if ghc.properties == nil {
+		// the version of go-github used here does not support custom
+		// properties, so request them directly
+		u := fmt.Sprintf("repos/%s/%s/properties/values", ghc.owner, ghc.repo)
+		req, err := ghc.client.NewRequest("GET", u, nil)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to create repository properties request")
+		}
+
+		var values []struct {
+			PropertyName string          `json:"property_name"`
+			Value        json.RawMessage `json:"value"`
+		}
+		if _, err := ghc.client.Do(ctx, req, &values); err != nil {
+			return nil, errors.Wrapf(err, "failed to get properties of repository %s/%s", ghc.owner, ghc.repo)
+		}
+
+		properties := make(map[string][]string)
+		for _, v := range values {
+			var single string
+			var multiple []string
+			switch {
+			case len(v.Value) == 0 || string(v.Value) == "null":
+				continue
+			case json.Unmarshal(v.Value, &single) == nil:
+				properties[v.PropertyName] = []string{single}
+			case json.Unmarshal(v.Value, &multiple) == nil && len(multiple) > 0:
+				properties[v.PropertyName] = multiple
+			}
+		}
+		ghc.properties = properties
+	}
+	return ghc.properties, nil
+}
+
+func (ghc *GithubContext) repository(ctx context.Context) (*github.Repository, error) {
+	if ghc.repositoryDetails == nil {
+		repo, _, err := ghc.client.Repositories.Get(ctx, ghc.owner, ghc.repo)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get repository %s/%s", ghc.owner, ghc.repo)
+		}
+		ghc.repositoryDetails = repo
+	}
+	return ghc.repositoryDetails, nil
+}
+
 func (ghc *GithubContext) Comments(ctx context.Context) ([]string, error) {
+	comments, err := ghc.AuthoredComments(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	bodies := make([]string, len(comments))
+	for i, c := range comments {
+		bodies[i] = c.Body
+	}
+	return bodies, nil
+}
+
+func (ghc *GithubContext) AuthoredComments(ctx context.Context) ([]*Comment, error) {
 	if ghc.comments == nil {
+		// comments that were updated after they were created may have been
+		// edited; their edits are loaded after listing all comments
+		edited := make(map[string]*Comment)
+
+		// comments are only cached once all of them are loaded, so a failed
+		// call can be retried
+		var all []*Comment
 
 		prCommentOpts := &github.PullRequestListCommentsOptions{ListOptions: github.ListOptions{PerPage: 100}}
 		for {
@@ -102,7 +289,15 @@ func (ghc *GithubContext) Comments(ctx context.Context) ([]string, error) {
 			}
 
 			for _, c := range comments {
-				ghc.comments = append(ghc.comments, c.GetBody())
+				all = append(all, &Comment{
+					Author:    c.GetUser().GetLogin(),
+					Body:      c.GetBody(),
+					CreatedAt: c.GetCreatedAt(),
+					Reactions: reactionCounts(c.Reactions),
+				})
+				if c.GetUpdatedAt().After(c.GetCreatedAt()) {
+					edited[c.GetNodeID()] = all[len(all)-1]
+				}
 			}
 
 			if res.NextPage == 0 {
@@ -119,7 +314,18 @@ func (ghc *GithubContext) Comments(ctx context.Context) ([]string, error) {
 			}
 
 			for _, c := range comments {
-				ghc.comments = append(ghc.comments, c.GetBody())
+				if isMergeAttemptsState(c) {
+					continue
+				}
+				all = append(all, &Comment{
+					Author:    c.GetUser().GetLogin(),
+					Body:      c.GetBody(),
+					CreatedAt: c.GetCreatedAt(),
+					Reactions: reactionCounts(c.Reactions),
+				})
+				if c.GetUpdatedAt().After(c.GetCreatedAt()) {
+					edited[c.GetNodeID()] = all[len(all)-1]
+				}
 			}
 
 			if res.NextPage == 0 {
@@ -127,11 +333,144 @@ func (ghc *GithubContext) Comments(ctx context.Context) ([]string, error) {
 			}
 			issueCommentOpts.Page = res.NextPage
 		}
+
+		if err := ghc.loadCommentEdits(ctx, edited); err != nil {
+			return nil, err
+		}
+		ghc.comments = all
 	}
 
 	return ghc.comments, nil
 }
 
+const commentEditsQuery = `query($ids: [ID!]!) {
+  nodes(ids: $ids) {
+    id
+    ... on Comment {
+      lastEditedAt
+      includesCreatedEdit
+      userContentEdits(last: 1) {
+        nodes {
+          diff
+        }
+      }
+    }
+  }
+}`
+
+// loadCommentEdits sets whether the comments, keyed by node ID, were edited
+// and their original bodies. The REST API does not report edits, so this
+// uses GraphQL, where the oldest edit of a comment records the body it was
+// created with.
+func (ghc *GithubContext) loadCommentEdits(ctx context.Context, comments map[string]*Comment) error {
+	ids := make([]string, 0, len(comments))
+	for id := range comments {
+		if id != "" {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+
+	for len(ids) > 0 {
+		batch := ids
+		if len(batch) > 100 {
+			batch = batch[:100]
+		}
+		ids = ids[len(batch):]
+
+		body := map[string]interface{}{
+			"query":     commentEditsQuery,
+			"variables": map[string]interface{}{"ids": batch},
+		}
+		req, err := ghc.client.NewRequest("POST", "../graphql", body)
+		if err != nil {
+			return errors.Wrap(err, "failed to create comment edits request")
+		}
+
+		var res struct {
+			Data struct {
+				Nodes []struct {
+					ID                  string     `json:"id"`
+					LastEditedAt        *time.Time `json:"lastEditedAt"`
+					IncludesCreatedEdit bool       `json:"includesCreatedEdit"`
+					UserContentEdits    struct {
+						Nodes []struct {
+							Diff *string `json:"diff"`
+						} `json:"nodes"`
+					} `json:"userContentEdits"`
+				} `json:"nodes"`
+			} `json:"data"`
+			Errors []struct {
+				Message string `json:"message"`
+			} `json:"errors"`
+		}
+		if _, err := ghc.client.Do(ctx, req, &res); err != nil {
+			return errors.Wrap(err, "failed to list pull request comment edits")
+		}
+		if len(res.Errors) > 0 {
+			return errors.Errorf("failed to list pull request comment edits: %s", res.Errors[0].Message)
+		}
+
+		for _, n := range res.Data.Nodes {
+			c, ok := comments[n.ID]
+			if !ok || n.LastEditedAt == nil {
+				continue
+			}
+			c.Edited = true
+			if edits := n.UserContentEdits.Nodes; n.IncludesCreatedEdit && len(edits) > 0 && edits[0].Diff != nil {
+				c.OriginalBody = *edits[0].Diff
+			}
+		}
+	}
+	return nil
+}
+
+func reactionCounts(r *github.Reactions) map[string]int {
+	counts := make(map[string]int)
+	for content, count := range map[string]int{
+		"+1":       r.GetPlusOne(),
+		"-1":       r.GetMinusOne(),
+		"laugh":    r.GetLaugh(),
+		"confused": r.GetConfused(),
+		"heart":    r.GetHeart(),
+		"hooray":   r.GetHooray(),
+	} {
+		if count > 0 {
+			counts[content] = count
+		}
+	}
+	return counts
+}
+
+func (ghc *GithubContext) Reactions(ctx context.Context) ([]*Reaction, error) {
+	if ghc.reactions == nil {
+		opts := &github.ListOptions{PerPage: 100}
+		reactions := []*Reaction{}
+
+		for {
+			page, res, err := ghc.client.Reactions.ListIssueReactions(ctx, ghc.owner, ghc.repo, ghc.number, opts)
+			if err != nil {
+				return nil, errors.Wrap(err, "failed to list pull request reactions")
+			}
+
+			for _, r := range page {
+				reactions = append(reactions, &Reaction{
+					Content: r.GetContent(),
+					User:    r.GetUser().GetLogin(),
+				})
+			}
+
+			if res.NextPage == 0 {
+				break
+			}
+			opts.Page = res.NextPage
+		}
+
+		ghc.reactions = reactions
+	}
+	return ghc.reactions, nil
+}
+
 func (ghc *GithubContext) Commits(ctx context.Context) ([]*Commit, error) {
 	if ghc.commits == nil {
 		opts := &github.ListOptions{
@@ -154,15 +493,126 @@ func (ghc *GithubContext) Commits(ctx context.Context) ([]*Commit, error) {
 
 		ghc.commits = make([]*Commit, len(allCommits))
 		for i, c := range allCommits {
+			parents := make([]string, len(c.Parents))
+			for j, p := range c.Parents {
+				parents[j] = p.GetSHA()
+			}
+
 			ghc.commits[i] = &Commit{
-				SHA:     c.GetCommit().GetSHA(),
+				SHA:     c.GetSHA(),
 				Message: c.GetCommit().GetMessage(),
+				Parents: parents,
+				Author: CommitIdentity{
+					Login: c.GetAuthor().GetLogin(),
+					Email: c.GetCommit().GetAuthor().GetEmail(),
+				},
+				Committer: CommitIdentity{
+					Login: c.GetCommitter().GetLogin(),
+					Email: c.GetCommit().GetCommitter().GetEmail(),
+				},
+				Verified:           c.GetCommit().GetVerification().GetVerified(),
+				VerificationReason: c.GetCommit().GetVerification().GetReason(),
+				CommittedAt:        c.GetCommit().GetCommitter().GetDate(),
 			}
 		}
 	}
 	return ghc.commits, nil
 }
 
+func (ghc *GithubContext) ChangedFiles(ctx context.Context) ([]*File, error) {
+	if ghc.files == nil {
+		opts := &github.ListOptions{PerPage: 100}
+		files := []*File{}
+
+		for {
+			commitFiles, res, err := ghc.client.PullRequests.ListFiles(ctx, ghc.owner, ghc.repo, ghc.number, opts)
+			if err != nil {
+				return nil, errors.Wrap(err, "failed to list pull request files")
+			}
+
+			for _, f := range commitFiles {
+				files = append(files, &File{
+					Filename:         f.GetFilename(),
+					Status:           f.GetStatus(),
+					PreviousFilename: f.GetPreviousFilename(),
+					Additions:        f.GetAdditions(),
+					Deletions:        f.GetDeletions(),
+					Binary:           f.GetPatch() == "" && f.GetChanges() == 0 && f.GetStatus() != "renamed",
+				})
+			}
+
+			if res.NextPage == 0 {
+				break
+			}
+			opts.Page = res.NextPage
+		}
+
+		ghc.files = files
+	}
+	return ghc.files, nil
+}
+
+func (ghc *GithubContext) FileSizes(ctx context.Context) (map[string]int64, error) {
+	if ghc.fileSizes == nil {
+		tree, _, err := ghc.client.Git.GetTree(ctx, ghc.owner, ghc.repo, ghc.HeadSHA(), true)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get tree for SHA %s", ghc.HeadSHA())
+		}
+
+		sizes := make(map[string]int64, len(tree.Entries))
+		for _, e := range tree.Entries {
+			if e.GetType() == "blob" {
+				sizes[e.GetPath()] = int64(e.GetSize())
+			}
+		}
+		ghc.fileSizes = sizes
+	}
+	return ghc.fileSizes, nil
+}
+
+func (ghc *GithubContext) Diff(ctx context.Context) (string, error) {
+	if ghc.diff == nil {
+		diff, _, err := ghc.client.PullRequests.GetRaw(ctx, ghc.owner, ghc.repo, ghc.number, github.RawOptions{Type: github.Diff})
+		if err != nil {
+			return "", errors.Wrap(err, "failed to get pull request diff")
+		}
+		ghc.diff = &diff
+	}
+	return *ghc.diff, nil
+}
+
+// codeOwnersPaths are the locations GitHub reads a CODEOWNERS file from, in
+// the order it checks them.
+var codeOwnersPaths = []string{".github/CODEOWNERS", "CODEOWNERS", "docs/CODEOWNERS"}
+
+func (ghc *GithubContext) CodeOwners(ctx context.Context) (string, error) {
+	if ghc.codeOwners == nil {
+		base, _ := ghc.Branches()
+		opts := &github.RepositoryContentGetOptions{Ref: base}
+
+		contents := ""
+		for _, p := range codeOwnersPaths {
+			file, _, _, err := ghc.client.Repositories.GetContents(ctx, ghc.owner, ghc.repo, p, opts)
+			if err != nil {
+				if isNotFound(err) {
+					continue
+				}
+				return "", errors.Wrapf(err, "failed to get %s on branch %q", p, base)
+			}
+			if file == nil {
+				continue
+			}
+
+			if contents, err = file.GetContent(); err != nil {
+				return "", errors.Wrapf(err, "failed to decode %s on branch %q", p, base)
+			}
+			break
+		}
+		ghc.codeOwners = &contents
+	}
+	return *ghc.codeOwners, nil
+}
+
 func (ghc *GithubContext) RequiredStatuses(ctx context.Context) ([]string, error) {
 	if ghc.branchProtection == nil {
 		if err := ghc.loadBranchProtection(ctx); err != nil {
@@ -187,6 +637,81 @@ func (ghc *GithubContext) PushRestrictions(ctx context.Context) (bool, error) {
 	return false, nil
 }
 
+func (ghc *GithubContext) RequiredApprovals(ctx context.Context) (int, error) {
+	if ghc.branchProtection == nil {
+		if err := ghc.loadBranchProtection(ctx); err != nil {
+			return 0, err
+		}
+	}
+	if reviews := ghc.branchProtection.GetRequiredPullRequestReviews(); reviews != nil {
+		return reviews.RequiredApprovingReviewCount, nil
+	}
+	return 0, nil
+}
+
+func (ghc *GithubContext) RequiresConversationResolution(ctx context.Context) (bool, error) {
+	if ghc.resolutionPolicy == nil {
+		// the client does not decode this part of the branch protection, so
+		// request the protection directly
+		base := ghc.pr.GetBase().GetRef()
+		u := fmt.Sprintf("repos/%s/%s/branches/%s/protection", ghc.owner, ghc.repo, url.PathEscape(base))
+		req, err := ghc.client.NewRequest("GET", u, nil)
+		if err != nil {
+			return false, errors.Wrap(err, "failed to create branch protection request")
+		}
+
+		var protection struct {
+			RequiredConversationResolution struct {
+				Enabled bool `json:"enabled"`
+			} `json:"required_conversation_resolution"`
+		}
+		if _, err := ghc.client.Do(ctx, req, &protection); err != nil && !isNotFound(err) {
+			return false, errors.Wrapf(err, "cannot get branch protection for %s", ghc.Locator())
+		}
+
+		required := protection.RequiredConversationResolution.Enabled
+		ghc.resolutionPolicy = &required
+	}
+	return *ghc.resolutionPolicy, nil
+}
+
+func (ghc *GithubContext) IsBranchProtected(ctx context.Context, branch string) (bool, error) {
+	if protected, ok := ghc.protectedBranches[branch]; ok {
+		return protected, nil
+	}
+
+	b, _, err := ghc.client.Repositories.GetBranch(ctx, ghc.owner, ghc.repo, branch)
+	if err != nil {
+		if isForbidden(err) {
+			return false, errors.Wrapf(err, "insufficient permissions to read protection status of branch %q on %s", branch, ghc.Locator())
+		}
+		return false, errors.Wrapf(err, "cannot get branch %q on %s", branch, ghc.Locator())
+	}
+
+	if ghc.protectedBranches == nil {
+		ghc.protectedBranches = make(map[string]bool)
+	}
+	ghc.protectedBranches[branch] = b.GetProtected()
+	return b.GetProtected(), nil
+}
+
+func (ghc *GithubContext) BranchExists(ctx context.Context, branch string) (bool, error) {
+	if exists, ok := ghc.existingBranches[branch]; ok {
+		return exists, nil
+	}
+
+	_, _, err := ghc.client.Repositories.GetBranch(ctx, ghc.owner, ghc.repo, branch)
+	if err != nil && !isNotFound(err) {
+		return false, errors.Wrapf(err, "cannot get branch %q on %s", branch, ghc.Locator())
+	}
+
+	if ghc.existingBranches == nil {
+		ghc.existingBranches = make(map[string]bool)
+	}
+	ghc.existingBranches[branch] = err == nil
+	return err == nil, nil
+}
+
 func (ghc *GithubContext) loadBranchProtection(ctx context.Context) error {
 	protection, _, err := ghc.client.Repositories.GetBranchProtection(ctx, ghc.owner, ghc.repo, ghc.pr.GetBase().GetRef())
 	if err != nil {
@@ -205,21 +730,48 @@ func isNotFound(err error) bool {
 	return ok && rerr.Response.StatusCode == http.StatusNotFound
 }
 
+func isForbidden(err error) bool {
+	rerr, ok := err.(*github.ErrorResponse)
+	return ok && rerr.Response.StatusCode == http.StatusForbidden
+}
+
 func (ghc *GithubContext) CurrentSuccessStatuses(ctx context.Context) ([]string, error) {
 	if ghc.successStatuses == nil {
-		opts := &github.ListOptions{PerPage: 100}
+		statuses, err := ghc.Statuses(ctx)
+		if err != nil {
+			return nil, err
+		}
+
 		var successStatuses []string
+		for _, s := range statuses {
+			if s.State == "success" {
+				successStatuses = append(successStatuses, s.Context)
+			}
+		}
+		ghc.successStatuses = successStatuses
+	}
+
+	return ghc.successStatuses, nil
+}
+
+func (ghc *GithubContext) Statuses(ctx context.Context) ([]*Status, error) {
+	if ghc.statuses == nil {
+		opts := &github.ListOptions{PerPage: 100}
+		statuses := []*Status{}
 
 		for {
 			combinedStatus, res, err := ghc.client.Repositories.GetCombinedStatus(ctx, ghc.owner, ghc.repo, ghc.pr.GetHead().GetSHA(), opts)
 			if err != nil {
-				return ghc.successStatuses, errors.Wrapf(err, "cannot get combined status for SHA %s on %s", ghc.pr.GetHead().GetSHA(), ghc.Locator())
+				return nil, errors.Wrapf(err, "cannot get combined status for SHA %s on %s", ghc.pr.GetHead().GetSHA(), ghc.Locator())
 			}
 
 			for _, s := range combinedStatus.Statuses {
-				if s.GetState() == "success" {
-					successStatuses = append(successStatuses, s.GetContext())
-				}
+				statuses = append(statuses, &Status{
+					Context:     s.GetContext(),
+					State:       s.GetState(),
+					Description: s.GetDescription(),
+					CompletedAt: s.GetUpdatedAt(),
+				})
 			}
 
 			if res.NextPage == 0 {
@@ -232,13 +784,21 @@ func (ghc *GithubContext) CurrentSuccessStatuses(ctx context.Context) ([]string,
 		for {
 			checkRuns, res, err := ghc.client.Checks.ListCheckRunsForRef(ctx, ghc.owner, ghc.repo, ghc.pr.GetHead().GetSHA(), checkOpts)
 			if err != nil {
-				return ghc.successStatuses, errors.Wrapf(err, "cannot get check runs for SHA %s on %s", ghc.pr.GetHead().GetSHA(), ghc.Locator())
+				return nil, errors.Wrapf(err, "cannot get check runs for SHA %s on %s", ghc.pr.GetHead().GetSHA(), ghc.Locator())
 			}
 
 			for _, s := range checkRuns.CheckRuns {
-				if s.GetConclusion() == "success" {
-					successStatuses = append(successStatuses, s.GetName())
+				state := s.GetStatus()
+				if state == "completed" {
+					state = s.GetConclusion()
 				}
+				statuses = append(statuses, &Status{
+					Context:     s.GetName(),
+					State:       state,
+					App:         s.GetApp().GetSlug(),
+					Description: s.GetOutput().GetTitle(),
+					CompletedAt: s.GetCompletedAt().Time,
+				})
 			}
 
 			if res.NextPage == 0 {
@@ -247,10 +807,175 @@ func (ghc *GithubContext) CurrentSuccessStatuses(ctx context.Context) ([]string,
 			checkOpts.Page = res.NextPage
 		}
 
-		ghc.successStatuses = successStatuses
+		ghc.statuses = statuses
 	}
 
-	return ghc.successStatuses, nil
+	return ghc.statuses, nil
+}
+
+func (ghc *GithubContext) CheckOutputs(ctx context.Context, check string) ([]*CheckOutput, error) {
+	if outputs, ok := ghc.checkOutputs[check]; ok {
+		return outputs, nil
+	}
+
+	outputs := []*CheckOutput{}
+	checkOpts := &github.ListCheckRunsOptions{
+		CheckName:   &check,
+		ListOptions: github.ListOptions{PerPage: 100},
+	}
+	for {
+		checkRuns, res, err := ghc.client.Checks.ListCheckRunsForRef(ctx, ghc.owner, ghc.repo, ghc.pr.GetHead().GetSHA(), checkOpts)
+		if err != nil {
+			return nil, errors.Wrapf(err, "cannot get check runs for SHA %s on %s", ghc.pr.GetHead().GetSHA(), ghc.Locator())
+		}
+
+		for _, r := range checkRuns.CheckRuns {
+			output := &CheckOutput{
+				Title:   r.GetOutput().GetTitle(),
+				Summary: r.GetOutput().GetSummary(),
+			}
+			if r.GetOutput().GetAnnotationsCount() > 0 {
+				annotations, err := ghc.checkAnnotations(ctx, r.GetID())
+				if err != nil {
+					return nil, err
+				}
+				output.Annotations = annotations
+			}
+			outputs = append(outputs, output)
+		}
+
+		if res.NextPage == 0 {
+			break
+		}
+		checkOpts.Page = res.NextPage
+	}
+
+	if ghc.checkOutputs == nil {
+		ghc.checkOutputs = make(map[string][]*CheckOutput)
+	}
+	ghc.checkOutputs[check] = outputs
+	return outputs, nil
+}
+
+func (ghc *GithubContext) checkAnnotations(ctx context.Context, checkRunID int64) ([]*CheckAnnotation, error) {
+	opts := &github.ListOptions{PerPage: 100}
+	var annotations []*CheckAnnotation
+
+	for {
+		as, res, err := ghc.client.Checks.ListCheckRunAnnotations(ctx, ghc.owner, ghc.repo, checkRunID, opts)
+		if err != nil {
+			return nil, errors.Wrapf(err, "cannot list annotations of check run %d on %s", checkRunID, ghc.Locator())
+		}
+
+		for _, a := range as {
+			annotations = append(annotations, &CheckAnnotation{
+				Path:    a.GetPath(),
+				Line:    a.GetStartLine(),
+				Level:   a.GetAnnotationLevel(),
+				Title:   a.GetTitle(),
+				Message: a.GetMessage(),
+			})
+		}
+
+		if res.NextPage == 0 {
+			break
+		}
+		opts.Page = res.NextPage
+	}
+	return annotations, nil
+}
+
+func (ghc *GithubContext) WorkflowRuns(ctx context.Context) ([]*WorkflowRun, error) {
+	if ghc.workflowRuns == nil {
+		// the client does not support filtering runs by head SHA or report
+		// the name of their workflow, so request the runs directly
+		page := 0
+		runs := []*WorkflowRun{}
+
+		for {
+			u := fmt.Sprintf("repos/%s/%s/actions/runs?head_sha=%s&per_page=100", ghc.owner, ghc.repo, ghc.pr.GetHead().GetSHA())
+			if page > 0 {
+				u += fmt.Sprintf("&page=%d", page)
+			}
+			req, err := ghc.client.NewRequest("GET", u, nil)
+			if err != nil {
+				return nil, errors.Wrap(err, "failed to create workflow runs request")
+			}
+
+			var list struct {
+				WorkflowRuns []struct {
+					Name       string    `json:"name"`
+					Status     string    `json:"status"`
+					Conclusion string    `json:"conclusion"`
+					CreatedAt  time.Time `json:"created_at"`
+				} `json:"workflow_runs"`
+			}
+			res, err := ghc.client.Do(ctx, req, &list)
+			if err != nil {
+				return nil, errors.Wrapf(err, "cannot list workflow runs for SHA %s on %s", ghc.pr.GetHead().GetSHA(), ghc.Locator())
+			}
+
+			for _, r := range list.WorkflowRuns {
+				runs = append(runs, &WorkflowRun{
+					Name:       r.Name,
+					Status:     r.Status,
+					Conclusion: r.Conclusion,
+					CreatedAt:  r.CreatedAt,
+				})
+			}
+
+			if res.NextPage == 0 {
+				break
+			}
+			page = res.NextPage
+		}
+
+		ghc.workflowRuns = runs
+	}
+	return ghc.workflowRuns, nil
+}
+
+func (ghc *GithubContext) Deployments(ctx context.Context) ([]*Deployment, error) {
+	if ghc.deployments == nil {
+		opts := &github.DeploymentsListOptions{
+			SHA:         ghc.pr.GetHead().GetSHA(),
+			ListOptions: github.ListOptions{PerPage: 100},
+		}
+		deployments := []*Deployment{}
+
+		for {
+			ds, res, err := ghc.client.Repositories.ListDeployments(ctx, ghc.owner, ghc.repo, opts)
+			if err != nil {
+				return nil, errors.Wrapf(err, "cannot list deployments for SHA %s on %s", ghc.pr.GetHead().GetSHA(), ghc.Locator())
+			}
+
+			for _, d := range ds {
+				// statuses are listed newest first, so only the first is needed
+				statuses, _, err := ghc.client.Repositories.ListDeploymentStatuses(ctx, ghc.owner, ghc.repo, d.GetID(), &github.ListOptions{PerPage: 1})
+				if err != nil {
+					return nil, errors.Wrapf(err, "cannot list statuses for deployment %d on %s", d.GetID(), ghc.Locator())
+				}
+
+				deployment := &Deployment{
+					Environment: d.GetEnvironment(),
+					CreatedAt:   d.GetCreatedAt().Time,
+				}
+				if len(statuses) > 0 {
+					deployment.State = statuses[0].GetState()
+				}
+				deployments = append(deployments, deployment)
+			}
+
+			if res.NextPage == 0 {
+				break
+			}
+			opts.Page = res.NextPage
+		}
+
+		ghc.deployments = deployments
+	}
+
+	return ghc.deployments, nil
 }
 
 func (ghc *GithubContext) Branches() (base string, head string) {
@@ -266,6 +991,39 @@ func (ghc *GithubContext) Branches() (base string, head string) {
 	return
 }
 
+func (ghc *GithubContext) AuthorAssociation() string {
+	return ghc.pr.GetAuthorAssociation()
+}
+
+func (ghc *GithubContext) AuthorContributions(ctx context.Context) (int, error) {
+	if ghc.contributions == nil {
+		author := ghc.pr.GetUser().GetLogin()
+		query := fmt.Sprintf("repo:%s/%s is:pr is:merged author:%s", ghc.owner, ghc.repo, author)
+		opts := &github.SearchOptions{ListOptions: github.ListOptions{PerPage: 1}}
+
+		result, _, err := ghc.client.Search.Issues(ctx, query, opts)
+		if err != nil {
+			return 0, errors.Wrapf(err, "failed to search merged pull requests by %q", author)
+		}
+		total := result.GetTotal()
+		ghc.contributions = &total
+	}
+	return *ghc.contributions, nil
+}
+
+func (ghc *GithubContext) AuthorCreatedAt(ctx context.Context) (time.Time, error) {
+	if ghc.authorCreatedAt == nil {
+		author := ghc.pr.GetUser().GetLogin()
+		user, _, err := ghc.client.Users.Get(ctx, author)
+		if err != nil {
+			return time.Time{}, errors.Wrapf(err, "failed to get user %q", author)
+		}
+		createdAt := user.GetCreatedAt().Time
+		ghc.authorCreatedAt = &createdAt
+	}
+	return *ghc.authorCreatedAt, nil
+}
+
 func (ghc *GithubContext) Labels(ctx context.Context) ([]string, error) {
 	var labelNames []string
 	for _, label := range ghc.pr.Labels {
@@ -274,6 +1032,402 @@ func (ghc *GithubContext) Labels(ctx context.Context) ([]string, error) {
 	return labelNames, nil
 }
 
+func (ghc *GithubContext) PullRequestState(ctx context.Context, number int) (*PullRequestState, error) {
+	if state, ok := ghc.pullRequestStates[number]; ok {
+		return state, nil
+	}
+
+	pr, _, err := ghc.client.PullRequests.Get(ctx, ghc.owner, ghc.repo, number)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get pull request %s/%s#%d", ghc.owner, ghc.repo, number)
+	}
+
+	if ghc.pullRequestStates == nil {
+		ghc.pullRequestStates = make(map[int]*PullRequestState)
+	}
+	ghc.pullRequestStates[number] = &PullRequestState{
+		State:  pr.GetState(),
+		Merged: pr.GetMerged(),
+	}
+	return ghc.pullRequestStates[number], nil
+}
+
+func (ghc *GithubContext) OpenPullRequestsWithHead(ctx context.Context, branch string) ([]int, error) {
+	if numbers, ok := ghc.pullRequestHeads[branch]; ok {
+		return numbers, nil
+	}
+
+	opts := &github.PullRequestListOptions{
+		State:       "open",
+		Head:        fmt.Sprintf("%s:%s", ghc.owner, branch),
+		ListOptions: github.ListOptions{PerPage: 100},
+	}
+
+	numbers := []int{}
+	for {
+		prs, res, err := ghc.client.PullRequests.List(ctx, ghc.owner, ghc.repo, opts)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to list open pull requests with head branch %s", branch)
+		}
+		for _, pr := range prs {
+			numbers = append(numbers, pr.GetNumber())
+		}
+		if res.NextPage == 0 {
+			break
+		}
+		opts.Page = res.NextPage
+	}
+
+	if ghc.pullRequestHeads == nil {
+		ghc.pullRequestHeads = make(map[string][]int)
+	}
+	ghc.pullRequestHeads[branch] = numbers
+	return numbers, nil
+}
+
+func (ghc *GithubContext) Issue(ctx context.Context, number int) (*Issue, error) {
+	if issue, ok := ghc.issues[number]; ok {
+		return issue, nil
+	}
+
+	var issue *Issue
+	i, _, err := ghc.client.Issues.Get(ctx, ghc.owner, ghc.repo, number)
+	switch {
+	case isNotFound(err):
+	case err != nil:
+		return nil, errors.Wrapf(err, "failed to get issue %s/%s#%d", ghc.owner, ghc.repo, number)
+	default:
+		issue = &Issue{State: i.GetState()}
+		for _, label := range i.Labels {
+			issue.Labels = append(issue.Labels, label.GetName())
+		}
+		for _, assignee := range i.Assignees {
+			issue.Assignees = append(issue.Assignees, assignee.GetLogin())
+		}
+	}
+
+	if ghc.issues == nil {
+		ghc.issues = make(map[int]*Issue)
+	}
+	ghc.issues[number] = issue
+	return issue, nil
+}
+
+const discussionQuery = `
+query($owner: String!, $repo: String!, $number: Int!) {
+  repository(owner: $owner, name: $repo) {
+    discussion(number: $number) { id }
+  }
+}`
+
+func (ghc *GithubContext) DiscussionExists(ctx context.Context, owner, repo string, number int) (bool, error) {
+	key := fmt.Sprintf("%s/%s#%d", owner, repo, number)
+	if exists, ok := ghc.discussions[key]; ok {
+		return exists, nil
+	}
+
+	// the REST API does not support repository discussions, so use GraphQL
+	body := map[string]interface{}{
+		"query": discussionQuery,
+		"variables": map[string]interface{}{
+			"owner":  owner,
+			"repo":   repo,
+			"number": number,
+		},
+	}
+	req, err := ghc.client.NewRequest("POST", "../graphql", body)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to create discussion request")
+	}
+
+	var res struct {
+		Data struct {
+			Repository *struct {
+				Discussion *struct {
+					ID string `json:"id"`
+				} `json:"discussion"`
+			} `json:"repository"`
+		} `json:"data"`
+		Errors []struct {
+			Type    string `json:"type"`
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	if _, err := ghc.client.Do(ctx, req, &res); err != nil {
+		return false, errors.Wrapf(err, "failed to get discussion %s", key)
+	}
+	for _, e := range res.Errors {
+		// missing repositories and discussions are reported as errors
+		if e.Type != "NOT_FOUND" {
+			return false, errors.Errorf("failed to get discussion %s: %s", key, e.Message)
+		}
+	}
+
+	exists := res.Data.Repository != nil && res.Data.Repository.Discussion != nil
+	if ghc.discussions == nil {
+		ghc.discussions = make(map[string]bool)
+	}
+	ghc.discussions[key] = exists
+	return exists, nil
+}
+
+func (ghc *GithubContext) RequestedReviewers(ctx context.Context) (*RequestedReviewers, error) {
+	if ghc.reviewers == nil {
+		opts := &github.ListOptions{PerPage: 100}
+		reviewers := &RequestedReviewers{}
+
+		for {
+			page, res, err := ghc.client.PullRequests.ListReviewers(ctx, ghc.owner, ghc.repo, ghc.number, opts)
+			if err != nil {
+				return nil, errors.Wrap(err, "failed to list requested reviewers")
+			}
+
+			for _, u := range page.Users {
+				reviewers.Users = append(reviewers.Users, u.GetLogin())
+			}
+			for _, t := range page.Teams {
+				reviewers.Teams = append(reviewers.Teams, t.GetSlug())
+			}
+
+			if res.NextPage == 0 {
+				break
+			}
+			opts.Page = res.NextPage
+		}
+
+		ghc.reviewers = reviewers
+	}
+	return ghc.reviewers, nil
+}
+
+func (ghc *GithubContext) Reviews(ctx context.Context) ([]*Review, error) {
+	if ghc.reviews == nil {
+		opts := &github.ListOptions{PerPage: 100}
+		reviews := []*Review{}
+
+		for {
+			page, res, err := ghc.client.PullRequests.ListReviews(ctx, ghc.owner, ghc.repo, ghc.number, opts)
+			if err != nil {
+				return nil, errors.Wrap(err, "failed to list pull request reviews")
+			}
+
+			for _, r := range page {
+				reviews = append(reviews, &Review{
+					Author:      r.GetUser().GetLogin(),
+					State:       r.GetState(),
+					SubmittedAt: r.GetSubmittedAt(),
+					CommitID:    r.GetCommitID(),
+				})
+			}
+
+			if res.NextPage == 0 {
+				break
+			}
+			opts.Page = res.NextPage
+		}
+
+		ghc.reviews = reviews
+	}
+	return ghc.reviews, nil
+}
+
+func (ghc *GithubContext) Participants(ctx context.Context) ([]*Participant, error) {
+	comments, err := ghc.AuthoredComments(ctx)
+	if err != nil {
+		return nil, err
+	}
+	reviews, err := ghc.Reviews(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return ParticipantsOf(comments, reviews), nil
+}
+
+const reviewThreadsQuery = `query($owner: String!, $repo: String!, $number: Int!, $cursor: String) {
+  repository(owner: $owner, name: $repo) {
+    pullRequest(number: $number) {
+      reviewThreads(first: 100, after: $cursor) {
+        pageInfo { hasNextPage endCursor }
+        nodes { path line diffSide isResolved }
+      }
+    }
+  }
+}`
+
+func (ghc *GithubContext) ReviewThreads(ctx context.Context) ([]*ReviewThread, error) {
+	if ghc.reviewThreads == nil {
+		// the REST API does not report whether threads are resolved, so use
+		// GraphQL, which is served relative to the REST API on GitHub
+		// Enterprise ("/api/graphql" instead of "/api/v3")
+		var cursor *string
+		threads := []*ReviewThread{}
+
+		for {
+			body := map[string]interface{}{
+				"query": reviewThreadsQuery,
+				"variables": map[string]interface{}{
+					"owner":  ghc.owner,
+					"repo":   ghc.repo,
+					"number": ghc.number,
+					"cursor": cursor,
+				},
+			}
+			req, err := ghc.client.NewRequest("POST", "../graphql", body)
+			if err != nil {
+				return nil, errors.Wrap(err, "failed to create review threads request")
+			}
+
+			var res struct {
+				Data struct {
+					Repository struct {
+						PullRequest struct {
+							ReviewThreads struct {
+								PageInfo struct {
+									HasNextPage bool   `json:"hasNextPage"`
+									EndCursor   string `json:"endCursor"`
+								} `json:"pageInfo"`
+								Nodes []struct {
+									Path       string `json:"path"`
+									Line       *int   `json:"line"`
+									DiffSide   string `json:"diffSide"`
+									IsResolved bool   `json:"isResolved"`
+								} `json:"nodes"`
+							} `json:"reviewThreads"`
+						} `json:"pullRequest"`
+					} `json:"repository"`
+				} `json:"data"`
+				Errors []struct {
+					Message string `json:"message"`
+				} `json:"errors"`
+			}
+			if _, err := ghc.client.Do(ctx, req, &res); err != nil {
+				return nil, errors.Wrap(err, "failed to list pull request review threads")
+			}
+			if len(res.Errors) > 0 {
+				return nil, errors.Errorf("failed to list pull request review threads: %s", res.Errors[0].Message)
+			}
+
+			page := res.Data.Repository.PullRequest.ReviewThreads
+			for _, n := range page.Nodes {
+				thread := &ReviewThread{
+					Path:     n.Path,
+					Side:     n.DiffSide,
+					Resolved: n.IsResolved,
+				}
+				if n.Line != nil {
+					thread.Line = *n.Line
+				}
+				threads = append(threads, thread)
+			}
+
+			if !page.PageInfo.HasNextPage {
+				break
+			}
+			cursor = &page.PageInfo.EndCursor
+		}
+
+		ghc.reviewThreads = threads
+	}
+	return ghc.reviewThreads, nil
+}
+
+func (ghc *GithubContext) TeamMembers(ctx context.Context, team string) ([]string, error) {
+	if members, ok := ghc.teamMembers[team]; ok {
+		return members, nil
+	}
+
+	opts := &github.TeamListTeamMembersOptions{
+		ListOptions: github.ListOptions{PerPage: 100},
+	}
+	members := []string{}
+
+	for {
+		users, res, err := ghc.client.Teams.ListTeamMembersBySlug(ctx, ghc.owner, team, opts)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to list members of team %s/%s", ghc.owner, team)
+		}
+
+		for _, u := range users {
+			members = append(members, u.GetLogin())
+		}
+
+		if res.NextPage == 0 {
+			break
+		}
+		opts.Page = res.NextPage
+	}
+
+	if ghc.teamMembers == nil {
+		ghc.teamMembers = make(map[string][]string)
+	}
+	ghc.teamMembers[team] = members
+	return members, nil
+}
+
+func (ghc *GithubContext) LabelEvents(ctx context.Context) ([]*LabelEvent, error) {
+	if ghc.labelEvents == nil {
+		opts := &github.ListOptions{PerPage: 100}
+		labelEvents := []*LabelEvent{}
+
+		for {
+			events, res, err := ghc.client.Issues.ListIssueEvents(ctx, ghc.owner, ghc.repo, ghc.number, opts)
+			if err != nil {
+				return nil, errors.Wrap(err, "failed to list pull request events")
+			}
+
+			for _, e := range events {
+				if e.GetEvent() != "labeled" {
+					continue
+				}
+				labelEvents = append(labelEvents, &LabelEvent{
+					Label:     e.GetLabel().GetName(),
+					Actor:     e.GetActor().GetLogin(),
+					CreatedAt: e.GetCreatedAt(),
+				})
+			}
+
+			if res.NextPage == 0 {
+				break
+			}
+			opts.Page = res.NextPage
+		}
+
+		ghc.labelEvents = labelEvents
+	}
+	return ghc.labelEvents, nil
+}
+
+func (ghc *GithubContext) Timeline(ctx context.Context) ([]*TimelineEvent, error) {
+	if ghc.timeline == nil {
+		opts := &github.ListOptions{PerPage: 100}
+		timeline := []*TimelineEvent{}
+
+		for {
+			events, res, err := ghc.client.Issues.ListIssueTimeline(ctx, ghc.owner, ghc.repo, ghc.number, opts)
+			if err != nil {
+				return nil, errors.Wrap(err, "failed to list pull request timeline")
+			}
+
+			for _, e := range events {
+				timeline = append(timeline, &TimelineEvent{
+					Event:     e.GetEvent(),
+					Actor:     e.GetActor().GetLogin(),
+					CreatedAt: e.GetCreatedAt(),
+				})
+			}
+
+			if res.NextPage == 0 {
+				break
+			}
+			opts.Page = res.NextPage
+		}
+
+		ghc.timeline = timeline
+	}
+	return ghc.timeline, nil
+}
+
 func (ghc *GithubContext) IsTargeted(ctx context.Context) (bool, error) {
 	ref := fmt.Sprintf("refs/heads/%s", ghc.pr.GetHead().GetRef())
 
@@ -284,5 +1438,90 @@ func (ghc *GithubContext) IsTargeted(ctx context.Context) (bool, error) {
 	return len(prs) > 0, nil
 }
 
+// mergeAttemptsFormat is the format of the hidden marker that is the entire
+// body of the comment that stores the number of consecutive failed merge
+// attempts for a pull request and the head commit they were made with.
+// Deleting the comment resets the count.
+const mergeAttemptsFormat = "<!-- bulldozer:merge-attempts %d %s -->"
+
+// mergeAttemptsMarker matches the marker written with mergeAttemptsFormat.
+// Markers written before the head commit was recorded do not have a SHA.
+var mergeAttemptsMarker = regexp.MustCompile(`<!-- bulldozer:merge-attempts (\d+)(?: ([0-9a-f]+))? -->`)
+
+// isMergeAttemptsState returns true if a comment is a bot comment that only
+// contains a merge attempts marker. These comments are state, not comments
+// that signals should match, and render as empty on GitHub.
+func isMergeAttemptsState(c *github.IssueComment) bool {
+	return c.GetUser().GetType() == "Bot" && strings.TrimSpace(mergeAttemptsMarker.ReplaceAllString(c.GetBody(), "")) == ""
+}
+
+// MergeAttempts returns the number of failed merge attempts recorded for the
+// current head commit. Attempts recorded for an earlier head commit do not
+// count, so pushing new commits resets the count. Only comments created by
+// the app are trusted, so other apps and users cannot forge or reset the
+// count.
+func (ghc *GithubContext) MergeAttempts(ctx context.Context) (int, error) {
+	if !ghc.mergeAttemptsLoaded {
+		if ghc.appLogin == nil {
+			return 0, errors.New("the app login is required to track merge attempts")
+		}
+		login, err := ghc.appLogin(ctx)
+		if err != nil {
+			return 0, errors.Wrap(err, "failed to determine app login")
+		}
+
+		opts := &github.IssueListCommentsOptions{ListOptions: github.ListOptions{PerPage: 100}}
+		for ghc.mergeAttemptsComment == nil {
+			comments, res, err := ghc.client.Issues.ListComments(ctx, ghc.owner, ghc.repo, ghc.number, opts)
+			if err != nil {
+				return 0, errors.Wrap(err, "failed to list issue comments")
+			}
+
+			for _, c := range comments {
+				if c.GetUser().GetLogin() != login || !mergeAttemptsMarker.MatchString(c.GetBody()) {
+					continue
+				}
+				m := mergeAttemptsMarker.FindStringSubmatch(c.GetBody())
+				if attempts, err := strconv.Atoi(m[1]); err == nil && m[2] == ghc.HeadSHA() {
+					ghc.mergeAttempts = attempts
+				}
+				ghc.mergeAttemptsComment = c
+				break
+			}
+
+			if res.NextPage == 0 {
+				break
+			}
+			opts.Page = res.NextPage
+		}
+		ghc.mergeAttemptsLoaded = true
+	}
+
+	return ghc.mergeAttempts, nil
+}
+
+func (ghc *GithubContext) SetMergeAttempts(ctx context.Context, attempts int) error {
+	if _, err := ghc.MergeAttempts(ctx); err != nil {
+		return err
+	}
+
+	body := fmt.Sprintf(mergeAttemptsFormat, attempts, ghc.HeadSHA())
+	comment := &github.IssueComment{Body: &body}
+
+	var err error
+	if ghc.mergeAttemptsComment != nil {
+		comment, _, err = ghc.client.Issues.EditComment(ctx, ghc.owner, ghc.repo, ghc.mergeAttemptsComment.GetID(), comment)
+	} else {
+		comment, _, err = ghc.client.Issues.CreateComment(ctx, ghc.owner, ghc.repo, ghc.number, comment)
+	}
+	if err != nil {
+		return errors.Wrap(err, "failed to record merge attempts")
+	}
+
+	ghc.mergeAttempts = attempts
+	ghc.mergeAttemptsComment = comment
+	return nil
+}
+
 // type assertion
 var _ Context = &GithubContext{}
@@ -16,6 +16,10 @@ package pulltest
 
 import (
 	"context"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
 
 	"github.com/palantir/bulldozer/pull"
 )
@@ -28,35 +32,145 @@ type MockPullContext struct {
 
 	TitleValue   string
 	BodyValue    string
+	CreatorValue string
 	HeadSHAValue string
 	LocatorValue string
 
+	CreatorTypeValue string
+
+	CreatedAtValue time.Time
+
 	BranchBase string
 	BranchName string
 
+	AuthorAssociationValue string
+
+	AuthorContributionsValue    int
+	AuthorContributionsErrValue error
+
+	AuthorCreatedAtValue    time.Time
+	AuthorCreatedAtErrValue error
+
 	MergeStateValue    *pull.MergeState
 	MergeStateErrValue error
 
+	AutoMergeValue    *pull.AutoMerge
+	AutoMergeErrValue error
+
+	MergeSettingsValue    *pull.MergeSettings
+	MergeSettingsErrValue error
+
+	DefaultBranchValue    string
+	DefaultBranchErrValue error
+
+	TopicsValue    []string
+	TopicsErrValue error
+
+	RepositoryPropertiesValue    map[string][]string
+	RepositoryPropertiesErrValue error
+
+	DeploymentsValue    []*pull.Deployment
+	DeploymentsErrValue error
+
+	WorkflowRunsValue    []*pull.WorkflowRun
+	WorkflowRunsErrValue error
+
 	LabelValue    []string
 	LabelErrValue error
 
+	PullRequestStateValue    map[int]*pull.PullRequestState
+	PullRequestStateErrValue error
+
+	// OpenPullRequestsWithHeadValue maps head branches to the numbers of
+	// the open pull requests from them.
+	OpenPullRequestsWithHeadValue    map[string][]int
+	OpenPullRequestsWithHeadErrValue error
+
+	IssuesValue   map[int]*pull.Issue
+	IssueErrValue error
+
+	// DiscussionsValue contains the discussions that exist, keyed like
+	// "owner/repo#123".
+	DiscussionsValue   map[string]bool
+	DiscussionErrValue error
+
+	RequestedReviewersValue    *pull.RequestedReviewers
+	RequestedReviewersErrValue error
+
+	ReviewsValue    []*pull.Review
+	ReviewsErrValue error
+
+	ParticipantsValue    []*pull.Participant
+	ParticipantsErrValue error
+
+	ReviewThreadsValue    []*pull.ReviewThread
+	ReviewThreadsErrValue error
+
+	TeamMembersValue    map[string][]string
+	TeamMembersErrValue error
+
+	LabelEventsValue    []*pull.LabelEvent
+	LabelEventsErrValue error
+
+	TimelineValue    []*pull.TimelineEvent
+	TimelineErrValue error
+
 	CommentValue    []string
 	CommentErrValue error
 
+	AuthoredCommentValue []*pull.Comment
+
+	ReactionsValue    []*pull.Reaction
+	ReactionsErrValue error
+
 	CommitsValue    []*pull.Commit
 	CommitsErrValue error
 
+	ChangedFilesValue    []*pull.File
+	ChangedFilesErrValue error
+
+	FileSizesValue    map[string]int64
+	FileSizesErrValue error
+
+	DiffValue    string
+	DiffErrValue error
+
+	CodeOwnersValue    string
+	CodeOwnersErrValue error
+
 	RequiredStatusesValue    []string
 	RequiredStatusesErrValue error
 
 	PushRestrictionsValue    bool
 	PushRestrictionsErrValue error
 
+	RequiredApprovalsValue    int
+	RequiredApprovalsErrValue error
+
+	RequiresConversationResolutionValue    bool
+	RequiresConversationResolutionErrValue error
+
+	IsBranchProtectedValue    bool
+	IsBranchProtectedErrValue error
+
+	BranchExistsValue    bool
+	BranchExistsErrValue error
+
 	SuccessStatusesValue    []string
 	SuccessStatusesErrValue error
 
+	StatusesValue    []*pull.Status
+	StatusesErrValue error
+
+	CheckOutputsValue    map[string][]*pull.CheckOutput
+	CheckOutputsErrValue error
+
 	IsTargetedValue    bool
 	IsTargetedErrValue error
+
+	MergeAttemptsValue       int
+	MergeAttemptsErrValue    error
+	SetMergeAttemptsErrValue error
 }
 
 func (c *MockPullContext) Owner() string {
@@ -86,26 +200,175 @@ func (c *MockPullContext) Body() string {
 	return c.BodyValue
 }
 
+func (c *MockPullContext) Creator() string {
+	return c.CreatorValue
+}
+
+func (c *MockPullContext) CreatorType() string {
+	return c.CreatorTypeValue
+}
+
 func (c *MockPullContext) HeadSHA() string {
 	return c.HeadSHAValue
 }
 
+func (c *MockPullContext) CreatedAt() time.Time {
+	return c.CreatedAtValue
+}
+
 func (c *MockPullContext) Branches() (base string, head string) {
 	return c.BranchBase, c.BranchName
 }
 
+func (c *MockPullContext) AuthorAssociation() string {
+	return c.AuthorAssociationValue
+}
+
+func (c *MockPullContext) AuthorContributions(ctx context.Context) (int, error) {
+	return c.AuthorContributionsValue, c.AuthorContributionsErrValue
+}
+
+func (c *MockPullContext) AuthorCreatedAt(ctx context.Context) (time.Time, error) {
+	return c.AuthorCreatedAtValue, c.AuthorCreatedAtErrValue
+}
+
 func (c *MockPullContext) MergeState(ctx context.Context) (*pull.MergeState, error) {
 	return c.MergeStateValue, c.MergeStateErrValue
 }
 
+func (c *MockPullContext) AutoMerge(ctx context.Context) (*pull.AutoMerge, error) {
+	return c.AutoMergeValue, c.AutoMergeErrValue
+}
+
+func (c *MockPullContext) MergeSettings(ctx context.Context) (*pull.MergeSettings, error) {
+	return c.MergeSettingsValue, c.MergeSettingsErrValue
+}
+
+func (c *MockPullContext) DefaultBranch(ctx context.Context) (string, error) {
+	return c.DefaultBranchValue, c.DefaultBranchErrValue
+}
+
+func (c *MockPullContext) Topics(ctx context.Context) ([]string, error) {
+	return c.TopicsValue, c.TopicsErrValue
+}
+
+func (c *MockPullContext) RepositoryProperties(ctx context.Context) (map[string][]string, error) {
+	return c.RepositoryPropertiesValue, c.RepositoryPropertiesErrValue
+}
+
+func (c *MockPullContext) Deployments(ctx context.Context) ([]*pull.Deployment, error) {
+	return c.DeploymentsValue, c.DeploymentsErrValue
+}
+
+func (c *MockPullContext) WorkflowRuns(ctx context.Context) ([]*pull.WorkflowRun, error) {
+	return c.WorkflowRunsValue, c.WorkflowRunsErrValue
+}
+
+func (c *MockPullContext) PullRequestState(ctx context.Context, number int) (*pull.PullRequestState, error) {
+	if c.PullRequestStateErrValue != nil {
+		return nil, c.PullRequestStateErrValue
+	}
+	if state, ok := c.PullRequestStateValue[number]; ok {
+		return state, nil
+	}
+	return nil, errors.Errorf("pull request #%d not found", number)
+}
+
+func (c *MockPullContext) OpenPullRequestsWithHead(ctx context.Context, branch string) ([]int, error) {
+	return c.OpenPullRequestsWithHeadValue[branch], c.OpenPullRequestsWithHeadErrValue
+}
+
+func (c *MockPullContext) Issue(ctx context.Context, number int) (*pull.Issue, error) {
+	return c.IssuesValue[number], c.IssueErrValue
+}
+
+func (c *MockPullContext) DiscussionExists(ctx context.Context, owner, repo string, number int) (bool, error) {
+	return c.DiscussionsValue[fmt.Sprintf("%s/%s#%d", owner, repo, number)], c.DiscussionErrValue
+}
+
+func (c *MockPullContext) RequestedReviewers(ctx context.Context) (*pull.RequestedReviewers, error) {
+	if c.RequestedReviewersValue == nil {
+		return &pull.RequestedReviewers{}, c.RequestedReviewersErrValue
+	}
+	return c.RequestedReviewersValue, c.RequestedReviewersErrValue
+}
+
+func (c *MockPullContext) Reviews(ctx context.Context) ([]*pull.Review, error) {
+	return c.ReviewsValue, c.ReviewsErrValue
+}
+
+// Participants returns ParticipantsValue if set. Otherwise, it returns the
+// participants of the comments and reviews of the mock.
+func (c *MockPullContext) Participants(ctx context.Context) ([]*pull.Participant, error) {
+	if c.ParticipantsValue != nil {
+		return c.ParticipantsValue, c.ParticipantsErrValue
+	}
+
+	comments, err := c.AuthoredComments(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return pull.ParticipantsOf(comments, c.ReviewsValue), c.ParticipantsErrValue
+}
+
+func (c *MockPullContext) ReviewThreads(ctx context.Context) ([]*pull.ReviewThread, error) {
+	return c.ReviewThreadsValue, c.ReviewThreadsErrValue
+}
+
+func (c *MockPullContext) TeamMembers(ctx context.Context, team string) ([]string, error) {
+	return c.TeamMembersValue[team], c.TeamMembersErrValue
+}
+
+func (c *MockPullContext) LabelEvents(ctx context.Context) ([]*pull.LabelEvent, error) {
+	return c.LabelEventsValue, c.LabelEventsErrValue
+}
+
+func (c *MockPullContext) Timeline(ctx context.Context) ([]*pull.TimelineEvent, error) {
+	return c.TimelineValue, c.TimelineErrValue
+}
+
 func (c *MockPullContext) Comments(ctx context.Context) ([]string, error) {
 	return c.CommentValue, c.CommentErrValue
 }
 
+// AuthoredComments returns AuthoredCommentValue if set. Otherwise, it returns
+// CommentValue as comments without authors.
+func (c *MockPullContext) AuthoredComments(ctx context.Context) ([]*pull.Comment, error) {
+	if c.AuthoredCommentValue != nil {
+		return c.AuthoredCommentValue, c.CommentErrValue
+	}
+
+	comments := make([]*pull.Comment, len(c.CommentValue))
+	for i, body := range c.CommentValue {
+		comments[i] = &pull.Comment{Body: body}
+	}
+	return comments, c.CommentErrValue
+}
+
+func (c *MockPullContext) Reactions(ctx context.Context) ([]*pull.Reaction, error) {
+	return c.ReactionsValue, c.ReactionsErrValue
+}
+
 func (c *MockPullContext) Commits(ctx context.Context) ([]*pull.Commit, error) {
 	return c.CommitsValue, c.CommitsErrValue
 }
 
+func (c *MockPullContext) ChangedFiles(ctx context.Context) ([]*pull.File, error) {
+	return c.ChangedFilesValue, c.ChangedFilesErrValue
+}
+
+func (c *MockPullContext) FileSizes(ctx context.Context) (map[string]int64, error) {
+	return c.FileSizesValue, c.FileSizesErrValue
+}
+
+func (c *MockPullContext) Diff(ctx context.Context) (string, error) {
+	return c.DiffValue, c.DiffErrValue
+}
+
+func (c *MockPullContext) CodeOwners(ctx context.Context) (string, error) {
+	return c.CodeOwnersValue, c.CodeOwnersErrValue
+}
+
 func (c *MockPullContext) RequiredStatuses(ctx context.Context) ([]string, error) {
 	return c.RequiredStatusesValue, c.RequiredStatusesErrValue
 }
@@ -114,10 +377,34 @@ func (c *MockPullContext) PushRestrictions(ctx context.Context) (bool, error) {
 	return c.PushRestrictionsValue, c.PushRestrictionsErrValue
 }
 
+func (c *MockPullContext) RequiredApprovals(ctx context.Context) (int, error) {
+	return c.RequiredApprovalsValue, c.RequiredApprovalsErrValue
+}
+
+func (c *MockPullContext) RequiresConversationResolution(ctx context.Context) (bool, error) {
+	return c.RequiresConversationResolutionValue, c.RequiresConversationResolutionErrValue
+}
+
+func (c *MockPullContext) IsBranchProtected(ctx context.Context, branch string) (bool, error) {
+	return c.IsBranchProtectedValue, c.IsBranchProtectedErrValue
+}
+
+func (c *MockPullContext) BranchExists(ctx context.Context, branch string) (bool, error) {
+	return c.BranchExistsValue, c.BranchExistsErrValue
+}
+
 func (c *MockPullContext) CurrentSuccessStatuses(ctx context.Context) ([]string, error) {
 	return c.SuccessStatusesValue, c.SuccessStatusesErrValue
 }
 
+func (c *MockPullContext) Statuses(ctx context.Context) ([]*pull.Status, error) {
+	return c.StatusesValue, c.StatusesErrValue
+}
+
+func (c *MockPullContext) CheckOutputs(ctx context.Context, check string) ([]*pull.CheckOutput, error) {
+	return c.CheckOutputsValue[check], c.CheckOutputsErrValue
+}
+
 func (c *MockPullContext) Labels(ctx context.Context) ([]string, error) {
 	return c.LabelValue, c.LabelErrValue
 }
@@ -126,5 +413,19 @@ func (c *MockPullContext) IsTargeted(ctx context.Context) (bool, error) {
 	return c.IsTargetedValue, c.IsTargetedErrValue
 }
 
+func (c *MockPullContext) MergeAttempts(ctx context.Context) (int, error) {
+	return c.MergeAttemptsValue, c.MergeAttemptsErrValue
+}
+
+// SetMergeAttempts updates MergeAttemptsValue unless SetMergeAttemptsErrValue
+// is set.
+func (c *MockPullContext) SetMergeAttempts(ctx context.Context, attempts int) error {
+	if c.SetMergeAttemptsErrValue != nil {
+		return c.SetMergeAttemptsErrValue
+	}
+	c.MergeAttemptsValue = attempts
+	return nil
+}
+
 // type assertion
 var _ pull.Context = &MockPullContext{}
@@ -16,6 +16,7 @@ package server
 
 import (
 	"os"
+	"strings"
 
 	"github.com/c2h5oh/datasize"
 	"github.com/palantir/go-baseapp/baseapp"
@@ -58,6 +59,27 @@ type Options struct {
 	PushRestrictionUserToken string            `yaml:"push_restriction_user_token"`
 
 	ConfigurationV0Paths []string `yaml:"configuration_v0_paths"`
+
+	// InterpolateEnvironment allows repository configurations to reference
+	// environment variables of the server. Since repositories control their
+	// configuration, only variables whose names start with
+	// InterpolationVariablePrefix are visible, so secrets like
+	// BULLDOZER_PUSH_RESTRICTION_USER_TOKEN cannot leak into signal reasons.
+	InterpolateEnvironment bool `yaml:"interpolate_environment"`
+}
+
+// InterpolationVariablePrefix is the prefix of the names of the environment
+// variables that repository configurations may reference when
+// InterpolateEnvironment is enabled.
+const InterpolationVariablePrefix = "BULLDOZER_VAR_"
+
+// lookupInterpolationVariable is a bulldozer.LookupFunc for the environment
+// variables with InterpolationVariablePrefix. Other variables are undefined.
+func lookupInterpolationVariable(name string) (string, bool) {
+	if !strings.HasPrefix(name, InterpolationVariablePrefix) {
+		return "", false
+	}
+	return os.LookupEnv(name)
 }
 
 func ParseConfig(bytes []byte) (*Config, error) {
@@ -0,0 +1,55 @@
+// Copyright 2019 Palantir Technologies, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package handler
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/palantir/go-githubapp/githubapp"
+	"github.com/pkg/errors"
+)
+
+// AppLogin looks up the login of the bot user that the app acts as the first
+// time it is needed, so that GitHub is only queried by deployments that track
+// merge attempts. Failed lookups are not cached and are retried on next use.
+type AppLogin struct {
+	ClientCreator githubapp.ClientCreator
+
+	mu    sync.Mutex
+	login string
+}
+
+// Get returns the login of the app's bot user, like "bulldozer[bot]". It
+// implements pull.AppLoginFunc.
+func (a *AppLogin) Get(ctx context.Context) (string, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.login == "" {
+		client, err := a.ClientCreator.NewAppClient()
+		if err != nil {
+			return "", errors.Wrap(err, "failed to instantiate github app client")
+		}
+
+		app, _, err := client.Apps.Get(ctx, "")
+		if err != nil {
+			return "", errors.Wrap(err, "failed to get github app")
+		}
+		a.login = fmt.Sprintf("%s[bot]", app.GetSlug())
+	}
+	return a.login, nil
+}
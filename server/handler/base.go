@@ -30,9 +30,22 @@ type Base struct {
 	githubapp.ClientCreator
 	bulldozer.ConfigFetcher
 
+	// AppLogin looks up the login of the app's bot user, which is required
+	// to track merge attempts. If it is nil, merge attempts are not tracked.
+	AppLogin *AppLogin
+
 	PushRestrictionUserToken string
 }
 
+// NewPullContext creates the context used to evaluate a pull request.
+func (b *Base) NewPullContext(client *github.Client, pr *github.PullRequest) pull.Context {
+	var appLogin pull.AppLoginFunc
+	if b.AppLogin != nil {
+		appLogin = b.AppLogin.Get
+	}
+	return pull.NewGithubContext(client, pr, appLogin)
+}
+
 func (b *Base) ProcessPullRequest(ctx context.Context, pullCtx pull.Context, client *github.Client, pr *github.PullRequest) error {
 	logger := zerolog.Ctx(ctx)
 
@@ -21,8 +21,6 @@ import (
 	"github.com/google/go-github/v32/github"
 	"github.com/palantir/go-githubapp/githubapp"
 	"github.com/pkg/errors"
-
-	"github.com/palantir/bulldozer/pull"
 )
 
 type CheckRun struct {
@@ -70,7 +68,7 @@ func (h *CheckRun) Handle(ctx context.Context, eventType, deliveryID string, pay
 		if err != nil {
 			return errors.Wrapf(err, "failed to fetch PR number %q for CheckRun", pr.GetNumber())
 		}
-		pullCtx := pull.NewGithubContext(client, fullPR)
+		pullCtx := h.NewPullContext(client, fullPR)
 
 		logger := logger.With().Int(githubapp.LogKeyPRNum, pr.GetNumber()).Logger()
 		if err := h.ProcessPullRequest(logger.WithContext(ctx), pullCtx, client, fullPR); err != nil {
@@ -70,9 +70,15 @@ func New(c *Config) (*Server, error) {
 		return nil, errors.Wrap(err, "failed to initialize Github client creator")
 	}
 
+	configFetcher := bulldozer.NewConfigFetcher(c.Options.ConfigurationPath, c.Options.ConfigurationV0Paths, c.Options.DefaultRepositoryConfig)
+	if c.Options.InterpolateEnvironment {
+		configFetcher = configFetcher.WithLookup(lookupInterpolationVariable)
+	}
+
 	baseHandler := handler.Base{
 		ClientCreator: clientCreator,
-		ConfigFetcher: bulldozer.NewConfigFetcher(c.Options.ConfigurationPath, c.Options.ConfigurationV0Paths, c.Options.DefaultRepositoryConfig),
+		ConfigFetcher: configFetcher,
+		AppLogin:      &handler.AppLogin{ClientCreator: clientCreator},
 
 		PushRestrictionUserToken: c.Options.PushRestrictionUserToken,
 	}